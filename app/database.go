@@ -3,6 +3,7 @@ package app
 import (
 	"fmt"
 	"log"
+	"manajemen_tugas_master/helper"
 	"manajemen_tugas_master/model/domain"
 	"os"
 
@@ -31,9 +32,76 @@ func ConnectDB() (*gorm.DB, error) {
 		&domain.PlanningFile{},
 		&domain.ProjectFile{},
 		&domain.PlanningDescriptionFile{},
+		&domain.StoredObject{},
+		&domain.FileVersion{},
+		&domain.UploadSession{},
+		&domain.UploadSessionPart{},
+		&domain.QuarantinedFile{},
+		&domain.OutboxMessage{},
+		&domain.Notification{},
+		&domain.NotificationPreference{},
+		&domain.Webhook{},
+		&domain.WebhookDelivery{},
+		&domain.WebhookDeliveryAttempt{},
+		&domain.ChatIntegration{},
+		&domain.DueDateReminder{},
+		&domain.EscalationPolicy{},
+		&domain.TaskEscalation{},
+		&domain.CalendarConnection{},
+		&domain.TaskCalendarEvent{},
+		&domain.CalendarFeed{},
 	); err != nil {
 		return nil, err
 	}
 
+	// file lama disimpan dengan nama file sebagai key objek, isi file_key agar tetap bisa diunduh
+	for _, table := range []string{"planning_files", "project_files", "planning_description_files"} {
+		if err := db.Exec("UPDATE " + table + " SET file_key = file_name WHERE file_key IS NULL OR file_key = ''").Error; err != nil {
+			return nil, fmt.Errorf("Failed to migrate file keys of %s: %v", table, err)
+		}
+	}
+
+	// catat objek lama yang belum memiliki stored object, dengan jumlah referensi sesuai jumlah file yang memakainya
+	if err := db.Exec(`INSERT INTO stored_objects (object_key, checksum, size, content_type, ref_count, created_at)
+		SELECT files.file_key, '', 0, '', COUNT(*), NOW() FROM (
+			SELECT file_key FROM planning_files
+			UNION ALL SELECT file_key FROM project_files
+			UNION ALL SELECT file_key FROM planning_description_files
+		) AS files
+		WHERE files.file_key <> '' AND files.file_key NOT IN (SELECT object_key FROM stored_objects)
+		GROUP BY files.file_key`).Error; err != nil {
+		return nil, fmt.Errorf("Failed to migrate stored objects: %v", err)
+	}
+
+	// lengkapi metadata file lama: task pemilik dari tabel penghubung, ukuran, content type dan checksum dari stored object
+	for _, file := range []struct{ table, joinTable, joinKey string }{
+		{"planning_files", "task_planning_files", "planning_file_id"},
+		{"project_files", "task_project_files", "project_file_id"},
+		{"planning_description_files", "task_planning_description_files", "planning_description_file_id"},
+	} {
+		if err := db.Exec("UPDATE " + file.table + " JOIN " + file.joinTable + " ON " + file.joinTable + "." + file.joinKey + " = " + file.table + ".id" +
+			" SET " + file.table + ".task_id = " + file.joinTable + ".task_id WHERE " + file.table + ".task_id IS NULL OR " + file.table + ".task_id = 0").Error; err != nil {
+			return nil, fmt.Errorf("Failed to migrate task id of %s: %v", file.table, err)
+		}
+		if err := db.Exec("UPDATE " + file.table + " JOIN stored_objects ON stored_objects.object_key = " + file.table + ".file_key" +
+			" SET " + file.table + ".size = stored_objects.size, " + file.table + ".content_type = stored_objects.content_type, " + file.table + ".checksum = stored_objects.checksum" +
+			" WHERE (" + file.table + ".checksum IS NULL OR " + file.table + ".checksum = '') AND stored_objects.checksum <> ''").Error; err != nil {
+			return nil, fmt.Errorf("Failed to migrate file metadata of %s: %v", file.table, err)
+		}
+	}
+
+	// objek gambar dan pdf yang tersimpan sebelum ada thumbnail dibuatkan thumbnail oleh worker
+	if err := db.Exec("UPDATE stored_objects SET thumbnail_status = ? WHERE (thumbnail_status IS NULL OR thumbnail_status = '') AND content_type IN ?",
+		domain.ThumbnailStatusPending, helper.ThumbnailContentTypes).Error; err != nil {
+		return nil, fmt.Errorf("Failed to migrate thumbnail status: %v", err)
+	}
+
+	// file yang tersimpan sebelum ada pemindaian virus tidak pernah dipindai
+	for _, table := range []string{"stored_objects", "planning_files", "project_files", "planning_description_files", "file_versions"} {
+		if err := db.Exec("UPDATE "+table+" SET scan_status = ? WHERE scan_status IS NULL OR scan_status = ''", domain.ScanStatusSkipped).Error; err != nil {
+			return nil, fmt.Errorf("Failed to migrate scan status of %s: %v", table, err)
+		}
+	}
+
 	return db, err
 }
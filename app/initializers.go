@@ -2,6 +2,7 @@ package app
 
 import (
 	"manajemen_tugas_master/controller"
+	"manajemen_tugas_master/helper"
 	"manajemen_tugas_master/repository"
 	"manajemen_tugas_master/service"
 
@@ -10,13 +11,18 @@ import (
 	"gorm.io/gorm"
 )
 
+// mail
+func InitializeMailer() (helper.Mailer, error) {
+	return helper.NewMailerFromEnv()
+}
+
 // user
 func InitializeRepositoryUser(db *gorm.DB) (repository.UserRepository, error) {
 	return repository.NewUserRepository(db), nil
 }
 
-func InitializeServiceUser(userRepository repository.UserRepository) (service.UserService, error) {
-	return service.NewUserService(userRepository, validator.New()), nil
+func InitializeServiceUser(userRepository repository.UserRepository, mailer helper.Mailer) (service.UserService, error) {
+	return service.NewUserService(userRepository, mailer, validator.New()), nil
 }
 
 func InitializeControllerUser(userService service.UserService, store *session.Store) (controller.UserController, error) {
@@ -28,22 +34,155 @@ func InitializeRepositoryBoard(db *gorm.DB) (repository.BoardRepository, error)
 	return repository.NewBoardRepository(db), nil
 }
 
-func InitializeServiceBoard(boardRepository repository.BoardRepository) (service.BoardService, error) {
-	return service.NewBoardService(boardRepository), nil
+func InitializeServiceBoard(boardRepository repository.BoardRepository, fileService service.FileService, webhookService service.WebhookService, calendarService service.CalendarService) (service.BoardService, error) {
+	return service.NewBoardService(boardRepository, fileService, webhookService, calendarService), nil
 }
 func InitializeControllerBoard(boardService service.BoardService) (controller.BoardController, error) {
 	return *controller.NewBoardController(boardService), nil
 }
 
+// notification
+func InitializeRepositoryNotification(db *gorm.DB) (repository.NotificationRepository, error) {
+	return repository.NewNotificationRepository(db), nil
+}
+
+func InitializeServiceNotification(notificationRepository repository.NotificationRepository, userRepository repository.UserRepository, chatService service.ChatService, mailer helper.Mailer) (service.NotificationService, error) {
+	return service.NewNotificationService(notificationRepository, userRepository, chatService, mailer), nil
+}
+
+func InitializeControllerNotification(notificationService service.NotificationService) (controller.NotificationController, error) {
+	return *controller.NewNotificationController(notificationService), nil
+}
+
+// chat
+func InitializeRepositoryChat(db *gorm.DB) (repository.ChatRepository, error) {
+	return repository.NewChatRepository(db), nil
+}
+
+func InitializeServiceChat(chatRepository repository.ChatRepository, userRepository repository.UserRepository) (service.ChatService, error) {
+	return service.NewChatService(chatRepository, userRepository), nil
+}
+
+func InitializeControllerChat(chatService service.ChatService) (controller.ChatController, error) {
+	return *controller.NewChatController(chatService), nil
+}
+
+// reminder
+func InitializeRepositoryReminder(db *gorm.DB) (repository.ReminderRepository, error) {
+	return repository.NewReminderRepository(db), nil
+}
+
+func InitializeServiceReminder(reminderRepository repository.ReminderRepository, taskAndOwnerRepository repository.TaskAndOwnerRepository, notificationService service.NotificationService) (service.ReminderService, error) {
+	return service.NewReminderService(reminderRepository, taskAndOwnerRepository, notificationService), nil
+}
+
+// escalation
+func InitializeRepositoryEscalation(db *gorm.DB) (repository.EscalationRepository, error) {
+	return repository.NewEscalationRepository(db), nil
+}
+
+func InitializeServiceEscalation(escalationRepository repository.EscalationRepository, taskAndOwnerRepository repository.TaskAndOwnerRepository, notificationService service.NotificationService) (service.EscalationService, error) {
+	return service.NewEscalationService(escalationRepository, taskAndOwnerRepository, notificationService), nil
+}
+
+func InitializeControllerEscalation(escalationService service.EscalationService) (controller.EscalationController, error) {
+	return *controller.NewEscalationController(escalationService), nil
+}
+
+// calendar
+func InitializeRepositoryCalendar(db *gorm.DB) (repository.CalendarRepository, error) {
+	return repository.NewCalendarRepository(db), nil
+}
+
+func InitializeServiceCalendar(calendarRepository repository.CalendarRepository) (service.CalendarService, error) {
+	return service.NewCalendarService(calendarRepository), nil
+}
+
+func InitializeControllerCalendar(calendarService service.CalendarService, store *session.Store) (controller.CalendarController, error) {
+	return *controller.NewCalendarController(calendarService, store), nil
+}
+
+// calendar feed
+func InitializeRepositoryCalendarFeed(db *gorm.DB) (repository.CalendarFeedRepository, error) {
+	return repository.NewCalendarFeedRepository(db), nil
+}
+
+func InitializeServiceCalendarFeed(calendarFeedRepository repository.CalendarFeedRepository, userRepository repository.UserRepository) (service.CalendarFeedService, error) {
+	return service.NewCalendarFeedService(calendarFeedRepository, userRepository), nil
+}
+
+func InitializeControllerCalendarFeed(calendarFeedService service.CalendarFeedService) (controller.CalendarFeedController, error) {
+	return *controller.NewCalendarFeedController(calendarFeedService), nil
+}
+
+// inbound mail
+func InitializeServiceInboundMail(userRepository repository.UserRepository, taskAndOwnerService service.TaskAndOwnerService) (service.InboundMailService, error) {
+	return service.NewInboundMailService(userRepository, taskAndOwnerService), nil
+}
+
+func InitializeControllerInboundMail(inboundMailService service.InboundMailService) (controller.InboundMailController, error) {
+	return *controller.NewInboundMailController(inboundMailService), nil
+}
+
+// webhook
+func InitializeRepositoryWebhook(db *gorm.DB) (repository.WebhookRepository, error) {
+	return repository.NewWebhookRepository(db), nil
+}
+
+func InitializeServiceWebhook(webhookRepository repository.WebhookRepository) (service.WebhookService, error) {
+	return service.NewWebhookService(webhookRepository), nil
+}
+
+func InitializeControllerWebhook(webhookService service.WebhookService) (controller.WebhookController, error) {
+	return *controller.NewWebhookController(webhookService), nil
+}
+
+// file
+func InitializeStorage() (helper.Storage, error) {
+	return helper.NewStorageFromEnv()
+}
+
+func InitializeRepositoryFile(db *gorm.DB) (repository.FileRepository, error) {
+	return repository.NewFileRepository(db), nil
+}
+
+func InitializeServiceThumbnail(storage helper.Storage, fileRepository repository.FileRepository) (service.ThumbnailService, error) {
+	return service.NewThumbnailService(storage, fileRepository), nil
+}
+
+func InitializeScanner() (helper.Scanner, error) {
+	return helper.NewScannerFromEnv()
+}
+
+func InitializeServiceFile(storage helper.Storage, fileRepository repository.FileRepository, taskAndOwnerRepository repository.TaskAndOwnerRepository, thumbnailService service.ThumbnailService, notificationService service.NotificationService, scanner helper.Scanner) (service.FileService, error) {
+	return service.NewFileService(storage, fileRepository, taskAndOwnerRepository, thumbnailService, notificationService, scanner), nil
+}
+
+func InitializeControllerFile(fileService service.FileService) (controller.FileController, error) {
+	return *controller.NewFileController(fileService), nil
+}
+
+func InitializeRepositoryUpload(db *gorm.DB) (repository.UploadRepository, error) {
+	return repository.NewUploadRepository(db), nil
+}
+
+func InitializeServiceUpload(storage helper.Storage, uploadRepository repository.UploadRepository, fileRepository repository.FileRepository, fileService service.FileService, thumbnailService service.ThumbnailService) (service.UploadService, error) {
+	return service.NewUploadService(storage, uploadRepository, fileRepository, fileService, thumbnailService), nil
+}
+
+func InitializeControllerUpload(uploadService service.UploadService, fileService service.FileService) (controller.UploadController, error) {
+	return *controller.NewUploadController(uploadService, fileService), nil
+}
+
 // task
 func InitializeRepositoryTask(db *gorm.DB) (repository.TaskAndOwnerRepository, error) {
 	return repository.NewTaskAndOwnerRepository(db), nil
 }
 
-func InitializeServiceTask(taskAndOwnerRepository repository.TaskAndOwnerRepository, boardRepository repository.BoardRepository) (service.TaskAndOwnerService, error) {
-	return service.NewTaskAndOwnerService(taskAndOwnerRepository, boardRepository, validator.New()), nil
+func InitializeServiceTask(taskAndOwnerRepository repository.TaskAndOwnerRepository, boardRepository repository.BoardRepository, fileService service.FileService, notificationService service.NotificationService, webhookService service.WebhookService, chatService service.ChatService, calendarService service.CalendarService) (service.TaskAndOwnerService, error) {
+	return service.NewTaskAndOwnerService(taskAndOwnerRepository, boardRepository, fileService, notificationService, webhookService, chatService, calendarService, validator.New()), nil
 }
 
-func InitializeControllerTask(taskAndOwnerService service.TaskAndOwnerService) (controller.TaskAndOwnerController, error) {
-	return *controller.NewTaskController(taskAndOwnerService), nil
+func InitializeControllerTask(taskAndOwnerService service.TaskAndOwnerService, fileService service.FileService, uploadService service.UploadService) (controller.TaskAndOwnerController, error) {
+	return *controller.NewTaskController(taskAndOwnerService, fileService, uploadService), nil
 }
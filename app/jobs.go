@@ -0,0 +1,279 @@
+package app
+
+import (
+	"errors"
+	"log"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/service"
+	"os"
+	"time"
+)
+
+// StartFileGarbageCollector menjalankan garbage collector storage secara berkala setiap STORAGE_GC_INTERVAL (default 24h, 0 untuk menonaktifkan).
+// Jika STORAGE_GC_DRY_RUN=true objek yang tidak terpakai hanya dilaporkan ke log tanpa dihapus
+func StartFileGarbageCollector(fileService service.FileService) {
+	interval := helper.DurationFromEnv("STORAGE_GC_INTERVAL", 24*time.Hour)
+	if interval == 0 {
+		log.Println("Storage garbage collector disabled")
+		return
+	}
+	dryRun := helper.BoolFromEnv("STORAGE_GC_DRY_RUN", false)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			report, err := fileService.CollectGarbage(dryRun)
+			if err != nil {
+				log.Printf("Storage garbage collector failed: %v", err)
+				continue
+			}
+			if report.DryRun {
+				log.Printf("Storage garbage collector (dry-run): scanned %d objects, %d unreferenced: %v", report.Scanned, len(report.Orphaned), report.Orphaned)
+			} else {
+				log.Printf("Storage garbage collector: scanned %d objects, removed %d of %d unreferenced, reconciled %d reference counts", report.Scanned, report.Removed, len(report.Orphaned), report.Reconciled)
+			}
+		}
+	}()
+}
+
+// StartScanWorker memindai ulang file yang diterima saat scanner tidak tersedia setiap SCANNER_RETRY_INTERVAL (default 5m, 0 untuk menonaktifkan)
+func StartScanWorker(fileService service.FileService) {
+	interval := helper.DurationFromEnv("SCANNER_RETRY_INTERVAL", 5*time.Minute)
+	if interval == 0 {
+		log.Println("Virus scan worker disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			scanned, err := fileService.ScanPendingFiles()
+			if err != nil {
+				log.Printf("Virus scan worker failed: %v", err)
+			}
+			if scanned > 0 {
+				log.Printf("Virus scan worker: scanned %d pending files", scanned)
+			}
+		}
+	}()
+}
+
+// StartNotificationWorker mengirim notifikasi pada outbox segera setelah ada pesan baru dan setiap NOTIFICATION_INTERVAL
+// (default 10s, 0 untuk menonaktifkan) untuk pesan yang menunggu dicoba ulang
+func StartNotificationWorker(notificationService service.NotificationService) {
+	interval := helper.DurationFromEnv("NOTIFICATION_INTERVAL", 10*time.Second)
+	if interval == 0 {
+		log.Println("Notification worker disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-notificationService.Scheduled():
+			}
+
+			delivered, err := notificationService.DeliverPending()
+			if err != nil {
+				log.Printf("Notification worker failed: %v", err)
+			}
+			if delivered > 0 {
+				log.Printf("Notification worker: delivered %d notifications", delivered)
+			}
+		}
+	}()
+}
+
+// StartWebhookWorker mengirim webhook segera setelah ada event baru dan setiap WEBHOOK_INTERVAL (default 10s,
+// 0 untuk menonaktifkan) untuk pengiriman yang menunggu dicoba ulang
+func StartWebhookWorker(webhookService service.WebhookService) {
+	interval := helper.DurationFromEnv("WEBHOOK_INTERVAL", 10*time.Second)
+	if interval == 0 {
+		log.Println("Webhook worker disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-webhookService.Scheduled():
+			}
+
+			delivered, err := webhookService.DeliverPending()
+			if err != nil {
+				log.Printf("Webhook worker failed: %v", err)
+			}
+			if delivered > 0 {
+				log.Printf("Webhook worker: delivered %d webhooks", delivered)
+			}
+		}
+	}()
+}
+
+// StartNotificationDigest memeriksa setiap NOTIFICATION_DIGEST_INTERVAL (default 1h, 0 untuk menonaktifkan) apakah ada
+// notifikasi digest yang sudah waktunya dirangkum, lalu mengirim satu email digest per user setelah jam NOTIFICATION_DIGEST_HOUR
+func StartNotificationDigest(notificationService service.NotificationService) {
+	interval := helper.DurationFromEnv("NOTIFICATION_DIGEST_INTERVAL", time.Hour)
+	if interval == 0 {
+		log.Println("Notification digest disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			sent, err := notificationService.SendDigests()
+			if err != nil {
+				log.Printf("Notification digest failed: %v", err)
+			}
+			if sent > 0 {
+				log.Printf("Notification digest: queued %d digest emails", sent)
+			}
+		}
+	}()
+}
+
+// StartDueDateReminders memeriksa setiap REMINDER_INTERVAL (default 5m, 0 untuk menonaktifkan) tenggat task yang
+// sudah memasuki jarak REMINDER_OFFSETS lalu mengirim pengingat ke manager (planning) atau employee (project)
+func StartDueDateReminders(reminderService service.ReminderService) {
+	interval := helper.DurationFromEnv("REMINDER_INTERVAL", 5*time.Minute)
+	if interval == 0 {
+		log.Println("Due date reminders disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			sent, err := reminderService.SendDueDateReminders()
+			if err != nil {
+				log.Printf("Due date reminders failed: %v", err)
+			}
+			if sent > 0 {
+				log.Printf("Due date reminders: queued %d reminders", sent)
+			}
+		}
+	}()
+}
+
+// StartOverdueEscalation menjalankan aturan eskalasi board setiap ESCALATION_INTERVAL (default 15m, 0 untuk menonaktifkan)
+// untuk task yang melewati tenggat project sementara project-nya belum Done
+func StartOverdueEscalation(escalationService service.EscalationService) {
+	interval := helper.DurationFromEnv("ESCALATION_INTERVAL", 15*time.Minute)
+	if interval == 0 {
+		log.Println("Overdue escalation disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			escalated, err := escalationService.EscalateOverdueTasks()
+			if err != nil {
+				log.Printf("Overdue escalation failed: %v", err)
+			}
+			if escalated > 0 {
+				log.Printf("Overdue escalation: recorded %d escalation steps", escalated)
+			}
+		}
+	}()
+}
+
+// StartInboundSMTP menjalankan server SMTP lokal pada INBOUND_SMTP_ADDR (kosong untuk menonaktifkan, default) untuk
+// menerima balasan email saat development tanpa layanan inbound parse
+func StartInboundSMTP(inboundMailService service.InboundMailService) {
+	address := os.Getenv("INBOUND_SMTP_ADDR")
+	if address == "" {
+		return
+	}
+
+	go func() {
+		err := helper.ServeInboundSMTP(address, func(recipients []string, raw []byte) error {
+			reply, err := inboundMailService.ReceiveEmail(raw, recipients)
+			if errors.Is(err, service.ErrAutomaticReply) {
+				return nil
+			}
+			if err != nil {
+				log.Printf("Inbound SMTP: rejected message: %v", err)
+				return err
+			}
+			log.Printf("Inbound SMTP: added reply of user %d as comment on task %d", reply.UserID, reply.TaskID)
+			return nil
+		})
+		log.Printf("Inbound SMTP stopped: %v", err)
+	}()
+}
+
+// StartThumbnailWorker membuat thumbnail gambar dan pdf di belakang layar, segera setelah ada upload baru
+// dan setiap THUMBNAIL_INTERVAL (default 1m, 0 untuk menonaktifkan) untuk objek yang tertunda
+func StartThumbnailWorker(thumbnailService service.ThumbnailService) {
+	interval := helper.DurationFromEnv("THUMBNAIL_INTERVAL", time.Minute)
+	if interval == 0 {
+		log.Println("Thumbnail worker disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-thumbnailService.Scheduled():
+			}
+
+			generated, err := thumbnailService.GeneratePending()
+			if err != nil {
+				log.Printf("Thumbnail worker failed: %v", err)
+			}
+			if generated > 0 {
+				log.Printf("Thumbnail worker: generated %d thumbnails", generated)
+			}
+		}
+	}()
+}
+
+// StartUploadCleanup membatalkan resumable upload yang kedaluwarsa setiap UPLOAD_CLEANUP_INTERVAL (default 1h, 0 untuk menonaktifkan),
+// part yang sudah terkirim dihapus dari storage dan upload yang selesai tetapi tidak pernah dilampirkan dilepas referensinya
+func StartUploadCleanup(uploadService service.UploadService) {
+	interval := helper.DurationFromEnv("UPLOAD_CLEANUP_INTERVAL", time.Hour)
+	if interval == 0 {
+		log.Println("Upload cleanup disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			removed, err := uploadService.CleanupExpiredUploads()
+			if err != nil {
+				log.Printf("Upload cleanup failed: %v", err)
+				continue
+			}
+			if removed > 0 {
+				log.Printf("Upload cleanup: removed %d expired uploads", removed)
+			}
+		}
+	}()
+}
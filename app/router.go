@@ -1,6 +1,7 @@
 package app
 
 import (
+	"log"
 	"manajemen_tugas_master/middleware"
 
 	"github.com/gofiber/fiber/v2"
@@ -9,20 +10,90 @@ import (
 )
 
 func SetupRoutes(app *fiber.App, db *gorm.DB, store *session.Store) {
+	mailer, err := InitializeMailer()
+	if err != nil {
+		log.Fatalf("Failed to initialize mailer: %v", err)
+	}
+
 	// user initialize
 	userRepository, _ := InitializeRepositoryUser(db)
-	userService, _ := InitializeServiceUser(userRepository)
+	userService, _ := InitializeServiceUser(userRepository, mailer)
 	userController, _ := InitializeControllerUser(userService, store)
 
+	// chat integration initialize
+	chatRepository, _ := InitializeRepositoryChat(db)
+	chatService, _ := InitializeServiceChat(chatRepository, userRepository)
+	chatController, _ := InitializeControllerChat(chatService)
+
+	// notification initialize
+	notificationRepository, _ := InitializeRepositoryNotification(db)
+	notificationService, _ := InitializeServiceNotification(notificationRepository, userRepository, chatService, mailer)
+	notificationController, _ := InitializeControllerNotification(notificationService)
+	StartNotificationWorker(notificationService)
+	StartNotificationDigest(notificationService)
+
+	// file initialize
+	taskRepository, _ := InitializeRepositoryTask(db)
+	storage, err := InitializeStorage()
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
+	scanner, err := InitializeScanner()
+	if err != nil {
+		log.Fatalf("Failed to initialize virus scanner: %v", err)
+	}
+	fileRepository, _ := InitializeRepositoryFile(db)
+	thumbnailService, _ := InitializeServiceThumbnail(storage, fileRepository)
+	fileService, _ := InitializeServiceFile(storage, fileRepository, taskRepository, thumbnailService, notificationService, scanner)
+	fileController, _ := InitializeControllerFile(fileService)
+	StartFileGarbageCollector(fileService)
+	StartThumbnailWorker(thumbnailService)
+	StartScanWorker(fileService)
+
+	// resumable upload initialize
+	uploadRepository, _ := InitializeRepositoryUpload(db)
+	uploadService, _ := InitializeServiceUpload(storage, uploadRepository, fileRepository, fileService, thumbnailService)
+	uploadController, _ := InitializeControllerUpload(uploadService, fileService)
+	StartUploadCleanup(uploadService)
+
+	// webhook initialize
+	webhookRepository, _ := InitializeRepositoryWebhook(db)
+	webhookService, _ := InitializeServiceWebhook(webhookRepository)
+	webhookController, _ := InitializeControllerWebhook(webhookService)
+	StartWebhookWorker(webhookService)
+
+	// google calendar initialize
+	calendarRepository, _ := InitializeRepositoryCalendar(db)
+	calendarService, _ := InitializeServiceCalendar(calendarRepository)
+	calendarController, _ := InitializeControllerCalendar(calendarService, store)
+	calendarFeedRepository, _ := InitializeRepositoryCalendarFeed(db)
+	calendarFeedService, _ := InitializeServiceCalendarFeed(calendarFeedRepository, userRepository)
+	calendarFeedController, _ := InitializeControllerCalendarFeed(calendarFeedService)
+
 	// board initialize
 	boardRepository, _ := InitializeRepositoryBoard(db)
-	boardService, _ := InitializeServiceBoard(boardRepository)
+	boardService, _ := InitializeServiceBoard(boardRepository, fileService, webhookService, calendarService)
 	boardController, _ := InitializeControllerBoard(boardService)
 
 	// task initialize
-	taskRepository, _ := InitializeRepositoryTask(db)
-	taskService, _ := InitializeServiceTask(taskRepository, boardRepository)
-	taskController, _ := InitializeControllerTask(taskService)
+	taskService, _ := InitializeServiceTask(taskRepository, boardRepository, fileService, notificationService, webhookService, chatService, calendarService)
+	taskController, _ := InitializeControllerTask(taskService, fileService, uploadService)
+
+	// due date reminder initialize
+	reminderRepository, _ := InitializeRepositoryReminder(db)
+	reminderService, _ := InitializeServiceReminder(reminderRepository, taskRepository, notificationService)
+	StartDueDateReminders(reminderService)
+
+	// overdue escalation initialize
+	escalationRepository, _ := InitializeRepositoryEscalation(db)
+	escalationService, _ := InitializeServiceEscalation(escalationRepository, taskRepository, notificationService)
+	escalationController, _ := InitializeControllerEscalation(escalationService)
+	StartOverdueEscalation(escalationService)
+
+	// reply by email initialize
+	inboundMailService, _ := InitializeServiceInboundMail(userRepository, taskService)
+	inboundMailController, _ := InitializeControllerInboundMail(inboundMailService)
+	StartInboundSMTP(inboundMailService)
 
 	app.Get("/", func(c *fiber.Ctx) error {
 		tokenStringJwt := c.Cookies("Authorization")
@@ -38,6 +109,12 @@ func SetupRoutes(app *fiber.App, db *gorm.DB, store *session.Store) {
 		})
 	})
 
+	// Route email masuk tanpa login, diautentikasi dengan INBOUND_MAIL_TOKEN dan alamat balasan bertanda tangan
+	app.Post("/inbound/email", inboundMailController.ReceiveInboundEmail)
+
+	// Feed iCalendar tanpa login, diautentikasi dengan token rahasia pada url
+	app.Get("/calendar/:token.ics", calendarFeedController.GetCalendarFeedICS)
+
 	// Group route untuk user
 	userRoutes := app.Group("/")
 	userRoutes.Post("user/signup", userController.SignupUser)
@@ -45,6 +122,8 @@ func SetupRoutes(app *fiber.App, db *gorm.DB, store *session.Store) {
 	userRoutes.Get("users", userController.GetAllUsers)
 	userRoutes.Get("auth/oauth", userController.GoogleOauth)
 	userRoutes.Get("auth/callback", userController.GoogleCallback)
+	// redirect Google tidak membawa header Authorization, user dikenali dari sesi yang dibuat saat connect
+	userRoutes.Get("calendar/google/callback", calendarController.GoogleCalendarCallback)
 	userRoutes.Post("user/forgot-password", userController.ForgotPassword)
 	userRoutes.Post("user/reset-password", userController.ResetPassword)
 	userRoutes.Get("user/:id", userController.GetUserByID)
@@ -60,6 +139,22 @@ func SetupRoutes(app *fiber.App, db *gorm.DB, store *session.Store) {
 	boardRoutes.Get("boards", boardController.GetAllBoards)
 	boardRoutes.Put("board/:id", boardController.EditBoard)
 	boardRoutes.Delete("board/:id", boardController.DeleteBoardById)
+	boardRoutes.Get("board/:id/files/archive", fileController.DownloadBoardArchive)
+	boardRoutes.Post("board/:boardId/webhooks", webhookController.CreateWebhook)
+	boardRoutes.Get("board/:boardId/webhooks", webhookController.GetWebhooks)
+	boardRoutes.Delete("board/:boardId/webhooks/:webhookId", webhookController.DeleteWebhook)
+	boardRoutes.Get("board/:boardId/webhooks/:webhookId/deliveries", webhookController.GetWebhookDeliveries)
+	boardRoutes.Get("board/:boardId/webhooks/:webhookId/deliveries/:deliveryId", webhookController.GetWebhookDelivery)
+	boardRoutes.Post("board/:boardId/webhooks/:webhookId/deliveries/:deliveryId/redeliver", webhookController.RedeliverWebhookDelivery)
+	boardRoutes.Post("board/:boardId/chat-integrations", chatController.CreateChatIntegration)
+	boardRoutes.Get("board/:boardId/chat-integrations", chatController.GetChatIntegrations)
+	boardRoutes.Put("board/:boardId/chat-integrations/:integrationId", chatController.UpdateChatIntegration)
+	boardRoutes.Delete("board/:boardId/chat-integrations/:integrationId", chatController.DeleteChatIntegration)
+	boardRoutes.Post("board/:boardId/chat-integrations/:integrationId/test", chatController.TestChatIntegration)
+	boardRoutes.Get("board/:boardId/escalation-policy", escalationController.GetEscalationPolicy)
+	boardRoutes.Put("board/:boardId/escalation-policy", escalationController.UpdateEscalationPolicy)
+	boardRoutes.Get("board/:boardId/calendar-feed", calendarFeedController.GetBoardCalendarFeed)
+	boardRoutes.Post("board/:boardId/calendar-feed/rotate", calendarFeedController.RotateBoardCalendarFeed)
 
 	// Group route untuk task
 	taskRoutes := app.Group("/")
@@ -81,4 +176,36 @@ func SetupRoutes(app *fiber.App, db *gorm.DB, store *session.Store) {
 	taskRoutes.Delete("task/:id/planning-file/:file_id", taskController.DeletePlanningFile)
 	taskRoutes.Delete("task/:id/project-file/:file_id", taskController.DeleteProjectFile)
 	taskRoutes.Delete("task/:id", taskController.DeleteTaskAndOwner)
+	taskRoutes.Get("task/:id/files/archive", fileController.DownloadTaskArchive)
+	taskRoutes.Get("task/:id/files/:file_id/download", fileController.DownloadFile)
+	taskRoutes.Get("task/:id/files/:file_id/thumbnail", fileController.DownloadThumbnail)
+	taskRoutes.Get("task/:id/files/:file_id/versions", fileController.GetFileVersions)
+	taskRoutes.Get("task/:id/files/:file_id/versions/diff", fileController.DiffFileVersions)
+	taskRoutes.Get("task/:id/files/:file_id/versions/:version/download", fileController.DownloadFileVersion)
+	taskRoutes.Post("task/:id/files/:file_id/versions/:version/restore", fileController.RestoreFileVersion)
+	taskRoutes.Post("task/:id/uploads", uploadController.CreateUpload)
+	taskRoutes.Get("uploads/:upload_id", uploadController.GetUpload)
+	taskRoutes.Patch("uploads/:upload_id", uploadController.UploadChunk)
+	taskRoutes.Post("uploads/:upload_id/finalize", uploadController.FinalizeUpload)
+	taskRoutes.Delete("uploads/:upload_id", uploadController.CancelUpload)
+	taskRoutes.Get("notifications", notificationController.GetUserNotifications)
+	taskRoutes.Get("notifications/unread-count", notificationController.GetUnreadNotificationCount)
+	taskRoutes.Put("notifications/read-all", notificationController.MarkAllNotificationsRead)
+	taskRoutes.Get("notifications/preferences", notificationController.GetNotificationPreferences)
+	taskRoutes.Put("notifications/preferences", notificationController.UpdateNotificationPreferences)
+	taskRoutes.Put("notifications/:id/read", notificationController.MarkNotificationRead)
+	taskRoutes.Get("calendar/google", calendarController.GetGoogleCalendarConnection)
+	taskRoutes.Get("calendar/google/connect", calendarController.ConnectGoogleCalendar)
+	taskRoutes.Delete("calendar/google", calendarController.DisconnectGoogleCalendar)
+	taskRoutes.Get("calendar/feed", calendarFeedController.GetCalendarFeed)
+	taskRoutes.Post("calendar/feed/rotate", calendarFeedController.RotateCalendarFeed)
+
+	// Group route untuk admin
+	adminRoutes := app.Group("/admin")
+	adminRoutes.Use(middleware.AuthUser(userService, store), middleware.AdminUser())
+	adminRoutes.Get("notifications", notificationController.GetNotifications)
+	adminRoutes.Get("notifications/:id", notificationController.GetNotificationByID)
+	adminRoutes.Post("notifications/:id/retry", notificationController.RetryNotification)
+	adminRoutes.Get("email-templates", notificationController.GetEmailTemplates)
+	adminRoutes.Get("email-templates/:name/preview", notificationController.PreviewEmailTemplate)
 }
@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"crypto/subtle"
+	"errors"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/web"
+	"manajemen_tugas_master/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+)
+
+// key sesi untuk state OAuth Google Calendar dan user yang memulai persetujuan
+const (
+	calendarStateKey = "calendar_state"
+	calendarUserKey  = "calendar_user"
+)
+
+type CalendarController struct {
+	calendarService service.CalendarService
+	store           *session.Store
+}
+
+func NewCalendarController(calendarService service.CalendarService, store *session.Store) *CalendarController {
+	return &CalendarController{calendarService, store}
+}
+
+// ConnectGoogleCalendar godoc
+// @Summary Connect Google Calendar
+// @Description Redirect to the Google consent page to connect the Google Calendar of the logged in user. After consent Google redirects to the callback endpoint which stores the connection. Due date events of tasks owned by the user are created in this calendar with the managers or employees as attendees, one event per task and due date type: changing the due date updates the event, attendees follow the managers and employees of the task and deleting the task or its board cancels the event. Tasks of owners who have not connected a calendar get no event. This endpoint requires cookie authentication.
+// @Tags calendar
+// @Produce json
+// @Security CookieAuth
+// @Success 302 "Redirect to the Google consent page"
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Failure 503 {object} web.ErrorResponse "Google Calendar is not configured"
+// @Router /calendar/google/connect [get]
+func (c *CalendarController) ConnectGoogleCalendar(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	state := helper.GenerateRandomState()
+	authURL, err := c.calendarService.AuthURL(state)
+	if err != nil {
+		return ctx.Status(calendarErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	sess, err := c.store.Get(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal mendapatkan sesi"})
+	}
+	sess.Set(calendarStateKey, state)
+	sess.Set(calendarUserKey, userID)
+	if err := sess.Save(); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal menyimpan sesi"})
+	}
+
+	return ctx.Redirect(authURL)
+}
+
+// GoogleCalendarCallback godoc
+// @Summary Google Calendar OAuth callback
+// @Description Google redirects the browser here after the consent page, without the authorization headers. The user is identified by the session created by the connect request and the state must match that request; it can only be used once. The refresh token is stored encrypted and access tokens are refreshed automatically. Connecting again replaces the previous connection.
+// @Tags calendar
+// @Produce json
+// @Param code query string true "Authorization code from Google"
+// @Param state query string true "State from the connect request"
+// @Success 200 {object} web.WebResponse{data=domain.CalendarConnection}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Failure 503 {object} web.ErrorResponse "Google Calendar is not configured"
+// @Router /calendar/google/callback [get]
+func (c *CalendarController) GoogleCalendarCallback(ctx *fiber.Ctx) error {
+	sess, err := c.store.Get(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal mendapatkan sesi"})
+	}
+	state, _ := sess.Get(calendarStateKey).(string)
+	userID, _ := sess.Get(calendarUserKey).(uint64)
+	// state hanya berlaku sekali
+	sess.Delete(calendarStateKey)
+	sess.Delete(calendarUserKey)
+	if err := sess.Save(); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal menyimpan sesi"})
+	}
+
+	if state == "" || userID == 0 || subtle.ConstantTimeCompare([]byte(state), []byte(ctx.Query("state"))) != 1 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid or expired calendar connection request"})
+	}
+	if errorCode := ctx.Query("error"); errorCode != "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Google Calendar access was not granted: " + errorCode})
+	}
+	code := ctx.Query("code")
+	if code == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Authorization code is required"})
+	}
+
+	connection, err := c.calendarService.Connect(userID, code)
+	if err != nil {
+		return ctx.Status(calendarErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Google Calendar connected",
+		Data:    connection,
+	})
+}
+
+// GetGoogleCalendarConnection godoc
+// @Summary Get the Google Calendar connection
+// @Description Get the Google account connected as calendar of the logged in user. This endpoint requires cookie authentication.
+// @Tags calendar
+// @Produce json
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=domain.CalendarConnection}
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse "Google Calendar is not connected"
+// @Failure 500 {object} web.ErrorResponse
+// @Router /calendar/google [get]
+func (c *CalendarController) GetGoogleCalendarConnection(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	connection, err := c.calendarService.GetConnection(userID)
+	if err != nil {
+		return ctx.Status(calendarErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    connection,
+	})
+}
+
+// DisconnectGoogleCalendar godoc
+// @Summary Disconnect Google Calendar
+// @Description Revoke the access of the application in the Google account and delete the stored tokens. Due date events are no longer created for tasks owned by the user. This endpoint requires cookie authentication.
+// @Tags calendar
+// @Produce json
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse "Google Calendar is not connected"
+// @Failure 500 {object} web.ErrorResponse
+// @Router /calendar/google [delete]
+func (c *CalendarController) DisconnectGoogleCalendar(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := c.calendarService.Disconnect(userID); err != nil {
+		return ctx.Status(calendarErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Google Calendar disconnected",
+	})
+}
+
+func calendarErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCalendarAuthorization), errors.Is(err, service.ErrCalendarNoRefreshToken):
+		return fiber.StatusBadRequest
+	case errors.Is(err, service.ErrCalendarNotConnected):
+		return fiber.StatusNotFound
+	case errors.Is(err, service.ErrCalendarNotConfigured):
+		return fiber.StatusServiceUnavailable
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"errors"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/web"
+	"manajemen_tugas_master/service"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CalendarFeedController struct {
+	calendarFeedService service.CalendarFeedService
+}
+
+func NewCalendarFeedController(calendarFeedService service.CalendarFeedService) *CalendarFeedController {
+	return &CalendarFeedController{calendarFeedService}
+}
+
+// GetCalendarFeedICS godoc
+// @Summary Download an iCalendar feed
+// @Description Get the iCalendar (RFC 5545) feed of a secret feed url, to subscribe to from any calendar application. Every planning and project due date is an all-day event whose UID stays the same for the task and due date type, so calendar applications update events instead of duplicating them. A user feed contains the tasks where the user is owner, manager or employee; a board feed contains every task of the board. The token in the url is the only authentication; rotating the token disables the old url.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Secret feed token"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /calendar/{token}.ics [get]
+func (c *CalendarFeedController) GetCalendarFeedICS(ctx *fiber.Ctx) error {
+	feed, err := c.calendarFeedService.RenderFeed(ctx.Params("token"))
+	if err != nil {
+		return ctx.Status(calendarFeedErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	ctx.Set(fiber.HeaderContentDisposition, `inline; filename="calendar.ics"`)
+	ctx.Set(fiber.HeaderCacheControl, "private, max-age=300")
+	return ctx.Status(fiber.StatusOK).Send(feed)
+}
+
+// GetCalendarFeed godoc
+// @Summary Get the user calendar feed url
+// @Description Get the secret iCalendar feed url with the planning and project due dates of every task where the logged in user is owner, manager or employee. The url is created on the first request. This endpoint requires cookie authentication.
+// @Tags calendar
+// @Produce json
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=web.CalendarFeedResponse}
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /calendar/feed [get]
+func (c *CalendarFeedController) GetCalendarFeed(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	feed, err := c.calendarFeedService.GetFeed(userID, 0)
+	if err != nil {
+		return ctx.Status(calendarFeedErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    feed,
+	})
+}
+
+// RotateCalendarFeed godoc
+// @Summary Rotate the user calendar feed url
+// @Description Replace the token of the user calendar feed. The previous url stops working immediately and calendar applications have to subscribe to the new url. This endpoint requires cookie authentication.
+// @Tags calendar
+// @Produce json
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=web.CalendarFeedResponse}
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /calendar/feed/rotate [post]
+func (c *CalendarFeedController) RotateCalendarFeed(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	feed, err := c.calendarFeedService.RotateFeed(userID, 0)
+	if err != nil {
+		return ctx.Status(calendarFeedErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Calendar feed rotated",
+		Data:    feed,
+	})
+}
+
+// GetBoardCalendarFeed godoc
+// @Summary Get the board calendar feed url
+// @Description Get the secret iCalendar feed url with the planning and project due dates of every task of the board. The url is created on the first request. Only the board owner can manage the board feed. This endpoint requires cookie authentication.
+// @Tags calendar
+// @Produce json
+// @Param boardId path int true "Board ID parameter" minimum(1) example(1)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=web.CalendarFeedResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /board/{boardId}/calendar-feed [get]
+func (c *CalendarFeedController) GetBoardCalendarFeed(ctx *fiber.Ctx) error {
+	userID, boardID, status, err := calendarFeedParams(ctx)
+	if err != nil {
+		return ctx.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	feed, err := c.calendarFeedService.GetFeed(userID, boardID)
+	if err != nil {
+		return ctx.Status(calendarFeedErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    feed,
+	})
+}
+
+// RotateBoardCalendarFeed godoc
+// @Summary Rotate the board calendar feed url
+// @Description Replace the token of the board calendar feed. The previous url stops working immediately. Only the board owner can manage the board feed. This endpoint requires cookie authentication.
+// @Tags calendar
+// @Produce json
+// @Param boardId path int true "Board ID parameter" minimum(1) example(1)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=web.CalendarFeedResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /board/{boardId}/calendar-feed/rotate [post]
+func (c *CalendarFeedController) RotateBoardCalendarFeed(ctx *fiber.Ctx) error {
+	userID, boardID, status, err := calendarFeedParams(ctx)
+	if err != nil {
+		return ctx.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	feed, err := c.calendarFeedService.RotateFeed(userID, boardID)
+	if err != nil {
+		return ctx.Status(calendarFeedErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Calendar feed rotated",
+		Data:    feed,
+	})
+}
+
+// calendarFeedParams membaca user yang login dan id board dari path beserta status respons jika gagal
+func calendarFeedParams(ctx *fiber.Ctx) (userID uint64, boardID uint64, status int, err error) {
+	userID, err = helper.GetCtxLocals(ctx)
+	if err != nil {
+		return 0, 0, fiber.StatusUnauthorized, err
+	}
+	boardID, err = strconv.ParseUint(ctx.Params("boardId"), 10, 64)
+	if err != nil || boardID == 0 {
+		return 0, 0, fiber.StatusBadRequest, errors.New("Invalid board Id")
+	}
+	return userID, boardID, fiber.StatusOK, nil
+}
+
+func calendarFeedErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrNotBoardOwner):
+		return fiber.StatusForbidden
+	case errors.Is(err, service.ErrBoardNotFound), errors.Is(err, service.ErrCalendarFeedNotFound):
+		return fiber.StatusNotFound
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
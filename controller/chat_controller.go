@@ -0,0 +1,234 @@
+package controller
+
+import (
+	"errors"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/web"
+	"manajemen_tugas_master/service"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ChatController struct {
+	chatService service.ChatService
+}
+
+func NewChatController(chatService service.ChatService) *ChatController {
+	return &ChatController{chatService}
+}
+
+// CreateChatIntegration godoc
+// @Summary Connect a board to a chat channel
+// @Description Connect the board to a Slack or Discord incoming webhook. The url must be an incoming webhook url on hooks.slack.com for Slack or discord.com for Discord, or on a host listed in CHAT_WEBHOOK_HOSTS. Task changes made with the task update endpoint and invitation responses are posted to the channel as formatted messages with the task name, who made the change and links, for the selected event types (name, status, comment, file, due_date, invitation; empty for all). Messages are written in the integration locale (en or id) and delivered through the notification outbox with retries. The webhook url is only shown masked afterwards. Only the board owner can manage chat integrations. This endpoint requires cookie authentication.
+// @Tags chat
+// @Accept json
+// @Produce json
+// @Param boardId path int true "Board ID parameter" minimum(1) example(1)
+// @Param integration body web.ChatIntegrationRequest true "Chat integration"
+// @Security CookieAuth
+// @Success 201 {object} web.WebResponse{data=web.ChatIntegrationResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /board/{boardId}/chat-integrations [post]
+func (c *ChatController) CreateChatIntegration(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+	boardID, err := strconv.ParseUint(ctx.Params("boardId"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid board Id"})
+	}
+
+	var request web.ChatIntegrationRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	integration, err := c.chatService.CreateIntegration(userID, boardID, &request)
+	if err != nil {
+		return ctx.Status(chatErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    201,
+		Message: "Chat integration created",
+		Data:    integration,
+	})
+}
+
+// GetChatIntegrations godoc
+// @Summary List board chat integrations
+// @Description List the Slack and Discord integrations of the board with their locale, event types and masked webhook url. Only the board owner can manage chat integrations. This endpoint requires cookie authentication.
+// @Tags chat
+// @Produce json
+// @Param boardId path int true "Board ID parameter" minimum(1) example(1)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=[]web.ChatIntegrationResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /board/{boardId}/chat-integrations [get]
+func (c *ChatController) GetChatIntegrations(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+	boardID, err := strconv.ParseUint(ctx.Params("boardId"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid board Id"})
+	}
+
+	integrations, err := c.chatService.GetIntegrations(userID, boardID)
+	if err != nil {
+		return ctx.Status(chatErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    integrations,
+	})
+}
+
+// UpdateChatIntegration godoc
+// @Summary Update a board chat integration
+// @Description Change the locale, the event types or pause and resume a chat integration. Fields that are not sent keep their value; an empty events list selects every event type. Only the board owner can manage chat integrations. This endpoint requires cookie authentication.
+// @Tags chat
+// @Accept json
+// @Produce json
+// @Param boardId path int true "Board ID parameter" minimum(1) example(1)
+// @Param integrationId path int true "Chat integration ID parameter" minimum(1) example(1)
+// @Param integration body web.UpdateChatIntegrationRequest true "Chat integration settings"
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=web.ChatIntegrationResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /board/{boardId}/chat-integrations/{integrationId} [put]
+func (c *ChatController) UpdateChatIntegration(ctx *fiber.Ctx) error {
+	userID, boardID, integrationID, status, err := chatParams(ctx)
+	if err != nil {
+		return ctx.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	var request web.UpdateChatIntegrationRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	integration, err := c.chatService.UpdateIntegration(userID, boardID, integrationID, &request)
+	if err != nil {
+		return ctx.Status(chatErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Chat integration updated",
+		Data:    integration,
+	})
+}
+
+// DeleteChatIntegration godoc
+// @Summary Delete a board chat integration
+// @Description Disconnect a chat channel from the board. Messages that are still waiting in the outbox for this integration are dropped. Only the board owner can manage chat integrations. This endpoint requires cookie authentication.
+// @Tags chat
+// @Produce json
+// @Param boardId path int true "Board ID parameter" minimum(1) example(1)
+// @Param integrationId path int true "Chat integration ID parameter" minimum(1) example(1)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /board/{boardId}/chat-integrations/{integrationId} [delete]
+func (c *ChatController) DeleteChatIntegration(ctx *fiber.Ctx) error {
+	userID, boardID, integrationID, status, err := chatParams(ctx)
+	if err != nil {
+		return ctx.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := c.chatService.DeleteIntegration(userID, boardID, integrationID); err != nil {
+		return ctx.Status(chatErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Chat integration deleted",
+	})
+}
+
+// TestChatIntegration godoc
+// @Summary Send a test chat message
+// @Description Post a test message to the chat channel right away to check the webhook url. The response status of the chat service is included when it rejects the message; the response body is only logged. Only the board owner can manage chat integrations. This endpoint requires cookie authentication.
+// @Tags chat
+// @Produce json
+// @Param boardId path int true "Board ID parameter" minimum(1) example(1)
+// @Param integrationId path int true "Chat integration ID parameter" minimum(1) example(1)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Failure 502 {object} web.ErrorResponse "Chat service rejected the message"
+// @Router /board/{boardId}/chat-integrations/{integrationId}/test [post]
+func (c *ChatController) TestChatIntegration(ctx *fiber.Ctx) error {
+	userID, boardID, integrationID, status, err := chatParams(ctx)
+	if err != nil {
+		return ctx.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := c.chatService.TestIntegration(userID, boardID, integrationID); err != nil {
+		return ctx.Status(chatErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Test message sent",
+	})
+}
+
+// chatParams membaca user yang login serta id board dan integrasi chat dari path beserta status respons jika gagal
+func chatParams(ctx *fiber.Ctx) (userID uint64, boardID uint64, integrationID uint64, status int, err error) {
+	userID, err = helper.GetCtxLocals(ctx)
+	if err != nil {
+		return 0, 0, 0, fiber.StatusUnauthorized, err
+	}
+	boardID, err = strconv.ParseUint(ctx.Params("boardId"), 10, 64)
+	if err != nil {
+		return 0, 0, 0, fiber.StatusBadRequest, errors.New("Invalid board Id")
+	}
+	integrationID, err = strconv.ParseUint(ctx.Params("integrationId"), 10, 64)
+	if err != nil {
+		return 0, 0, 0, fiber.StatusBadRequest, errors.New("Invalid chat integration Id")
+	}
+	return userID, boardID, integrationID, fiber.StatusOK, nil
+}
+
+func chatErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidChatProvider), errors.Is(err, service.ErrInvalidChatURL),
+		errors.Is(err, service.ErrInvalidNotificationEvent), errors.Is(err, service.ErrUnsupportedLocale):
+		return fiber.StatusBadRequest
+	case errors.Is(err, service.ErrNotBoardOwner):
+		return fiber.StatusForbidden
+	case errors.Is(err, service.ErrBoardNotFound), errors.Is(err, service.ErrChatIntegrationNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, service.ErrChatDeliveryFailed):
+		return fiber.StatusBadGateway
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"errors"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/web"
+	"manajemen_tugas_master/service"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type EscalationController struct {
+	escalationService service.EscalationService
+}
+
+func NewEscalationController(escalationService service.EscalationService) *EscalationController {
+	return &EscalationController{escalationService}
+}
+
+// GetEscalationPolicy godoc
+// @Summary Get the board escalation policy
+// @Description Get the overdue escalation policy of the board. When a task passes its project due date while the project is still Working or Undone, the employees are notified at the end of the due date, the managers manager_after_hours later and the task owner owner_after_hours later. Every escalation step is recorded in the escalations of the task and escalation stops once the project is Done. Boards that were never configured use the default policy (enabled, 24 and 72 hours). Only the board owner can manage the escalation policy. This endpoint requires cookie authentication.
+// @Tags escalation
+// @Produce json
+// @Param boardId path int true "Board ID parameter" minimum(1) example(1)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=domain.EscalationPolicy}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /board/{boardId}/escalation-policy [get]
+func (c *EscalationController) GetEscalationPolicy(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+	boardID, err := strconv.ParseUint(ctx.Params("boardId"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid board Id"})
+	}
+
+	policy, err := c.escalationService.GetPolicy(userID, boardID)
+	if err != nil {
+		return ctx.Status(escalationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    policy,
+	})
+}
+
+// UpdateEscalationPolicy godoc
+// @Summary Update the board escalation policy
+// @Description Enable or disable overdue escalation for the board and change after how many hours past the end of the project due date the managers and the task owner are notified. Fields that are not sent keep their value; owner_after_hours must not be less than manager_after_hours. Only the board owner can manage the escalation policy. This endpoint requires cookie authentication.
+// @Tags escalation
+// @Accept json
+// @Produce json
+// @Param boardId path int true "Board ID parameter" minimum(1) example(1)
+// @Param policy body web.EscalationPolicyRequest true "Escalation policy"
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=domain.EscalationPolicy}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /board/{boardId}/escalation-policy [put]
+func (c *EscalationController) UpdateEscalationPolicy(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+	boardID, err := strconv.ParseUint(ctx.Params("boardId"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid board Id"})
+	}
+
+	var request web.EscalationPolicyRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	policy, err := c.escalationService.UpdatePolicy(userID, boardID, &request)
+	if err != nil {
+		return ctx.Status(escalationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Escalation policy updated",
+		Data:    policy,
+	})
+}
+
+func escalationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidEscalationPolicy):
+		return fiber.StatusBadRequest
+	case errors.Is(err, service.ErrNotBoardOwner):
+		return fiber.StatusForbidden
+	case errors.Is(err, service.ErrBoardNotFound):
+		return fiber.StatusNotFound
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
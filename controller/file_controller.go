@@ -0,0 +1,485 @@
+package controller
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/model/web"
+	"manajemen_tugas_master/service"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type FileController struct {
+	fileService service.FileService
+}
+
+func NewFileController(fileService service.FileService) *FileController {
+	return &FileController{fileService}
+}
+
+// DownloadFile godoc
+// @Summary Download a task file
+// @Description Return a short-lived signed URL for a file attached to a task, or stream the file when the storage driver does not support signed URLs. Files whose scan_status is pending cannot be downloaded until they have been scanned. Only the owner, managers and employees of the task can download its files. This endpoint requires cookie authentication.
+// @Tags files
+// @Accept json
+// @Produce json
+// @Produce octet-stream
+// @Param id path int true "Task ID parameter" minimum(1) example(1)
+// @Param file_id path int true "File ID parameter" minimum(1) example(1)
+// @Param type query string false "File type, required when the file id exists in more than one file type" Enums(planning-description-file,planning-file,project-file)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=web.FileDownloadResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 410 {object} web.ErrorResponse "File is infected and has been quarantined"
+// @Failure 423 {object} web.ErrorResponse "File is awaiting virus scan"
+// @Failure 500 {object} web.ErrorResponse
+// @Router /task/{id}/files/{file_id}/download [get]
+func (f *FileController) DownloadFile(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User not authenticated"})
+	}
+
+	taskID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid task Id"})
+	}
+
+	fileID, err := strconv.ParseUint(ctx.Params("file_id"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid file Id"})
+	}
+
+	fileType := ctx.Query("type")
+	switch fileType {
+	case "", domain.FileTypePlanningDescription, domain.FileTypePlanning, domain.FileTypeProject:
+	default:
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid file type"})
+	}
+
+	// hanya owner, manager dan employee pada task yang boleh mengunduh file
+	if err := f.fileService.ValidateTaskMember(uint(taskID), uint(userID)); err != nil {
+		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	download, err := f.fileService.DownloadFile(taskID, fileID, fileType)
+	if err != nil {
+		if errors.Is(err, service.ErrFileNotFound) {
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if download.Body != nil {
+		ctx.Set(fiber.HeaderContentType, download.ContentType)
+		ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", download.FileName))
+		return ctx.Status(fiber.StatusOK).SendStream(download.Body, int(download.Size))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data: web.FileDownloadResponse{
+			FileName:  download.FileName,
+			Url:       download.URL,
+			ExpiresAt: download.ExpiresAt,
+		},
+	})
+}
+
+// DownloadThumbnail godoc
+// @Summary Get the thumbnail of a task file
+// @Description Redirect to a short-lived signed URL of the PNG thumbnail of an image or the first page of a PDF, or stream it when the storage driver does not support signed URLs, so it can be used directly as an image source. Thumbnails are generated in the background after upload; the thumbnail_url field of a file is only set once its thumbnail is ready. Only the owner, managers and employees of the task can view it. This endpoint requires cookie authentication.
+// @Tags files
+// @Produce png
+// @Param id path int true "Task ID parameter" minimum(1) example(1)
+// @Param file_id path int true "File ID parameter" minimum(1) example(1)
+// @Param type query string true "File type" Enums(planning-file,project-file)
+// @Security CookieAuth
+// @Success 200 {file} file "PNG thumbnail"
+// @Success 302 "Redirect to the signed URL of the thumbnail"
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /task/{id}/files/{file_id}/thumbnail [get]
+func (f *FileController) DownloadThumbnail(ctx *fiber.Ctx) error {
+	userID, taskID, fileID, fileType, err := versionedFileParams(ctx)
+	if fileType == "" {
+		return err
+	}
+
+	if err := f.fileService.ValidateTaskMember(uint(taskID), uint(userID)); err != nil {
+		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	download, err := f.fileService.DownloadThumbnail(taskID, fileID, fileType)
+	if err != nil {
+		status := fileErrorStatus(err)
+		if errors.Is(err, service.ErrThumbnailNotFound) {
+			status = fiber.StatusNotFound
+		}
+		return ctx.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	ctx.Set(fiber.HeaderCacheControl, "private, max-age=300")
+	if download.Body != nil {
+		ctx.Set(fiber.HeaderContentType, "image/png")
+		return ctx.Status(fiber.StatusOK).SendStream(download.Body, int(download.Size))
+	}
+	return ctx.Redirect(download.URL, fiber.StatusFound)
+}
+
+// DownloadTaskArchive godoc
+// @Summary Download all files of a task as a ZIP archive
+// @Description Stream a ZIP archive of every file attached to a task, organized into planning/, planning-description/ and project/ folders. Files awaiting a virus scan are left out. The archive is built on the fly from storage. Only the owner, managers and employees of the task can download it. This endpoint requires cookie authentication.
+// @Tags files
+// @Produce application/zip
+// @Param id path int true "Task ID parameter" minimum(1) example(1)
+// @Security CookieAuth
+// @Success 200 {file} file "ZIP archive"
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /task/{id}/files/archive [get]
+func (f *FileController) DownloadTaskArchive(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User not authenticated"})
+	}
+
+	taskID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid task Id"})
+	}
+
+	if err := f.fileService.ValidateTaskMember(uint(taskID), uint(userID)); err != nil {
+		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	archive, err := f.fileService.TaskArchive(taskID)
+	if err != nil {
+		return ctx.Status(archiveErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return f.streamArchive(ctx, archive)
+}
+
+// DownloadBoardArchive godoc
+// @Summary Download all files of a board as a ZIP archive
+// @Description Stream a ZIP archive of the files of every task on a board, with one folder per task containing planning/, planning-description/ and project/ folders. The board owner receives every task; other users only receive the tasks they own, manage or work on. This endpoint requires cookie authentication.
+// @Tags files
+// @Produce application/zip
+// @Param id path int true "Board ID parameter" minimum(1) example(1)
+// @Security CookieAuth
+// @Success 200 {file} file "ZIP archive"
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /board/{id}/files/archive [get]
+func (f *FileController) DownloadBoardArchive(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User not authenticated"})
+	}
+
+	boardID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid board Id"})
+	}
+
+	archive, err := f.fileService.BoardArchive(boardID, uint(userID))
+	if err != nil {
+		return ctx.Status(archiveErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return f.streamArchive(ctx, archive)
+}
+
+// streamArchive mengirim arsip sebagai chunked response, isi arsip ditulis bertahap tanpa ditampung di memori
+func (f *FileController) streamArchive(ctx *fiber.Ctx, archive *service.FileArchive) error {
+	ctx.Set(fiber.HeaderContentType, "application/zip")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", archive.FileName))
+	ctx.Status(fiber.StatusOK).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := f.fileService.WriteArchive(archive, w); err != nil {
+			log.Printf("Failed to stream archive %s: %v", archive.FileName, err)
+		}
+	})
+	return nil
+}
+
+func archiveErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrNoFilesToArchive), errors.Is(err, service.ErrBoardNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, service.ErrBoardAccessDenied):
+		return fiber.StatusForbidden
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
+
+// versionedFileParams membaca user, task id, file id dan jenis file untuk endpoint versi file,
+// hanya planning file dan project file yang memiliki riwayat versi
+func versionedFileParams(ctx *fiber.Ctx) (uint64, uint64, uint64, string, error) {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return 0, 0, 0, "", ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User not authenticated"})
+	}
+
+	taskID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return 0, 0, 0, "", ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid task Id"})
+	}
+
+	fileID, err := strconv.ParseUint(ctx.Params("file_id"), 10, 64)
+	if err != nil {
+		return 0, 0, 0, "", ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid file Id"})
+	}
+
+	fileType := ctx.Query("type")
+	if fileType != domain.FileTypePlanning && fileType != domain.FileTypeProject {
+		return 0, 0, 0, "", ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Type query must be planning-file or project-file"})
+	}
+
+	return userID, taskID, fileID, fileType, nil
+}
+
+func fileErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrFileNotFound), errors.Is(err, service.ErrFileVersionNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, service.ErrFileAwaitingScan):
+		return fiber.StatusLocked
+	case errors.Is(err, service.ErrFileInfected):
+		return fiber.StatusGone
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
+
+// GetFileVersions godoc
+// @Summary List versions of a task file
+// @Description List every version of a planning file or project file. Re-uploading a file with the same name to the same task creates a new version. This endpoint requires cookie authentication.
+// @Tags files
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID parameter" minimum(1) example(1)
+// @Param file_id path int true "File ID parameter" minimum(1) example(1)
+// @Param type query string true "File type" Enums(planning-file,project-file)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=[]domain.FileVersion}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /task/{id}/files/{file_id}/versions [get]
+func (f *FileController) GetFileVersions(ctx *fiber.Ctx) error {
+	userID, taskID, fileID, fileType, err := versionedFileParams(ctx)
+	if fileType == "" {
+		return err
+	}
+
+	if err := f.fileService.ValidateTaskMember(uint(taskID), uint(userID)); err != nil {
+		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	versions, err := f.fileService.ListFileVersions(taskID, fileID, fileType)
+	if err != nil {
+		return ctx.Status(fileErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    versions,
+	})
+}
+
+// DownloadFileVersion godoc
+// @Summary Download a version of a task file
+// @Description Return a short-lived signed URL for a previous version of a planning file or project file, or stream it when the storage driver does not support signed URLs. This endpoint requires cookie authentication.
+// @Tags files
+// @Accept json
+// @Produce json
+// @Produce octet-stream
+// @Param id path int true "Task ID parameter" minimum(1) example(1)
+// @Param file_id path int true "File ID parameter" minimum(1) example(1)
+// @Param version path int true "Version number" minimum(1) example(1)
+// @Param type query string true "File type" Enums(planning-file,project-file)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=web.FileDownloadResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 410 {object} web.ErrorResponse "File is infected and has been quarantined"
+// @Failure 423 {object} web.ErrorResponse "File is awaiting virus scan"
+// @Failure 500 {object} web.ErrorResponse
+// @Router /task/{id}/files/{file_id}/versions/{version}/download [get]
+func (f *FileController) DownloadFileVersion(ctx *fiber.Ctx) error {
+	userID, taskID, fileID, fileType, err := versionedFileParams(ctx)
+	if fileType == "" {
+		return err
+	}
+
+	version, err := ctx.ParamsInt("version")
+	if err != nil || version < 1 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid version"})
+	}
+
+	if err := f.fileService.ValidateTaskMember(uint(taskID), uint(userID)); err != nil {
+		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	download, err := f.fileService.DownloadFileVersion(taskID, fileID, fileType, version)
+	if err != nil {
+		return ctx.Status(fileErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if download.Body != nil {
+		ctx.Set(fiber.HeaderContentType, download.ContentType)
+		ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", download.FileName))
+		return ctx.Status(fiber.StatusOK).SendStream(download.Body, int(download.Size))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data: web.FileDownloadResponse{
+			FileName:  download.FileName,
+			Url:       download.URL,
+			ExpiresAt: download.ExpiresAt,
+		},
+	})
+}
+
+// RestoreFileVersion godoc
+// @Summary Restore a previous version of a task file
+// @Description Make a previous version the current version of a planning file or project file. The restored content is recorded as a new version, so the history is never rewritten. Planning files can be restored by managers, project files by employees. This endpoint requires cookie authentication.
+// @Tags files
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID parameter" minimum(1) example(1)
+// @Param file_id path int true "File ID parameter" minimum(1) example(1)
+// @Param version path int true "Version number to restore" minimum(1) example(1)
+// @Param type query string true "File type" Enums(planning-file,project-file)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=domain.FileVersion}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /task/{id}/files/{file_id}/versions/{version}/restore [post]
+func (f *FileController) RestoreFileVersion(ctx *fiber.Ctx) error {
+	userID, taskID, fileID, fileType, err := versionedFileParams(ctx)
+	if fileType == "" {
+		return err
+	}
+
+	version, err := ctx.ParamsInt("version")
+	if err != nil || version < 1 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid version"})
+	}
+
+	if err := f.fileService.ValidateFileUploader(uint(taskID), uint(userID), fileType); err != nil {
+		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	restored, err := f.fileService.RestoreFileVersion(taskID, fileID, fileType, version, userID)
+	if err != nil {
+		if status := fileErrorStatus(err); status == fiber.StatusNotFound {
+			return ctx.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    restored,
+	})
+}
+
+// DiffFileVersions godoc
+// @Summary Compare two versions of a task file
+// @Description Compare the metadata (size, content, content type, uploader and upload time) of two versions of a planning file or project file. This endpoint requires cookie authentication.
+// @Tags files
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID parameter" minimum(1) example(1)
+// @Param file_id path int true "File ID parameter" minimum(1) example(1)
+// @Param type query string true "File type" Enums(planning-file,project-file)
+// @Param from query int true "Older version number" minimum(1) example(1)
+// @Param to query int true "Newer version number" minimum(1) example(2)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=web.FileVersionDiff}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /task/{id}/files/{file_id}/versions/diff [get]
+func (f *FileController) DiffFileVersions(ctx *fiber.Ctx) error {
+	userID, taskID, fileID, fileType, err := versionedFileParams(ctx)
+	if fileType == "" {
+		return err
+	}
+
+	from, err := strconv.Atoi(ctx.Query("from"))
+	if err != nil || from < 1 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid from version"})
+	}
+	to, err := strconv.Atoi(ctx.Query("to"))
+	if err != nil || to < 1 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid to version"})
+	}
+
+	if err := f.fileService.ValidateTaskMember(uint(taskID), uint(userID)); err != nil {
+		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	diff, err := f.fileService.DiffFileVersions(taskID, fileID, fileType, from, to)
+	if err != nil {
+		return ctx.Status(fileErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    diff,
+	})
+}
+
+// uploadErrorStatus memetakan pelanggaran upload policy ke status http yang sesuai
+func uploadErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrFileTooLarge):
+		return fiber.StatusRequestEntityTooLarge
+	case errors.Is(err, service.ErrFileTypeNotAllowed):
+		return fiber.StatusUnsupportedMediaType
+	case errors.Is(err, service.ErrTooManyFiles), errors.Is(err, service.ErrUploadNotAttachable):
+		return fiber.StatusConflict
+	case errors.Is(err, service.ErrFileInfected):
+		return fiber.StatusUnprocessableEntity
+	case errors.Is(err, helper.ErrScannerUnavailable):
+		return fiber.StatusServiceUnavailable
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
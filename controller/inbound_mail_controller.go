@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/web"
+	"manajemen_tugas_master/service"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type InboundMailController struct {
+	inboundMailService service.InboundMailService
+}
+
+func NewInboundMailController(inboundMailService service.InboundMailService) *InboundMailController {
+	return &InboundMailController{inboundMailService}
+}
+
+// ReceiveInboundEmail godoc
+// @Summary Receive a reply email
+// @Description Receive an email replying to a task notification and append it as a comment on the task. Notification emails carry a signed reply address per user and task when REPLY_DOMAIN is set. The raw MIME message is posted as the request body, or as the "email" (SendGrid Inbound Parse) or "body-mime" (Mailgun) field of a multipart form together with the envelope recipients. The sender must be the user the reply address was sent to and still be a member of the task; quoted text, signatures and automatic replies are removed. Rejected messages answer 406 so inbound services do not retry them. The endpoint requires the INBOUND_MAIL_TOKEN as token query parameter or X-Inbound-Token header and answers 503 while it is not configured.
+// @Tags inbound mail
+// @Accept plain
+// @Accept mpfd
+// @Produce json
+// @Param token query string false "Inbound mail token"
+// @Param email formData string false "Raw MIME message (SendGrid Inbound Parse)"
+// @Param body-mime formData string false "Raw MIME message (Mailgun)"
+// @Param recipient formData string false "Envelope recipients, comma separated (Mailgun)"
+// @Param envelope formData string false "Envelope as json with a to list (SendGrid Inbound Parse)"
+// @Success 200 {object} web.WebResponse{data=web.InboundMailResponse}
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse "Reply by email is not enabled"
+// @Failure 406 {object} web.ErrorResponse "The message was rejected"
+// @Failure 500 {object} web.ErrorResponse
+// @Failure 503 {object} web.ErrorResponse "Inbound mail is not configured"
+// @Router /inbound/email [post]
+func (c *InboundMailController) ReceiveInboundEmail(ctx *fiber.Ctx) error {
+	token := ctx.Query("token")
+	if token == "" {
+		token = ctx.Get("X-Inbound-Token")
+	}
+	if err := c.inboundMailService.Authorize(token); err != nil {
+		return ctx.Status(inboundMailErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	raw := ctx.Body()
+	var recipients []string
+	if form, err := ctx.MultipartForm(); err == nil {
+		raw = nil
+		for _, field := range []string{"email", "body-mime"} {
+			if values := form.Value[field]; len(values) > 0 && values[0] != "" {
+				raw = []byte(values[0])
+				break
+			}
+		}
+		for _, value := range form.Value["recipient"] {
+			recipients = append(recipients, strings.Split(value, ",")...)
+		}
+		if values := form.Value["envelope"]; len(values) > 0 {
+			var envelope struct {
+				To []string `json:"to"`
+			}
+			if json.Unmarshal([]byte(values[0]), &envelope) == nil {
+				recipients = append(recipients, envelope.To...)
+			}
+		}
+	}
+	if len(raw) == 0 {
+		return ctx.Status(fiber.StatusNotAcceptable).JSON(fiber.Map{"error": helper.ErrInvalidInboundMail.Error()})
+	}
+
+	reply, err := c.inboundMailService.ReceiveEmail(raw, recipients)
+	if errors.Is(err, service.ErrAutomaticReply) {
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+			Code:    200,
+			Message: "Automatic reply ignored",
+		})
+	}
+	if err != nil {
+		return ctx.Status(inboundMailErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Reply added as comment",
+		Data:    reply,
+	})
+}
+
+func inboundMailErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidInboundToken):
+		return fiber.StatusUnauthorized
+	case errors.Is(err, service.ErrInboundMailNotConfigured):
+		return fiber.StatusServiceUnavailable
+	case errors.Is(err, service.ErrReplyDisabled):
+		return fiber.StatusNotFound
+	case errors.Is(err, helper.ErrInvalidInboundMail), errors.Is(err, service.ErrInvalidReplyAddress),
+		errors.Is(err, service.ErrReplySenderMismatch), errors.Is(err, service.ErrEmptyReply),
+		errors.Is(err, service.ErrReplyTooLong), errors.Is(err, service.ErrNotTaskMember),
+		errors.Is(err, service.ErrTaskNotFound):
+		return fiber.StatusNotAcceptable
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
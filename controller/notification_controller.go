@@ -0,0 +1,397 @@
+package controller
+
+import (
+	"errors"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/web"
+	"manajemen_tugas_master/service"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultNotificationPageSize = 50
+	maxNotificationPageSize     = 200
+)
+
+type NotificationController struct {
+	notificationService service.NotificationService
+}
+
+func NewNotificationController(notificationService service.NotificationService) *NotificationController {
+	return &NotificationController{notificationService}
+}
+
+// GetNotifications godoc
+// @Summary List notification deliveries
+// @Description List the notifications of the outbox, newest first. Notifications are recorded together with the change that triggers them and delivered by a background worker; failed deliveries are retried with exponential backoff and become dead after NOTIFICATION_MAX_ATTEMPTS attempts. Only users listed in ADMIN_EMAILS can access this endpoint. This endpoint requires cookie authentication.
+// @Tags admin
+// @Produce json
+// @Param status query string false "Delivery status" Enums(pending,sending,sent,dead)
+// @Param limit query int false "Maximum number of notifications" minimum(1) maximum(200) default(50)
+// @Param offset query int false "Number of notifications to skip" minimum(0) default(0)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=web.NotificationListResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /admin/notifications [get]
+func (n *NotificationController) GetNotifications(ctx *fiber.Ctx) error {
+	limit := ctx.QueryInt("limit", defaultNotificationPageSize)
+	offset := ctx.QueryInt("offset", 0)
+	if limit <= 0 || limit > maxNotificationPageSize || offset < 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid limit or offset"})
+	}
+
+	notifications, total, err := n.notificationService.ListNotifications(ctx.Query("status"), limit, offset)
+	if err != nil {
+		return ctx.Status(notificationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data: web.NotificationListResponse{
+			Total:         total,
+			Limit:         limit,
+			Offset:        offset,
+			Notifications: notifications,
+		},
+	})
+}
+
+// GetNotificationByID godoc
+// @Summary Get a notification delivery
+// @Description Return a notification of the outbox with its recipients, body, number of attempts and last delivery error. Only users listed in ADMIN_EMAILS can access this endpoint. This endpoint requires cookie authentication.
+// @Tags admin
+// @Produce json
+// @Param id path int true "Notification ID parameter" minimum(1) example(1)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=domain.OutboxMessage}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /admin/notifications/{id} [get]
+func (n *NotificationController) GetNotificationByID(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid notification Id"})
+	}
+
+	notification, err := n.notificationService.GetNotification(id)
+	if err != nil {
+		return ctx.Status(notificationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    notification,
+	})
+}
+
+// RetryNotification godoc
+// @Summary Retry a notification delivery
+// @Description Deliver a dead notification again, or a pending notification that is waiting for its next attempt right away. The number of attempts starts again from zero. Only users listed in ADMIN_EMAILS can access this endpoint. This endpoint requires cookie authentication.
+// @Tags admin
+// @Produce json
+// @Param id path int true "Notification ID parameter" minimum(1) example(1)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=domain.OutboxMessage}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse "Notification already sent or being sent"
+// @Failure 500 {object} web.ErrorResponse
+// @Router /admin/notifications/{id}/retry [post]
+func (n *NotificationController) RetryNotification(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid notification Id"})
+	}
+
+	notification, err := n.notificationService.RetryNotification(id)
+	if err != nil {
+		return ctx.Status(notificationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Notification scheduled for delivery",
+		Data:    notification,
+	})
+}
+
+// GetEmailTemplates godoc
+// @Summary List email templates
+// @Description List the names of the email templates. Every template has an HTML and a plain text version in each supported locale (en, id); emails are rendered in the locale chosen by the recipient. Only users listed in ADMIN_EMAILS can access this endpoint. This endpoint requires cookie authentication.
+// @Tags admin
+// @Produce json
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=[]string}
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Router /admin/email-templates [get]
+func (n *NotificationController) GetEmailTemplates(ctx *fiber.Ctx) error {
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    n.notificationService.EmailTemplates(),
+	})
+}
+
+// PreviewEmailTemplate godoc
+// @Summary Preview an email template
+// @Description Render an email template with sample data. The html format returns the HTML email as a page, text returns the plain text alternative and json returns the subject with both versions. Only users listed in ADMIN_EMAILS can access this endpoint. This endpoint requires cookie authentication.
+// @Tags admin
+// @Produce json,html,plain
+// @Param name path string true "Template name" example(task_renamed)
+// @Param locale query string false "Locale" Enums(en,id) default(en)
+// @Param format query string false "Preview format" Enums(html,text,json) default(html)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=helper.RenderedEmail}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /admin/email-templates/{name}/preview [get]
+func (n *NotificationController) PreviewEmailTemplate(ctx *fiber.Ctx) error {
+	format := ctx.Query("format", "html")
+	if format != "html" && format != "text" && format != "json" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid format"})
+	}
+
+	rendered, err := n.notificationService.PreviewEmail(ctx.Params("name"), ctx.Query("locale"))
+	if err != nil {
+		return ctx.Status(notificationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	switch format {
+	case "text":
+		ctx.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+		return ctx.Status(fiber.StatusOK).SendString(rendered.TextBody)
+	case "json":
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+			Code:    200,
+			Message: "Success",
+			Data:    rendered,
+		})
+	default:
+		ctx.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return ctx.Status(fiber.StatusOK).SendString(rendered.HTMLBody)
+	}
+}
+
+// GetUserNotifications godoc
+// @Summary List my notifications
+// @Description List the in-app notifications of the logged in user, newest first. Notifications are created for the same task events that send emails (task changes, comments, files and invitations) and are rendered in the user's locale. This endpoint requires cookie authentication.
+// @Tags notifications
+// @Produce json
+// @Param unread query bool false "Only unread notifications" default(false)
+// @Param limit query int false "Maximum number of notifications" minimum(1) maximum(200) default(50)
+// @Param offset query int false "Number of notifications to skip" minimum(0) default(0)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=web.UserNotificationListResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /notifications [get]
+func (n *NotificationController) GetUserNotifications(ctx *fiber.Ctx) error {
+	user, err := helper.GetCtxUser(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	limit := ctx.QueryInt("limit", defaultNotificationPageSize)
+	offset := ctx.QueryInt("offset", 0)
+	if limit <= 0 || limit > maxNotificationPageSize || offset < 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid limit or offset"})
+	}
+
+	notifications, err := n.notificationService.ListUserNotifications(user, ctx.QueryBool("unread", false), limit, offset)
+	if err != nil {
+		return ctx.Status(notificationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    notifications,
+	})
+}
+
+// GetUnreadNotificationCount godoc
+// @Summary Count my unread notifications
+// @Description Return the number of unread in-app notifications of the logged in user. This endpoint requires cookie authentication.
+// @Tags notifications
+// @Produce json
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=web.UnreadNotificationCountResponse}
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /notifications/unread-count [get]
+func (n *NotificationController) GetUnreadNotificationCount(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	unread, err := n.notificationService.CountUnreadNotifications(userID)
+	if err != nil {
+		return ctx.Status(notificationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    web.UnreadNotificationCountResponse{Unread: unread},
+	})
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark a notification as read
+// @Description Mark one in-app notification of the logged in user as read. Marking a notification that is already read keeps its original read time. This endpoint requires cookie authentication.
+// @Tags notifications
+// @Produce json
+// @Param id path int true "Notification ID parameter" minimum(1) example(1)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=web.UserNotificationResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /notifications/{id}/read [put]
+func (n *NotificationController) MarkNotificationRead(ctx *fiber.Ctx) error {
+	user, err := helper.GetCtxUser(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid notification Id"})
+	}
+
+	notification, err := n.notificationService.MarkNotificationRead(user, id)
+	if err != nil {
+		return ctx.Status(notificationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Notification marked as read",
+		Data:    notification,
+	})
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Mark all notifications as read
+// @Description Mark every unread in-app notification of the logged in user as read. This endpoint requires cookie authentication.
+// @Tags notifications
+// @Produce json
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=web.MarkAllNotificationsReadResponse}
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /notifications/read-all [put]
+func (n *NotificationController) MarkAllNotificationsRead(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	updated, err := n.notificationService.MarkAllNotificationsRead(userID)
+	if err != nil {
+		return ctx.Status(notificationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "All notifications marked as read",
+		Data:    web.MarkAllNotificationsReadResponse{Updated: updated},
+	})
+}
+
+// GetNotificationPreferences godoc
+// @Summary Get my notification preferences
+// @Description Return how the logged in user receives notifications for each event type (name, status, comment, file, due_date, invitation). instant sends an email right away and an in-app notification, in_app only creates the in-app notification, digest creates the in-app notification and summarizes it in one daily email, none turns the notifications off. Event types that were never changed use instant. This endpoint requires cookie authentication.
+// @Tags notifications
+// @Produce json
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=web.NotificationPreferencesResponse}
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /notifications/preferences [get]
+func (n *NotificationController) GetNotificationPreferences(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	preferences, err := n.notificationService.GetNotificationPreferences(userID)
+	if err != nil {
+		return ctx.Status(notificationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    web.NotificationPreferencesResponse{Preferences: preferences},
+	})
+}
+
+// UpdateNotificationPreferences godoc
+// @Summary Update my notification preferences
+// @Description Change how the logged in user receives notifications per event type, for example {"preferences": {"status": "digest", "comment": "instant", "file": "in_app"}}. Event types missing from the request keep their current mode. This endpoint requires cookie authentication.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param request body web.NotificationPreferencesRequest true "Mode per event type"
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=web.NotificationPreferencesResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /notifications/preferences [put]
+func (n *NotificationController) UpdateNotificationPreferences(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	var request web.NotificationPreferencesRequest
+	if err := ctx.BodyParser(&request); err != nil || len(request.Preferences) == 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	preferences, err := n.notificationService.UpdateNotificationPreferences(userID, request.Preferences)
+	if err != nil {
+		return ctx.Status(notificationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Notification preferences updated",
+		Data:    web.NotificationPreferencesResponse{Preferences: preferences},
+	})
+}
+
+func notificationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidNotificationStatus), errors.Is(err, service.ErrUnsupportedLocale),
+		errors.Is(err, service.ErrInvalidNotificationEvent), errors.Is(err, service.ErrInvalidNotificationMode):
+		return fiber.StatusBadRequest
+	case errors.Is(err, service.ErrNotificationNotFound), errors.Is(err, service.ErrEmailTemplateNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, service.ErrNotificationNotRetryable):
+		return fiber.StatusConflict
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
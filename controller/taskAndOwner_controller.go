@@ -1,10 +1,10 @@
 package controller
 
 import (
-	"manajemen_tugas_master/helper"
 	"manajemen_tugas_master/model/domain"
 	"manajemen_tugas_master/model/web"
 	"manajemen_tugas_master/service"
+	"mime/multipart"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -12,10 +12,12 @@ import (
 
 type TaskAndOwnerController struct {
 	taskAndOwnerService service.TaskAndOwnerService
+	fileService         service.FileService
+	uploadService       service.UploadService
 }
 
-func NewTaskController(taskAndOwnerService service.TaskAndOwnerService) *TaskAndOwnerController {
-	return &TaskAndOwnerController{taskAndOwnerService}
+func NewTaskController(taskAndOwnerService service.TaskAndOwnerService, fileService service.FileService, uploadService service.UploadService) *TaskAndOwnerController {
+	return &TaskAndOwnerController{taskAndOwnerService, fileService, uploadService}
 }
 
 // CreateTaskAndOwner godoc
@@ -318,7 +320,7 @@ func (t *TaskAndOwnerController) GetAllProjectFiles(ctx *fiber.Ctx) error {
 
 // UpdateTaskAndOwner godoc
 // @Summary Update a task
-// @Description Update various aspects of a task including manager, employee, files, and other details. This endpoint requires cookie authentication.
+// @Description Update the details, members and files of a task; upload rules and notifications are described in the README. This endpoint requires cookie authentication.
 // @Tags tasks
 // @Accept multipart/form-data
 // @Produce json
@@ -326,31 +328,39 @@ func (t *TaskAndOwnerController) GetAllProjectFiles(ctx *fiber.Ctx) error {
 // @Param request2 path int true "Task ID parameter" minimum(1) example(1)
 // @Param request3 formData string false "Manager email" example(example@gmail.com)
 // @Param request4 formData string false "Employee email" example(example@gmail.com)
-// @Param request5 formData file false "Planning file"
-// @Param request6 formData file false "Project file"
+// @Param request5 formData file false "Planning files, repeat the planning_file field to upload several files"
+// @Param request6 formData file false "Project files, repeat the project_file field to upload several files"
 // @Param request7 formData string false "Name of the task" example(example name task)
 // @Param request8 formData string false "Planning description percentage" example(25)
-// @Param request9 formData file false "Planning description file"
+// @Param request9 formData file false "Planning description files, repeat the planning_description_file field to upload several files"
 // @Param request10 formData string false "Planning status" Enums(Approved,Not Approved)
 // @Param request11 formData string false "Project status" Enums(Working,Done,Undone)
 // @Param request12 formData string false "Planning due date" example(17-11-2002)
 // @Param request13 formData string false "Project due date" example(17-11-2002)
 // @Param request14 formData string false "Priority" Enums(Low,Medium,High)
 // @Param request15 formData string false "Project comment" example(example comment)
+// @Param request16 formData string false "ID of a finalized resumable upload to attach as a planning file, repeat to attach several"
+// @Param request17 formData string false "ID of a finalized resumable upload to attach as a project file, repeat to attach several"
 // @Security CookieAuth
 // @Success 200 {object} web.WebResponse{data=web.UpdateResponseTask}
 // @Failure 400 {object} web.ErrorResponse
 // @Failure 401 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse "Maximum number of files per task reached or upload cannot be attached"
+// @Failure 413 {object} web.ErrorResponse "File too large"
+// @Failure 415 {object} web.ErrorResponse "File type not allowed"
+// @Failure 422 {object} web.ErrorResponse "File is infected and has been quarantined"
 // @Failure 500 {object} web.ErrorResponse
+// @Failure 503 {object} web.ErrorResponse "Virus scanner unavailable"
 // @Router /board/{boardId}/task/{taskId} [put]
 func (t *TaskAndOwnerController) UpdateTaskAndOwner(ctx *fiber.Ctx) error {
 	var (
-		task                    domain.Task
-		planningFile            domain.PlanningFile
-		projectFile             domain.ProjectFile
-		manager                 domain.Manager
-		employee                domain.Employee
-		PlanningDescriptionFile domain.PlanningDescriptionFile
+		task                     domain.Task
+		planningFiles            []*domain.PlanningFile
+		projectFiles             []*domain.ProjectFile
+		manager                  domain.Manager
+		employee                 domain.Employee
+		planningDescriptionFiles []*domain.PlanningDescriptionFile
+		uploadedKeys             []string
 	)
 
 	// Get user from context (either JWT or OAuth)
@@ -396,32 +406,6 @@ func (t *TaskAndOwnerController) UpdateTaskAndOwner(ctx *fiber.Ctx) error {
 		employee.Email = employeeEmail
 	}
 
-	// planning file
-	if planningFiles, err := ctx.FormFile("planning_file"); planningFiles != nil && err == nil {
-		if err := t.taskAndOwnerService.UpdateValidationManager(uint(taskIdUint64), uint(userID)); err != nil {
-			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
-		}
-		PlanningFileUrl, PlanningFileName, err := helper.SetupS3Uploader(planningFiles)
-		if err != nil {
-			return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Error uploading planning file" + err.Error()})
-		}
-		planningFile.FileUrl = PlanningFileUrl
-		planningFile.FileName = PlanningFileName
-	}
-
-	// project file
-	if projectFiles, err := ctx.FormFile("project_file"); projectFiles != nil && err == nil {
-		if err := t.taskAndOwnerService.UpdateValidationEmployee(uint(taskIdUint64), uint(userID)); err != nil {
-			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
-		}
-		ProjectFileUrl, ProjectFileName, err := helper.SetupS3Uploader(projectFiles)
-		if err != nil {
-			return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Error uploading project file" + err.Error()})
-		}
-		projectFile.FileUrl = ProjectFileUrl
-		projectFile.FileName = ProjectFileName
-	}
-
 	// field yang tidak berelasi pada task
 	if nameTask := ctx.FormValue("name_task"); nameTask != "" {
 		if err := t.taskAndOwnerService.UpdateValidationOwner(uint(taskIdUint64), uint(userID)); err != nil {
@@ -437,18 +421,6 @@ func (t *TaskAndOwnerController) UpdateTaskAndOwner(ctx *fiber.Ctx) error {
 		task.PlanningDescriptionPersen = planningDescriptionPersen
 	}
 
-	if planningDescriptionFile, err := ctx.FormFile("planning_description_file"); planningDescriptionFile != nil && err == nil {
-		if err := t.taskAndOwnerService.UpdateValidationOwner(uint(taskIdUint64), uint(userID)); err != nil {
-			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
-		}
-		planningDescriptionFileUrl, planningDescriptionFileName, err := helper.SetupS3Uploader(planningDescriptionFile)
-		if err != nil {
-			return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Error uploading project file" + err.Error()})
-		}
-		PlanningDescriptionFile.FileUrl = planningDescriptionFileUrl
-		PlanningDescriptionFile.FileName = planningDescriptionFileName
-	}
-
 	if planningStatus := ctx.FormValue("planning_status"); planningStatus != "" {
 		if err := t.taskAndOwnerService.UpdateValidationOwner(uint(taskIdUint64), uint(userID)); err != nil {
 			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
@@ -491,9 +463,86 @@ func (t *TaskAndOwnerController) UpdateTaskAndOwner(ctx *fiber.Ctx) error {
 		task.ProjectComment = projectComment
 	}
 
+	// file di-upload setelah semua field lain lolos validasi, setiap field file boleh berisi lebih dari satu file.
+	// Planning file dan project file juga bisa berasal dari resumable upload yang sudah di-finalize
+	var (
+		formFiles  map[string][]*multipart.FileHeader
+		formValues map[string][]string
+		claimedIDs []string
+	)
+	if form, err := ctx.MultipartForm(); err == nil {
+		formFiles = form.File
+		formValues = form.Value
+	}
+	rollback := func() {
+		t.fileService.ReleaseFiles(uploadedKeys)
+		t.uploadService.UnclaimUploads(claimedIDs)
+	}
+
+	// planning file
+	if files, uploadIDs := formFiles["planning_file"], formValues["planning_upload_id"]; len(files) > 0 || len(uploadIDs) > 0 {
+		if err := t.taskAndOwnerService.UpdateValidationManager(uint(taskIdUint64), uint(userID)); err != nil {
+			rollback()
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		uploaded, claimed, err := t.uploadTaskFiles(files, uploadIDs, boardIdUint64, taskIdUint64, userID, domain.FileTypePlanning)
+		if err != nil {
+			rollback()
+			return ctx.Status(uploadErrorStatus(err)).JSON(fiber.Map{"error": "Error uploading planning file: " + err.Error()})
+		}
+		claimedIDs = append(claimedIDs, uploadIDs...)
+		for _, file := range uploaded {
+			planningFiles = append(planningFiles, &domain.PlanningFile{FileKey: file.Key, FileName: file.FileName, Size: file.Size, ContentType: file.ContentType, Checksum: file.Checksum, ScanStatus: file.ScanStatus})
+			uploadedKeys = append(uploadedKeys, file.Key)
+		}
+		for _, file := range claimed {
+			planningFiles = append(planningFiles, &domain.PlanningFile{FileKey: file.Key, FileName: file.FileName, Size: file.Size, ContentType: file.ContentType, Checksum: file.Checksum, ScanStatus: file.ScanStatus})
+		}
+	}
+
+	// project file
+	if files, uploadIDs := formFiles["project_file"], formValues["project_upload_id"]; len(files) > 0 || len(uploadIDs) > 0 {
+		if err := t.taskAndOwnerService.UpdateValidationEmployee(uint(taskIdUint64), uint(userID)); err != nil {
+			rollback()
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		uploaded, claimed, err := t.uploadTaskFiles(files, uploadIDs, boardIdUint64, taskIdUint64, userID, domain.FileTypeProject)
+		if err != nil {
+			rollback()
+			return ctx.Status(uploadErrorStatus(err)).JSON(fiber.Map{"error": "Error uploading project file: " + err.Error()})
+		}
+		claimedIDs = append(claimedIDs, uploadIDs...)
+		for _, file := range uploaded {
+			projectFiles = append(projectFiles, &domain.ProjectFile{FileKey: file.Key, FileName: file.FileName, Size: file.Size, ContentType: file.ContentType, Checksum: file.Checksum, ScanStatus: file.ScanStatus})
+			uploadedKeys = append(uploadedKeys, file.Key)
+		}
+		for _, file := range claimed {
+			projectFiles = append(projectFiles, &domain.ProjectFile{FileKey: file.Key, FileName: file.FileName, Size: file.Size, ContentType: file.ContentType, Checksum: file.Checksum, ScanStatus: file.ScanStatus})
+		}
+	}
+
+	// planning description file
+	if files := formFiles["planning_description_file"]; len(files) > 0 {
+		if err := t.taskAndOwnerService.UpdateValidationOwner(uint(taskIdUint64), uint(userID)); err != nil {
+			rollback()
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		uploaded, err := t.fileService.UploadFiles(files, boardIdUint64, taskIdUint64, domain.FileTypePlanningDescription)
+		if err != nil {
+			rollback()
+			return ctx.Status(uploadErrorStatus(err)).JSON(fiber.Map{"error": "Error uploading planning description file: " + err.Error()})
+		}
+		for _, file := range uploaded {
+			planningDescriptionFiles = append(planningDescriptionFiles, &domain.PlanningDescriptionFile{FileKey: file.Key, FileName: file.FileName, Size: file.Size, ContentType: file.ContentType, Checksum: file.Checksum, ScanStatus: file.ScanStatus})
+			uploadedKeys = append(uploadedKeys, file.Key)
+		}
+	}
+
 	// save
-	response, err := t.taskAndOwnerService.UpdateTaskAndOwner(&task, &manager, &employee, &PlanningDescriptionFile, &planningFile, &projectFile, uint(taskIdUint64), uint(boardIdUint64))
+	response, err := t.taskAndOwnerService.UpdateTaskAndOwner(&task, &manager, &employee, planningDescriptionFiles, planningFiles, projectFiles, uint(taskIdUint64), uint(boardIdUint64), uint(userID))
 	if err != nil {
+		// lepas kembali file yang sudah di-upload dan kembalikan resumable upload karena tidak jadi tersimpan pada task
+		rollback()
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
@@ -504,6 +553,35 @@ func (t *TaskAndOwnerController) UpdateTaskAndOwner(ctx *fiber.Ctx) error {
 	})
 }
 
+// uploadTaskFiles mengambil resumable upload yang sudah di-finalize lalu meng-upload file dari form untuk satu jenis file.
+// Batas jumlah file diperiksa untuk gabungan keduanya, upload yang sudah diambil dikembalikan jika ada yang gagal
+func (t *TaskAndOwnerController) uploadTaskFiles(files []*multipart.FileHeader, uploadIDs []string, boardID uint64, taskID uint64, userID uint64, fileType string) ([]service.UploadedFile, []service.UploadedFile, error) {
+	claimed, err := t.uploadService.ClaimUploads(uploadIDs, userID, taskID, fileType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fileNames := make([]string, 0, len(files)+len(claimed))
+	for _, file := range files {
+		fileNames = append(fileNames, file.Filename)
+	}
+	for _, file := range claimed {
+		fileNames = append(fileNames, file.FileName)
+	}
+	if err := t.fileService.CheckFileCount(taskID, fileType, fileNames); err != nil {
+		t.uploadService.UnclaimUploads(uploadIDs)
+		return nil, nil, err
+	}
+
+	uploaded, err := t.fileService.UploadFiles(files, boardID, taskID, fileType)
+	if err != nil {
+		t.uploadService.UnclaimUploads(uploadIDs)
+		return nil, nil, err
+	}
+
+	return uploaded, claimed, nil
+}
+
 // RespondToInvitation godoc
 // @Summary Respond to an invitation
 // @Description Accept or reject an invitation to a task. This endpoint requires cookie authentication.
@@ -726,19 +804,13 @@ func (t *TaskAndOwnerController) DeletePlanningDescriptionFile(ctx *fiber.Ctx) e
 		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	fileName, err := t.taskAndOwnerService.DeletePlanningDescriptionFile(uint(fileIdUint64))
+	fileKeys, err := t.taskAndOwnerService.DeletePlanningDescriptionFile(uint(fileIdUint64))
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	// AWS S3 delete
-	err = helper.SetupS3Delete(fileName)
-	if err != nil {
-		if _, ok := err.(error); ok {
-			return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
-		}
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
-	}
+	// hapus file beserta seluruh versinya dari storage
+	t.fileService.ReleaseFiles(fileKeys)
 
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"message": "File deleted successfully"})
 }
@@ -791,19 +863,13 @@ func (t *TaskAndOwnerController) DeletePlanningFile(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	fileName, err := t.taskAndOwnerService.DeletePlanningFile(uint(fileIdUint64))
+	fileKeys, err := t.taskAndOwnerService.DeletePlanningFile(uint(fileIdUint64))
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	// AWS S3 delete
-	err = helper.SetupS3Delete(fileName)
-	if err != nil {
-		if _, ok := err.(error); ok {
-			return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
-		}
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
-	}
+	// hapus file beserta seluruh versinya dari storage
+	t.fileService.ReleaseFiles(fileKeys)
 
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"message": "File deleted successfully"})
 }
@@ -856,19 +922,13 @@ func (t *TaskAndOwnerController) DeleteProjectFile(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	fileName, err := t.taskAndOwnerService.DeleteProjectFile(uint(fileIdUint64))
+	fileKeys, err := t.taskAndOwnerService.DeleteProjectFile(uint(fileIdUint64))
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	// AWS S3 delete
-	err = helper.SetupS3Delete(fileName)
-	if err != nil {
-		if _, ok := err.(error); ok {
-			return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
-		}
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
-	}
+	// hapus file beserta seluruh versinya dari storage
+	t.fileService.ReleaseFiles(fileKeys)
 
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"message": "File deleted successfully"})
 }
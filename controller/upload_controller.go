@@ -0,0 +1,246 @@
+package controller
+
+import (
+	"bytes"
+	"errors"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/model/web"
+	"manajemen_tugas_master/service"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// header resumable upload, namanya mengikuti protokol tus agar mudah dipakai client yang sudah ada
+const (
+	headerUploadOffset = "Upload-Offset"
+	headerUploadLength = "Upload-Length"
+)
+
+type UploadController struct {
+	uploadService service.UploadService
+	fileService   service.FileService
+}
+
+func NewUploadController(uploadService service.UploadService, fileService service.FileService) *UploadController {
+	return &UploadController{uploadService, fileService}
+}
+
+// CreateUpload godoc
+// @Summary Start a resumable upload
+// @Description Start a resumable upload of a planning file or project file to a task. Send the content with PATCH /uploads/{upload_id} in chunks of at least 5MB (the last chunk may be smaller), then finalize it and attach it with the planning_upload_id or project_upload_id field of the task update. The size and the number of files are checked against the upload policy now, the content type when the upload is finalized. Unfinished uploads expire after UPLOAD_SESSION_TTL. This endpoint requires cookie authentication.
+// @Tags uploads
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID parameter" minimum(1) example(1)
+// @Param request body web.CreateUploadRequest true "File to upload"
+// @Security CookieAuth
+// @Success 201 {object} web.WebResponse{data=domain.UploadSession}
+// @Header 201 {string} Location "URL of the upload"
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse "Maximum number of files per task reached"
+// @Failure 413 {object} web.ErrorResponse "File too large"
+// @Failure 500 {object} web.ErrorResponse
+// @Router /task/{id}/uploads [post]
+func (u *UploadController) CreateUpload(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User not authenticated"})
+	}
+
+	taskID, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid task Id"})
+	}
+
+	var request web.CreateUploadRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if request.FileType != domain.FileTypePlanning && request.FileType != domain.FileTypeProject {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "file_type must be planning-file or project-file"})
+	}
+
+	// aturan yang sama dengan upload pada update task: planning file oleh manager, project file oleh employee
+	if err := u.fileService.ValidateFileUploader(uint(taskID), uint(userID), request.FileType); err != nil {
+		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	session, err := u.uploadService.CreateUpload(taskID, userID, request.FileType, request.FileName, request.Size)
+	if err != nil {
+		return ctx.Status(uploadSessionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	ctx.Set(fiber.HeaderLocation, "/uploads/"+session.ID)
+	setUploadHeaders(ctx, session)
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    201,
+		Message: "Success",
+		Data:    session,
+	})
+}
+
+// GetUpload godoc
+// @Summary Get the progress of a resumable upload
+// @Description Return the upload and the number of bytes received so far in the Upload-Offset header. A client that lost its connection resumes by sending the next chunk from that offset. HEAD returns the headers only. This endpoint requires cookie authentication.
+// @Tags uploads
+// @Produce json
+// @Param upload_id path string true "Upload ID parameter"
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=domain.UploadSession}
+// @Header 200 {integer} Upload-Offset "Bytes received"
+// @Header 200 {integer} Upload-Length "Total size of the file"
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /uploads/{upload_id} [get]
+func (u *UploadController) GetUpload(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User not authenticated"})
+	}
+
+	session, err := u.uploadService.GetUpload(ctx.Params("upload_id"), userID)
+	if err != nil {
+		return ctx.Status(uploadSessionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	ctx.Set(fiber.HeaderCacheControl, "no-store")
+	setUploadHeaders(ctx, session)
+	if ctx.Method() == fiber.MethodHead {
+		return ctx.SendStatus(fiber.StatusOK)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    session,
+	})
+}
+
+// UploadChunk godoc
+// @Summary Upload the next chunk of a resumable upload
+// @Description Append the request body to the upload. The Upload-Offset header must equal the number of bytes already received, otherwise 409 is returned and the client should ask for the current offset. Every chunk except the last must be at least 5MB. This endpoint requires cookie authentication.
+// @Tags uploads
+// @Accept application/offset+octet-stream
+// @Produce json
+// @Param upload_id path string true "Upload ID parameter"
+// @Param Upload-Offset header int true "Offset of this chunk"
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=domain.UploadSession}
+// @Header 200 {integer} Upload-Offset "Bytes received"
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse "Offset mismatch or upload already finalized"
+// @Failure 413 {object} web.ErrorResponse "Chunk exceeds the upload length"
+// @Failure 500 {object} web.ErrorResponse
+// @Router /uploads/{upload_id} [patch]
+func (u *UploadController) UploadChunk(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User not authenticated"})
+	}
+
+	offset, err := strconv.ParseInt(ctx.Get(headerUploadOffset), 10, 64)
+	if err != nil || offset < 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid Upload-Offset header"})
+	}
+
+	chunk := ctx.Body()
+	session, err := u.uploadService.AppendChunk(ctx.Params("upload_id"), userID, offset, bytes.NewReader(chunk), int64(len(chunk)))
+	if err != nil {
+		return ctx.Status(uploadSessionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	setUploadHeaders(ctx, session)
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    session,
+	})
+}
+
+// FinalizeUpload godoc
+// @Summary Finalize a resumable upload
+// @Description Assemble the received chunks into one file and check its content against the upload policy of its file type and scan it for viruses. An infected file is quarantined and the task owner is notified. A rejected file is deleted together with the upload, a finalize that fails for another reason can be retried. A finalized upload can be attached to its task with the planning_upload_id or project_upload_id field of the task update. This endpoint requires cookie authentication.
+// @Tags uploads
+// @Produce json
+// @Param upload_id path string true "Upload ID parameter"
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=domain.UploadSession}
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse "Upload incomplete or already finalized"
+// @Failure 415 {object} web.ErrorResponse "File type not allowed"
+// @Failure 422 {object} web.ErrorResponse "File is infected and has been quarantined"
+// @Failure 500 {object} web.ErrorResponse
+// @Failure 503 {object} web.ErrorResponse "Virus scanner unavailable"
+// @Router /uploads/{upload_id}/finalize [post]
+func (u *UploadController) FinalizeUpload(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User not authenticated"})
+	}
+
+	session, err := u.uploadService.FinalizeUpload(ctx.Params("upload_id"), userID)
+	if err != nil {
+		return ctx.Status(uploadSessionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	setUploadHeaders(ctx, session)
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    session,
+	})
+}
+
+// CancelUpload godoc
+// @Summary Cancel a resumable upload
+// @Description Cancel an upload that has not been attached to its task and delete the data received so far. This endpoint requires cookie authentication.
+// @Tags uploads
+// @Produce json
+// @Param upload_id path string true "Upload ID parameter"
+// @Security CookieAuth
+// @Success 204
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 409 {object} web.ErrorResponse "Upload already attached"
+// @Failure 500 {object} web.ErrorResponse
+// @Router /uploads/{upload_id} [delete]
+func (u *UploadController) CancelUpload(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User not authenticated"})
+	}
+
+	if err := u.uploadService.CancelUpload(ctx.Params("upload_id"), userID); err != nil {
+		return ctx.Status(uploadSessionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func setUploadHeaders(ctx *fiber.Ctx, session *domain.UploadSession) {
+	ctx.Set(headerUploadOffset, strconv.FormatInt(session.Offset, 10))
+	ctx.Set(headerUploadLength, strconv.FormatInt(session.Size, 10))
+}
+
+func uploadSessionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUploadNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, service.ErrUploadOffsetMismatch), errors.Is(err, service.ErrUploadIncomplete),
+		errors.Is(err, service.ErrUploadClosed), errors.Is(err, service.ErrUploadNotAttachable):
+		return fiber.StatusConflict
+	case errors.Is(err, service.ErrUploadChunkTooLarge):
+		return fiber.StatusRequestEntityTooLarge
+	case errors.Is(err, service.ErrInvalidUpload), errors.Is(err, service.ErrUploadChunkTooSmall):
+		return fiber.StatusBadRequest
+	default:
+		return uploadErrorStatus(err)
+	}
+}
@@ -0,0 +1,286 @@
+package controller
+
+import (
+	"errors"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/web"
+	"manajemen_tugas_master/service"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultWebhookDeliveryPageSize = 50
+	maxWebhookDeliveryPageSize     = 200
+)
+
+type WebhookController struct {
+	webhookService service.WebhookService
+}
+
+func NewWebhookController(webhookService service.WebhookService) *WebhookController {
+	return &WebhookController{webhookService}
+}
+
+// CreateWebhook godoc
+// @Summary Register a board webhook
+// @Description Register an url that receives a signed POST request for the events of the board (task.created, task.updated, task.deleted, board.deleted, invitation.accepted). An empty events list subscribes to every event. Each request carries the X-Webhook-Event and X-Webhook-Delivery headers and an X-Webhook-Signature header of the form sha256=<hex HMAC-SHA256 of the raw body keyed with the secret>. When no secret is given one is generated; the secret is only returned in this response. The url must point to a public host: localhost, loopback, private, link-local and multicast addresses are rejected, also when the host name resolves or redirects to them at delivery time. Only the board owner can manage webhooks. This endpoint requires cookie authentication.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param boardId path int true "Board ID parameter" minimum(1) example(1)
+// @Param webhook body web.CreateWebhookRequest true "Webhook"
+// @Security CookieAuth
+// @Success 201 {object} web.WebResponse{data=web.CreatedWebhookResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /board/{boardId}/webhooks [post]
+func (w *WebhookController) CreateWebhook(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+	boardID, err := strconv.ParseUint(ctx.Params("boardId"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid board Id"})
+	}
+
+	var request web.CreateWebhookRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	webhook, err := w.webhookService.CreateWebhook(userID, boardID, &request)
+	if err != nil {
+		return ctx.Status(webhookErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    201,
+		Message: "Webhook created",
+		Data:    webhook,
+	})
+}
+
+// GetWebhooks godoc
+// @Summary List board webhooks
+// @Description List the webhooks registered on the board. Secrets are never returned. Only the board owner can manage webhooks. This endpoint requires cookie authentication.
+// @Tags webhooks
+// @Produce json
+// @Param boardId path int true "Board ID parameter" minimum(1) example(1)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=[]domain.Webhook}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /board/{boardId}/webhooks [get]
+func (w *WebhookController) GetWebhooks(ctx *fiber.Ctx) error {
+	userID, err := helper.GetCtxLocals(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+	boardID, err := strconv.ParseUint(ctx.Params("boardId"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid board Id"})
+	}
+
+	webhooks, err := w.webhookService.GetWebhooks(userID, boardID)
+	if err != nil {
+		return ctx.Status(webhookErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    webhooks,
+	})
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a board webhook
+// @Description Delete a webhook of the board. Deliveries that are still pending are no longer sent. Only the board owner can manage webhooks. This endpoint requires cookie authentication.
+// @Tags webhooks
+// @Produce json
+// @Param boardId path int true "Board ID parameter" minimum(1) example(1)
+// @Param webhookId path int true "Webhook ID parameter" minimum(1) example(1)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /board/{boardId}/webhooks/{webhookId} [delete]
+func (w *WebhookController) DeleteWebhook(ctx *fiber.Ctx) error {
+	userID, boardID, webhookID, status, err := webhookParams(ctx)
+	if err != nil {
+		return ctx.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := w.webhookService.DeleteWebhook(userID, boardID, webhookID); err != nil {
+		return ctx.Status(webhookErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Webhook deleted",
+	})
+}
+
+// GetWebhookDeliveries godoc
+// @Summary List webhook deliveries
+// @Description List the deliveries of a webhook, newest first, with their status, number of attempts and last response. Failed deliveries are retried with exponential backoff and become failed after WEBHOOK_MAX_ATTEMPTS attempts. Only the board owner can manage webhooks. This endpoint requires cookie authentication.
+// @Tags webhooks
+// @Produce json
+// @Param boardId path int true "Board ID parameter" minimum(1) example(1)
+// @Param webhookId path int true "Webhook ID parameter" minimum(1) example(1)
+// @Param limit query int false "Maximum number of deliveries" minimum(1) maximum(200) default(50)
+// @Param offset query int false "Number of deliveries to skip" minimum(0) default(0)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=web.WebhookDeliveryListResponse}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /board/{boardId}/webhooks/{webhookId}/deliveries [get]
+func (w *WebhookController) GetWebhookDeliveries(ctx *fiber.Ctx) error {
+	userID, boardID, webhookID, status, err := webhookParams(ctx)
+	if err != nil {
+		return ctx.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	limit := ctx.QueryInt("limit", defaultWebhookDeliveryPageSize)
+	offset := ctx.QueryInt("offset", 0)
+	if limit <= 0 || limit > maxWebhookDeliveryPageSize || offset < 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid limit or offset"})
+	}
+
+	deliveries, total, err := w.webhookService.GetDeliveries(userID, boardID, webhookID, limit, offset)
+	if err != nil {
+		return ctx.Status(webhookErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data: web.WebhookDeliveryListResponse{
+			Total:      total,
+			Limit:      limit,
+			Offset:     offset,
+			Deliveries: deliveries,
+		},
+	})
+}
+
+// GetWebhookDelivery godoc
+// @Summary Get a webhook delivery
+// @Description Return a delivery of a webhook with its payload, signature and the log of every attempt (response status, response body and error). Only the board owner can manage webhooks. This endpoint requires cookie authentication.
+// @Tags webhooks
+// @Produce json
+// @Param boardId path int true "Board ID parameter" minimum(1) example(1)
+// @Param webhookId path int true "Webhook ID parameter" minimum(1) example(1)
+// @Param deliveryId path int true "Delivery ID parameter" minimum(1) example(1)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=domain.WebhookDelivery}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /board/{boardId}/webhooks/{webhookId}/deliveries/{deliveryId} [get]
+func (w *WebhookController) GetWebhookDelivery(ctx *fiber.Ctx) error {
+	userID, boardID, webhookID, status, err := webhookParams(ctx)
+	if err != nil {
+		return ctx.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+	deliveryID, err := strconv.ParseUint(ctx.Params("deliveryId"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid delivery Id"})
+	}
+
+	delivery, err := w.webhookService.GetDelivery(userID, boardID, webhookID, deliveryID)
+	if err != nil {
+		return ctx.Status(webhookErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Success",
+		Data:    delivery,
+	})
+}
+
+// RedeliverWebhookDelivery godoc
+// @Summary Redeliver a webhook delivery
+// @Description Send the payload of an earlier delivery again as a new delivery to the current url of the webhook, signed with the current secret. The new delivery references the original one in redelivery_of. Only the board owner can manage webhooks. This endpoint requires cookie authentication.
+// @Tags webhooks
+// @Produce json
+// @Param boardId path int true "Board ID parameter" minimum(1) example(1)
+// @Param webhookId path int true "Webhook ID parameter" minimum(1) example(1)
+// @Param deliveryId path int true "Delivery ID parameter" minimum(1) example(1)
+// @Security CookieAuth
+// @Success 200 {object} web.WebResponse{data=domain.WebhookDelivery}
+// @Failure 400 {object} web.ErrorResponse
+// @Failure 401 {object} web.ErrorResponse
+// @Failure 403 {object} web.ErrorResponse
+// @Failure 404 {object} web.ErrorResponse
+// @Failure 500 {object} web.ErrorResponse
+// @Router /board/{boardId}/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver [post]
+func (w *WebhookController) RedeliverWebhookDelivery(ctx *fiber.Ctx) error {
+	userID, boardID, webhookID, status, err := webhookParams(ctx)
+	if err != nil {
+		return ctx.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+	deliveryID, err := strconv.ParseUint(ctx.Params("deliveryId"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid delivery Id"})
+	}
+
+	delivery, err := w.webhookService.Redeliver(userID, boardID, webhookID, deliveryID)
+	if err != nil {
+		return ctx.Status(webhookErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    200,
+		Message: "Delivery scheduled",
+		Data:    delivery,
+	})
+}
+
+// webhookParams membaca user yang login serta id board dan webhook dari path beserta status respons jika gagal
+func webhookParams(ctx *fiber.Ctx) (userID uint64, boardID uint64, webhookID uint64, status int, err error) {
+	userID, err = helper.GetCtxLocals(ctx)
+	if err != nil {
+		return 0, 0, 0, fiber.StatusUnauthorized, err
+	}
+	boardID, err = strconv.ParseUint(ctx.Params("boardId"), 10, 64)
+	if err != nil {
+		return 0, 0, 0, fiber.StatusBadRequest, errors.New("Invalid board Id")
+	}
+	webhookID, err = strconv.ParseUint(ctx.Params("webhookId"), 10, 64)
+	if err != nil {
+		return 0, 0, 0, fiber.StatusBadRequest, errors.New("Invalid webhook Id")
+	}
+	return userID, boardID, webhookID, fiber.StatusOK, nil
+}
+
+func webhookErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidWebhookURL), errors.Is(err, service.ErrInvalidWebhookEvent):
+		return fiber.StatusBadRequest
+	case errors.Is(err, service.ErrNotBoardOwner):
+		return fiber.StatusForbidden
+	case errors.Is(err, service.ErrBoardNotFound), errors.Is(err, service.ErrWebhookNotFound), errors.Is(err, service.ErrWebhookDeliveryNotFound):
+		return fiber.StatusNotFound
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
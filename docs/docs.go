@@ -17,31 +17,51 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/oauth": {
+        "/admin/email-templates": {
             "get": {
-                "description": "Start the Google OAuth process. If successful, the user will be redirected to the URL \"(frontendURL)/auth-success?email=(encodedUserEmail)\u0026token=(encodedToken)\" with the user's email and token in the query parameters.",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
                 ],
+                "description": "List the names of the email templates. Every template has an HTML and a plain text version in each supported locale (en, id); emails are rendered in the locale chosen by the recipient. Only users listed in ADMIN_EMAILS can access this endpoint. This endpoint requires cookie authentication.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Initiate Google OAuth",
+                "summary": "List email templates",
                 "responses": {
-                    "302": {
-                        "description": "Redirect to success URL"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
@@ -49,38 +69,73 @@ const docTemplate = `{
                 }
             }
         },
-        "/board": {
-            "post": {
+        "/admin/email-templates/{name}/preview": {
+            "get": {
                 "security": [
                     {
                         "CookieAuth": []
                     }
                 ],
-                "description": "Create a new board. This endpoint requires cookie authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Render an email template with sample data. The html format returns the HTML email as a page, text returns the plain text alternative and json returns the subject with both versions. Only users listed in ADMIN_EMAILS can access this endpoint. This endpoint requires cookie authentication.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/html",
+                    "text/plain"
                 ],
                 "tags": [
-                    "boards"
+                    "admin"
                 ],
-                "summary": "Create a new board",
+                "summary": "Preview an email template",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Name of the board",
-                        "name": "name_board",
-                        "in": "formData",
+                        "example": "task_renamed",
+                        "description": "Template name",
+                        "name": "name",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "id"
+                        ],
+                        "type": "string",
+                        "default": "en",
+                        "description": "Locale",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "html",
+                            "text",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "html",
+                        "description": "Preview format",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.BoardResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/helper.RenderedEmail"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -95,6 +150,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -104,141 +171,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/board/{boardId}/task/{taskId}": {
-            "put": {
+        "/admin/notifications": {
+            "get": {
                 "security": [
                     {
                         "CookieAuth": []
                     }
                 ],
-                "description": "Update various aspects of a task including manager, employee, files, and other details. This endpoint requires cookie authentication.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "List the notifications of the outbox, newest first. Notifications are recorded together with the change that triggers them and delivered by a background worker; failed deliveries are retried with exponential backoff and become dead after NOTIFICATION_MAX_ATTEMPTS attempts. Only users listed in ADMIN_EMAILS can access this endpoint. This endpoint requires cookie authentication.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "admin"
                 ],
-                "summary": "Update a task",
+                "summary": "List notification deliveries",
                 "parameters": [
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "example": 1,
-                        "description": "Board ID parameter",
-                        "name": "request",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "example": 1,
-                        "description": "Task ID parameter",
-                        "name": "request2",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "example@gmail.com",
-                        "description": "Manager email",
-                        "name": "request3",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "example": "example@gmail.com",
-                        "description": "Employee email",
-                        "name": "request4",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Planning file",
-                        "name": "request5",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Project file",
-                        "name": "request6",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "example": "example name task",
-                        "description": "Name of the task",
-                        "name": "request7",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "example": "25",
-                        "description": "Planning description percentage",
-                        "name": "request8",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Planning description file",
-                        "name": "request9",
-                        "in": "formData"
-                    },
-                    {
-                        "enum": [
-                            "Approved",
-                            "Not Approved"
-                        ],
-                        "type": "string",
-                        "description": "Planning status",
-                        "name": "request10",
-                        "in": "formData"
-                    },
                     {
                         "enum": [
-                            "Working",
-                            "Done",
-                            "Undone"
+                            "pending",
+                            "sending",
+                            "sent",
+                            "dead"
                         ],
                         "type": "string",
-                        "description": "Project status",
-                        "name": "request11",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "example": "17-11-2002",
-                        "description": "Planning due date",
-                        "name": "request12",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "example": "17-11-2002",
-                        "description": "Project due date",
-                        "name": "request13",
-                        "in": "formData"
+                        "description": "Delivery status",
+                        "name": "status",
+                        "in": "query"
                     },
                     {
-                        "enum": [
-                            "Low",
-                            "Medium",
-                            "High"
-                        ],
-                        "type": "string",
-                        "description": "Priority",
-                        "name": "request14",
-                        "in": "formData"
+                        "maximum": 200,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of notifications",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "example": "example comment",
-                        "description": "Project comment",
-                        "name": "request15",
-                        "in": "formData"
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of notifications to skip",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -253,7 +229,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/web.UpdateResponseTask"
+                                            "$ref": "#/definitions/web.NotificationListResponse"
                                         }
                                     }
                                 }
@@ -272,6 +248,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -281,47 +263,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/board/{id}": {
-            "put": {
+        "/admin/notifications/{id}": {
+            "get": {
                 "security": [
                     {
                         "CookieAuth": []
                     }
                 ],
-                "description": "Edit a board's name by its ID.  This endpoint requires cookie authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Return a notification of the outbox with its recipients, body, number of attempts and last delivery error. Only users listed in ADMIN_EMAILS can access this endpoint. This endpoint requires cookie authentication.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "boards"
+                    "admin"
                 ],
-                "summary": "Edit a board",
+                "summary": "Get a notification delivery",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
                         "example": 1,
-                        "description": "Board ID parameter",
-                        "name": "request",
+                        "description": "Notification ID parameter",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "New name of the board",
-                        "name": "name_board",
-                        "in": "formData",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.BoardResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.OutboxMessage"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -330,6 +314,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -343,31 +339,30 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/notifications/{id}/retry": {
+            "post": {
                 "security": [
                     {
                         "CookieAuth": []
                     }
                 ],
-                "description": "Delete a board by its ID.  This endpoint requires cookie authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Deliver a dead notification again, or a pending notification that is waiting for its next attempt right away. The number of attempts starts again from zero. Only users listed in ADMIN_EMAILS can access this endpoint. This endpoint requires cookie authentication.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "boards"
+                    "admin"
                 ],
-                "summary": "Delete a board",
+                "summary": "Retry a notification delivery",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
                         "example": 1,
-                        "description": "Board ID parameter",
-                        "name": "request",
+                        "description": "Notification ID parameter",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -376,7 +371,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.OutboxMessage"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -385,12 +392,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Notification already sent or being sent",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -400,14 +425,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/boards": {
+        "/auth/oauth": {
             "get": {
+                "description": "Start the Google OAuth process. If successful, the user will be redirected to the URL \"(frontendURL)/auth-success?email=(encodedUserEmail)\u0026token=(encodedToken)\" with the user's email and token in the query parameters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Initiate Google OAuth",
+                "responses": {
+                    "302": {
+                        "description": "Redirect to success URL"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/board": {
+            "post": {
                 "security": [
                     {
                         "CookieAuth": []
                     }
                 ],
-                "description": "Retrieve all boards.  This endpoint requires cookie authentication.",
+                "description": "Create a new board. This endpoint requires cookie authentication.",
                 "consumes": [
                     "application/json"
                 ],
@@ -417,15 +474,21 @@ const docTemplate = `{
                 "tags": [
                     "boards"
                 ],
-                "summary": "Get all boards",
+                "summary": "Create a new board",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of the board",
+                        "name": "name_board",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/web.BoardResponse"
-                            }
+                            "$ref": "#/definitions/web.BoardResponse"
                         }
                     },
                     "400": {
@@ -434,8 +497,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
@@ -449,24 +512,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/invitations": {
+        "/board/{boardId}/calendar-feed": {
             "get": {
                 "security": [
                     {
                         "CookieAuth": []
                     }
                 ],
-                "description": "Get a list of all invitations. This endpoint requires cookie authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the secret iCalendar feed url with the planning and project due dates of every task of the board. The url is created on the first request. Only the board owner can manage the board feed. This endpoint requires cookie authentication.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "invitations"
+                    "calendar"
+                ],
+                "summary": "Get the board calendar feed url",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Board ID parameter",
+                        "name": "boardId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all invitations",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -479,16 +550,37 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/web.InvitationResponse"
-                                            }
+                                            "$ref": "#/definitions/web.CalendarFeedResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -498,44 +590,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/invitations/{invitationID}": {
+        "/board/{boardId}/calendar-feed/rotate": {
             "post": {
                 "security": [
                     {
                         "CookieAuth": []
                     }
                 ],
-                "description": "Accept or reject an invitation to a task. This endpoint requires cookie authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Replace the token of the board calendar feed. The previous url stops working immediately. Only the board owner can manage the board feed. This endpoint requires cookie authentication.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "invitations"
+                    "calendar"
                 ],
-                "summary": "Respond to an invitation",
+                "summary": "Rotate the board calendar feed url",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
                         "example": 1,
-                        "description": "Invitation ID parameter",
-                        "name": "request",
+                        "description": "Board ID parameter",
+                        "name": "boardId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "enum": [
-                            "accept",
-                            "reject"
-                        ],
-                        "type": "string",
-                        "description": "Invitation response status",
-                        "name": "request",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -550,7 +628,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/web.InvitationResponse"
+                                            "$ref": "#/definitions/web.CalendarFeedResponse"
                                         }
                                     }
                                 }
@@ -569,6 +647,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -578,31 +668,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/task/{id}": {
+        "/board/{boardId}/chat-integrations": {
             "get": {
                 "security": [
                     {
                         "CookieAuth": []
                     }
                 ],
-                "description": "Get detailed information about a task by its ID. This endpoint requires cookie authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the Slack and Discord integrations of the board with their locale, event types and masked webhook url. Only the board owner can manage chat integrations. This endpoint requires cookie authentication.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "chat"
                 ],
-                "summary": "Get a task by ID",
+                "summary": "List board chat integrations",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
                         "example": 1,
                         "description": "Board ID parameter",
-                        "name": "request",
+                        "name": "boardId",
                         "in": "path",
                         "required": true
                     }
@@ -619,7 +706,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/web.TaskResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/web.ChatIntegrationResponse"
+                                            }
                                         }
                                     }
                                 }
@@ -632,6 +722,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -646,13 +748,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "CookieAuth": []
                     }
                 ],
-                "description": "Remove a task and its associated owner. This endpoint requires cookie authentication.",
+                "description": "Connect the board to a Slack or Discord incoming webhook. The url must be an incoming webhook url on hooks.slack.com for Slack or discord.com for Discord, or on a host listed in CHAT_WEBHOOK_HOSTS. Task changes made with the task update endpoint and invitation responses are posted to the channel as formatted messages with the task name, who made the change and links, for the selected event types (name, status, comment, file, due_date, invitation; empty for all). Messages are written in the integration locale (en or id) and delivered through the notification outbox with retries. The webhook url is only shown masked afterwards. Only the board owner can manage chat integrations. This endpoint requires cookie authentication.",
                 "consumes": [
                     "application/json"
                 ],
@@ -660,25 +762,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "chat"
                 ],
-                "summary": "Delete a task and its owner",
+                "summary": "Connect a board to a chat channel",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
                         "example": 1,
-                        "description": "Task ID parameter",
-                        "name": "request",
+                        "description": "Board ID parameter",
+                        "name": "boardId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Chat integration",
+                        "name": "integration",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.ChatIntegrationRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/web.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.ChatIntegrationResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -693,6 +816,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -702,14 +837,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/task/{id}/employee/{employee_id}": {
-            "delete": {
+        "/board/{boardId}/chat-integrations/{integrationId}": {
+            "put": {
                 "security": [
                     {
                         "CookieAuth": []
                     }
                 ],
-                "description": "Remove an employee from a specific task. This endpoint requires cookie authentication.",
+                "description": "Change the locale, the event types or pause and resume a chat integration. Fields that are not sent keep their value; an empty events list selects every event type. Only the board owner can manage chat integrations. This endpoint requires cookie authentication.",
                 "consumes": [
                     "application/json"
                 ],
@@ -717,16 +852,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "chat"
                 ],
-                "summary": "Delete an employee from a task",
+                "summary": "Update a board chat integration",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
                         "example": 1,
-                        "description": "Task ID parameter",
-                        "name": "request",
+                        "description": "Board ID parameter",
+                        "name": "boardId",
                         "in": "path",
                         "required": true
                     },
@@ -734,22 +869,43 @@ const docTemplate = `{
                         "minimum": 1,
                         "type": "integer",
                         "example": 1,
-                        "description": "Employee ID parameter",
-                        "name": "request",
+                        "description": "Chat integration ID parameter",
+                        "name": "integrationId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Chat integration settings",
+                        "name": "integration",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.UpdateChatIntegrationRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.ChatIntegrationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
@@ -759,6 +915,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -766,33 +934,28 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/task/{id}/manager/{manager_id}": {
+            },
             "delete": {
                 "security": [
                     {
                         "CookieAuth": []
                     }
                 ],
-                "description": "Remove a manager from a specific task. This endpoint requires cookie authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Disconnect a chat channel from the board. Messages that are still waiting in the outbox for this integration are dropped. Only the board owner can manage chat integrations. This endpoint requires cookie authentication.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "chat"
                 ],
-                "summary": "Delete a manager from a task",
+                "summary": "Delete a board chat integration",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
                         "example": 1,
-                        "description": "Task ID parameter",
-                        "name": "request",
+                        "description": "Board ID parameter",
+                        "name": "boardId",
                         "in": "path",
                         "required": true
                     },
@@ -800,8 +963,8 @@ const docTemplate = `{
                         "minimum": 1,
                         "type": "integer",
                         "example": 1,
-                        "description": "Manager ID parameter",
-                        "name": "request",
+                        "description": "Chat integration ID parameter",
+                        "name": "integrationId",
                         "in": "path",
                         "required": true
                     }
@@ -810,7 +973,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.SuccessResponse"
+                            "$ref": "#/definitions/web.WebResponse"
                         }
                     },
                     "400": {
@@ -825,6 +988,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -834,31 +1009,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/task/{id}/planning-description-file/{file_id}": {
-            "delete": {
+        "/board/{boardId}/chat-integrations/{integrationId}/test": {
+            "post": {
                 "security": [
                     {
                         "CookieAuth": []
                     }
                 ],
-                "description": "Remove a planning description file from a specific task. This endpoint requires cookie authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Post a test message to the chat channel right away to check the webhook url. The response status of the chat service is included when it rejects the message; the response body is only logged. Only the board owner can manage chat integrations. This endpoint requires cookie authentication.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "chat"
                 ],
-                "summary": "Delete a planning description file",
+                "summary": "Send a test chat message",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
                         "example": 1,
-                        "description": "Task ID parameter",
-                        "name": "request",
+                        "description": "Board ID parameter",
+                        "name": "boardId",
                         "in": "path",
                         "required": true
                     },
@@ -866,8 +1038,8 @@ const docTemplate = `{
                         "minimum": 1,
                         "type": "integer",
                         "example": 1,
-                        "description": "File ID parameter",
-                        "name": "request",
+                        "description": "Chat integration ID parameter",
+                        "name": "integrationId",
                         "in": "path",
                         "required": true
                     }
@@ -876,7 +1048,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.SuccessResponse"
+                            "$ref": "#/definitions/web.WebResponse"
                         }
                     },
                     "400": {
@@ -891,74 +1063,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/task/{id}/planning-file/{file_id}": {
-            "delete": {
-                "security": [
-                    {
-                        "CookieAuth": []
-                    }
-                ],
-                "description": "Remove a planning file from a specific task. This endpoint requires cookie authentication.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "tasks"
-                ],
-                "summary": "Delete a planning file",
-                "parameters": [
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "example": 1,
-                        "description": "Task ID parameter",
-                        "name": "request",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "example": 1,
-                        "description": "File ID parameter",
-                        "name": "request",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/web.SuccessResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "502": {
+                        "description": "Chat service rejected the message",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
@@ -966,40 +1090,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/task/{id}/project-file/{file_id}": {
-            "delete": {
+        "/board/{boardId}/escalation-policy": {
+            "get": {
                 "security": [
                     {
                         "CookieAuth": []
                     }
                 ],
-                "description": "Remove a project file from a specific task. This endpoint requires cookie authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the overdue escalation policy of the board. When a task passes its project due date while the project is still Working or Undone, the employees are notified at the end of the due date, the managers manager_after_hours later and the task owner owner_after_hours later. Every escalation step is recorded in the escalations of the task and escalation stops once the project is Done. Boards that were never configured use the default policy (enabled, 24 and 72 hours). Only the board owner can manage the escalation policy. This endpoint requires cookie authentication.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "escalation"
                 ],
-                "summary": "Delete a project file",
+                "summary": "Get the board escalation policy",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
                         "example": 1,
-                        "description": "Task ID parameter",
-                        "name": "request",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "example": 1,
-                        "description": "File ID parameter",
-                        "name": "request",
+                        "description": "Board ID parameter",
+                        "name": "boardId",
                         "in": "path",
                         "required": true
                     }
@@ -1008,7 +1120,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.EscalationPolicy"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1023,6 +1147,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1030,48 +1166,47 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/task/{}:board_id}": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "CookieAuth": []
                     }
                 ],
-                "description": "Create a new task and assign it to the authenticated user as the owner. This endpoint requires cookie authentication.",
+                "description": "Enable or disable overdue escalation for the board and change after how many hours past the end of the project due date the managers and the task owner are notified. Fields that are not sent keep their value; owner_after_hours must not be less than manager_after_hours. Only the board owner can manage the escalation policy. This endpoint requires cookie authentication.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "escalation"
                 ],
-                "summary": "Create a new task and assign an owner",
+                "summary": "Update the board escalation policy",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
                         "example": 1,
                         "description": "Board ID parameter",
-                        "name": "request",
+                        "name": "boardId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "example": "name_task",
-                        "description": "Name of the task",
-                        "name": "request",
-                        "in": "formData",
-                        "required": true
+                        "description": "Escalation policy",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.EscalationPolicyRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1081,7 +1216,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/web.TaskCreateResponse"
+                                            "$ref": "#/definitions/domain.EscalationPolicy"
                                         }
                                     }
                                 }
@@ -1100,54 +1235,11 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/tasks": {
-            "get": {
-                "security": [
-                    {
-                        "CookieAuth": []
-                    }
-                ],
-                "description": "Get a list of all tasks with their owners. This endpoint requires cookie authentication.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "tasks"
-                ],
-                "summary": "Get all tasks",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/web.WebResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/web.TaskResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
-                        }
                     },
                     "404": {
                         "description": "Not Found",
@@ -1164,35 +1256,172 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/forgot-password": {
-            "post": {
-                "description": "Send a reset code to the user's email",
+        "/board/{boardId}/task/{taskId}": {
+            "put": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Update the details, members and files of a task; upload rules and notifications are described in the README. This endpoint requires cookie authentication.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "tasks"
                 ],
-                "summary": "Initiate forgot password process",
+                "summary": "Update a task",
                 "parameters": [
                     {
-                        "description": "User's email",
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Board ID parameter",
                         "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/web.ForgotPasswordRequest"
-                        }
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Task ID parameter",
+                        "name": "request2",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "example@gmail.com",
+                        "description": "Manager email",
+                        "name": "request3",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "example@gmail.com",
+                        "description": "Employee email",
+                        "name": "request4",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Planning files, repeat the planning_file field to upload several files",
+                        "name": "request5",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Project files, repeat the project_file field to upload several files",
+                        "name": "request6",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "example name task",
+                        "description": "Name of the task",
+                        "name": "request7",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "25",
+                        "description": "Planning description percentage",
+                        "name": "request8",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Planning description files, repeat the planning_description_file field to upload several files",
+                        "name": "request9",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "Approved",
+                            "Not Approved"
+                        ],
+                        "type": "string",
+                        "description": "Planning status",
+                        "name": "request10",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "Working",
+                            "Done",
+                            "Undone"
+                        ],
+                        "type": "string",
+                        "description": "Project status",
+                        "name": "request11",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "17-11-2002",
+                        "description": "Planning due date",
+                        "name": "request12",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "17-11-2002",
+                        "description": "Project due date",
+                        "name": "request13",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "Low",
+                            "Medium",
+                            "High"
+                        ],
+                        "type": "string",
+                        "description": "Priority",
+                        "name": "request14",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "example comment",
+                        "description": "Project comment",
+                        "name": "request15",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of a finalized resumable upload to attach as a planning file, repeat to attach several",
+                        "name": "request16",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of a finalized resumable upload to attach as a project file, repeat to attach several",
+                        "name": "request17",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.UpdateResponseTask"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1201,50 +1430,97 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Maximum number of files per task reached or upload cannot be attached",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "File type not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "File is infected and has been quarantined",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Virus scanner unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/user/login": {
-            "post": {
-                "description": "Login with user credentials",
-                "consumes": [
-                    "application/json"
+        "/board/{boardId}/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
                 ],
+                "description": "List the webhooks registered on the board. Secrets are never returned. Only the board owner can manage webhooks. This endpoint requires cookie authentication.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "webhooks"
                 ],
-                "summary": "Authenticate a user",
+                "summary": "List board webhooks",
                 "parameters": [
                     {
-                        "description": "User login credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/web.LoginRequest"
-                        }
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Board ID parameter",
+                        "name": "boardId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.TokenResponse"
-                        },
-                        "headers": {
-                            "Set-Cookie": {
-                                "type": "string",
-                                "description": "Authorization"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Webhook"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1259,6 +1535,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1272,11 +1554,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/user/reset-password": {
+            },
             "post": {
-                "description": "Reset the user's password using the provided reset code",
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Register an url that receives a signed POST request for the events of the board (task.created, task.updated, task.deleted, board.deleted, invitation.accepted). An empty events list subscribes to every event. Each request carries the X-Webhook-Event and X-Webhook-Delivery headers and an X-Webhook-Signature header of the form sha256=\u003chex HMAC-SHA256 of the raw body keyed with the secret\u003e. When no secret is given one is generated; the secret is only returned in this response. The url must point to a public host: localhost, loopback, private, link-local and multicast addresses are rejected, also when the host name resolves or redirects to them at delivery time. Only the board owner can manage webhooks. This endpoint requires cookie authentication.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1284,32 +1569,71 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "webhooks"
                 ],
-                "summary": "Reset user's password",
+                "summary": "Register a board webhook",
                 "parameters": [
                     {
-                        "description": "Password reset info",
-                        "name": "request",
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Board ID parameter",
+                        "name": "boardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook",
+                        "name": "webhook",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/web.ResetPasswordRequest"
+                            "$ref": "#/definitions/web.CreateWebhookRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/web.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/web.ErrorResponse"
-                        }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.CreatedWebhookResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -1320,35 +1644,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/signup": {
-            "post": {
-                "description": "Create a new user account",
-                "consumes": [
-                    "application/json"
+        "/board/{boardId}/webhooks/{webhookId}": {
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
                 ],
+                "description": "Delete a webhook of the board. Deliveries that are still pending are no longer sent. Only the board owner can manage webhooks. This endpoint requires cookie authentication.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "webhooks"
                 ],
-                "summary": "Register a new user",
+                "summary": "Delete a board webhook",
                 "parameters": [
                     {
-                        "description": "User signup information",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/web.SignupRequest"
-                        }
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Board ID parameter",
+                        "name": "boardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Webhook ID parameter",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.TokenResponse"
+                            "$ref": "#/definitions/web.WebResponse"
                         }
                     },
                     "400": {
@@ -1357,8 +1692,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
@@ -1372,35 +1719,71 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/board/{boardId}/webhooks/{webhookId}/deliveries": {
             "get": {
-                "description": "Retrieve information for all users",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
                 ],
+                "description": "List the deliveries of a webhook, newest first, with their status, number of attempts and last response. Failed deliveries are retried with exponential backoff and become failed after WEBHOOK_MAX_ATTEMPTS attempts. Only the board owner can manage webhooks. This endpoint requires cookie authentication.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Board ID parameter",
+                        "name": "boardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Webhook ID parameter",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 200,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of deliveries",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of deliveries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all users",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/web.GetAllUsersResponse"
+                                    "$ref": "#/definitions/web.WebResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/web.UserDetail"
-                                            }
+                                            "$ref": "#/definitions/web.WebhookDeliveryListResponse"
                                         }
                                     }
                                 }
@@ -1413,6 +1796,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1428,26 +1823,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/board/{boardId}/webhooks/{webhookId}/deliveries/{deliveryId}": {
             "get": {
-                "description": "Retrieve user details by user ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
                 ],
+                "description": "Return a delivery of a webhook with its payload, signature and the log of every attempt (response status, response body and error). Only the board owner can manage webhooks. This endpoint requires cookie authentication.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "webhooks"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Get a webhook delivery",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
                         "example": 1,
-                        "description": "User ID parameter",
-                        "name": "request",
+                        "description": "Board ID parameter",
+                        "name": "boardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Webhook ID parameter",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Delivery ID parameter",
+                        "name": "deliveryId",
                         "in": "path",
                         "required": true
                     }
@@ -1456,7 +1871,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.GetUserByIDResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.WebhookDelivery"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1465,6 +1892,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1478,49 +1917,69 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/board/{boardId}/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver": {
+            "post": {
                 "security": [
                     {
                         "CookieAuth": []
                     }
                 ],
-                "description": "Update a user's information. This endpoint requires cookie authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Send the payload of an earlier delivery again as a new delivery to the current url of the webhook, signed with the current secret. The new delivery references the original one in redelivery_of. Only the board owner can manage webhooks. This endpoint requires cookie authentication.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "webhooks"
                 ],
-                "summary": "Update a user",
+                "summary": "Redeliver a webhook delivery",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
                         "example": 1,
-                        "description": "User ID parameter",
-                        "name": "request",
+                        "description": "Board ID parameter",
+                        "name": "boardId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User's email",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/web.UpdateUser"
-                        }
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Webhook ID parameter",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Delivery ID parameter",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.UpdateUser"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.WebhookDelivery"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1530,7 +1989,13 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - Cookie authentication required",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
@@ -1548,14 +2013,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/board/{id}": {
+            "put": {
                 "security": [
                     {
                         "CookieAuth": []
                     }
                 ],
-                "description": "Delete a user by their ID. This endpoint requires cookie authentication.",
+                "description": "Edit a board's name by its ID.  This endpoint requires cookie authentication.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1563,22 +2030,84 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "boards"
                 ],
-                "summary": "Delete a user",
+                "summary": "Edit a board",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
                         "example": 1,
-                        "description": "User ID parameter",
+                        "description": "Board ID parameter",
                         "name": "request",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
+                    },
+                    {
+                        "type": "string",
+                        "description": "New name of the board",
+                        "name": "name_board",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.BoardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Delete a board by its ID.  This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "boards"
+                ],
+                "summary": "Delete a board",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Board ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/web.SuccessResponse"
@@ -1590,8 +2119,68 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/board/{id}/files/archive": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Stream a ZIP archive of the files of every task on a board, with one folder per task containing planning/, planning-description/ and project/ folders. The board owner receives every task; other users only receive the tasks they own, manage or work on. This endpoint requires cookie authentication.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Download all files of a board as a ZIP archive",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Board ID parameter",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ZIP archive",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
                     "401": {
-                        "description": "Unauthorized - Cookie authentication required",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/web.ErrorResponse"
                         }
@@ -1610,38 +2199,3758 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "web.BoardResponse": {
-            "type": "object",
-            "properties": {
-                "board_created_by": {
-                    "type": "object",
-                    "properties": {
-                        "user_email": {
-                            "type": "string",
-                            "example": "user@example.com"
-                        },
-                        "user_id": {
-                            "type": "integer",
-                            "example": 1
+        },
+        "/boards": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Retrieve all boards.  This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "boards"
+                ],
+                "summary": "Get all boards",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/web.BoardResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
                         }
                     }
+                }
+            }
+        },
+        "/calendar/feed": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Get the secret iCalendar feed url with the planning and project due dates of every task where the logged in user is owner, manager or employee. The url is created on the first request. This endpoint requires cookie authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Get the user calendar feed url",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.CalendarFeedResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/calendar/feed/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Replace the token of the user calendar feed. The previous url stops working immediately and calendar applications have to subscribe to the new url. This endpoint requires cookie authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Rotate the user calendar feed url",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.CalendarFeedResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/calendar/google": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Get the Google account connected as calendar of the logged in user. This endpoint requires cookie authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Get the Google Calendar connection",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CalendarConnection"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Google Calendar is not connected",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Revoke the access of the application in the Google account and delete the stored tokens. Due date events are no longer created for tasks owned by the user. This endpoint requires cookie authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Disconnect Google Calendar",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Google Calendar is not connected",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/calendar/google/callback": {
+            "get": {
+                "description": "Google redirects the browser here after the consent page, without the authorization headers. The user is identified by the session created by the connect request and the state must match that request; it can only be used once. The refresh token is stored encrypted and access tokens are refreshed automatically. Connecting again replaces the previous connection.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Google Calendar OAuth callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization code from Google",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State from the connect request",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CalendarConnection"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Google Calendar is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/calendar/google/connect": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Redirect to the Google consent page to connect the Google Calendar of the logged in user. After consent Google redirects to the callback endpoint which stores the connection. Due date events of tasks owned by the user are created in this calendar with the managers or employees as attendees, one event per task and due date type: changing the due date updates the event, attendees follow the managers and employees of the task and deleting the task or its board cancels the event. Tasks of owners who have not connected a calendar get no event. This endpoint requires cookie authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Connect Google Calendar",
+                "responses": {
+                    "302": {
+                        "description": "Redirect to the Google consent page"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Google Calendar is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/calendar/{token}.ics": {
+            "get": {
+                "description": "Get the iCalendar (RFC 5545) feed of a secret feed url, to subscribe to from any calendar application. Every planning and project due date is an all-day event whose UID stays the same for the task and due date type, so calendar applications update events instead of duplicating them. A user feed contains the tasks where the user is owner, manager or employee; a board feed contains every task of the board. The token in the url is the only authentication; rotating the token disables the old url.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Download an iCalendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Secret feed token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inbound/email": {
+            "post": {
+                "description": "Receive an email replying to a task notification and append it as a comment on the task. Notification emails carry a signed reply address per user and task when REPLY_DOMAIN is set. The raw MIME message is posted as the request body, or as the \"email\" (SendGrid Inbound Parse) or \"body-mime\" (Mailgun) field of a multipart form together with the envelope recipients. The sender must be the user the reply address was sent to and still be a member of the task; quoted text, signatures and automatic replies are removed. Rejected messages answer 406 so inbound services do not retry them. The endpoint requires the INBOUND_MAIL_TOKEN as token query parameter or X-Inbound-Token header and answers 503 while it is not configured.",
+                "consumes": [
+                    "text/plain",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inbound mail"
+                ],
+                "summary": "Receive a reply email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Inbound mail token",
+                        "name": "token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Raw MIME message (SendGrid Inbound Parse)",
+                        "name": "email",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Raw MIME message (Mailgun)",
+                        "name": "body-mime",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Envelope recipients, comma separated (Mailgun)",
+                        "name": "recipient",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Envelope as json with a to list (SendGrid Inbound Parse)",
+                        "name": "envelope",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.InboundMailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reply by email is not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "406": {
+                        "description": "The message was rejected",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Inbound mail is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Get a list of all invitations. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invitations"
+                ],
+                "summary": "Get all invitations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/web.InvitationResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{invitationID}": {
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Accept or reject an invitation to a task. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invitations"
+                ],
+                "summary": "Respond to an invitation",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Invitation ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "accept",
+                            "reject"
+                        ],
+                        "type": "string",
+                        "description": "Invitation response status",
+                        "name": "request",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.InvitationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "List the in-app notifications of the logged in user, newest first. Notifications are created for the same task events that send emails (task changes, comments, files and invitations) and are rendered in the user's locale. This endpoint requires cookie authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "List my notifications",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Only unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 200,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of notifications",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of notifications to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.UserNotificationListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Return how the logged in user receives notifications for each event type (name, status, comment, file, due_date, invitation). instant sends an email right away and an in-app notification, in_app only creates the in-app notification, digest creates the in-app notification and summarizes it in one daily email, none turns the notifications off. Event types that were never changed use instant. This endpoint requires cookie authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get my notification preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.NotificationPreferencesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Change how the logged in user receives notifications per event type, for example {\"preferences\": {\"status\": \"digest\", \"comment\": \"instant\", \"file\": \"in_app\"}}. Event types missing from the request keep their current mode. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Update my notification preferences",
+                "parameters": [
+                    {
+                        "description": "Mode per event type",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.NotificationPreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.NotificationPreferencesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/read-all": {
+            "put": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Mark every unread in-app notification of the logged in user as read. This endpoint requires cookie authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark all notifications as read",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.MarkAllNotificationsReadResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/unread-count": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Return the number of unread in-app notifications of the logged in user. This endpoint requires cookie authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Count my unread notifications",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.UnreadNotificationCountResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/read": {
+            "put": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Mark one in-app notification of the logged in user as read. Marking a notification that is already read keeps its original read time. This endpoint requires cookie authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark a notification as read",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Notification ID parameter",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.UserNotificationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Get detailed information about a task by its ID. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Get a task by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Board ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.TaskResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Remove a task and its associated owner. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Delete a task and its owner",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Task ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/employee/{employee_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Remove an employee from a specific task. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Delete an employee from a task",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Task ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Employee ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/files/archive": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Stream a ZIP archive of every file attached to a task, organized into planning/, planning-description/ and project/ folders. Files awaiting a virus scan are left out. The archive is built on the fly from storage. Only the owner, managers and employees of the task can download it. This endpoint requires cookie authentication.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Download all files of a task as a ZIP archive",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Task ID parameter",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ZIP archive",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/files/{file_id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Return a short-lived signed URL for a file attached to a task, or stream the file when the storage driver does not support signed URLs. Files whose scan_status is pending cannot be downloaded until they have been scanned. Only the owner, managers and employees of the task can download its files. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Download a task file",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Task ID parameter",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "File ID parameter",
+                        "name": "file_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "planning-description-file",
+                            "planning-file",
+                            "project-file"
+                        ],
+                        "type": "string",
+                        "description": "File type, required when the file id exists in more than one file type",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.FileDownloadResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "File is infected and has been quarantined",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "File is awaiting virus scan",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/files/{file_id}/thumbnail": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Redirect to a short-lived signed URL of the PNG thumbnail of an image or the first page of a PDF, or stream it when the storage driver does not support signed URLs, so it can be used directly as an image source. Thumbnails are generated in the background after upload; the thumbnail_url field of a file is only set once its thumbnail is ready. Only the owner, managers and employees of the task can view it. This endpoint requires cookie authentication.",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Get the thumbnail of a task file",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Task ID parameter",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "File ID parameter",
+                        "name": "file_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "planning-file",
+                            "project-file"
+                        ],
+                        "type": "string",
+                        "description": "File type",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PNG thumbnail",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "302": {
+                        "description": "Redirect to the signed URL of the thumbnail"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/files/{file_id}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "List every version of a planning file or project file. Re-uploading a file with the same name to the same task creates a new version. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "List versions of a task file",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Task ID parameter",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "File ID parameter",
+                        "name": "file_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "planning-file",
+                            "project-file"
+                        ],
+                        "type": "string",
+                        "description": "File type",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FileVersion"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/files/{file_id}/versions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Compare the metadata (size, content, content type, uploader and upload time) of two versions of a planning file or project file. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Compare two versions of a task file",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Task ID parameter",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "File ID parameter",
+                        "name": "file_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "planning-file",
+                            "project-file"
+                        ],
+                        "type": "string",
+                        "description": "File type",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Older version number",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 2,
+                        "description": "Newer version number",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.FileVersionDiff"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/files/{file_id}/versions/{version}/download": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Return a short-lived signed URL for a previous version of a planning file or project file, or stream it when the storage driver does not support signed URLs. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Download a version of a task file",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Task ID parameter",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "File ID parameter",
+                        "name": "file_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Version number",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "planning-file",
+                            "project-file"
+                        ],
+                        "type": "string",
+                        "description": "File type",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.FileDownloadResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "File is infected and has been quarantined",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "File is awaiting virus scan",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/files/{file_id}/versions/{version}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Make a previous version the current version of a planning file or project file. The restored content is recorded as a new version, so the history is never rewritten. Planning files can be restored by managers, project files by employees. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Restore a previous version of a task file",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Task ID parameter",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "File ID parameter",
+                        "name": "file_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Version number to restore",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "planning-file",
+                            "project-file"
+                        ],
+                        "type": "string",
+                        "description": "File type",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.FileVersion"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/manager/{manager_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Remove a manager from a specific task. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Delete a manager from a task",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Task ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Manager ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/planning-description-file/{file_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Remove a planning description file from a specific task. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Delete a planning description file",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Task ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "File ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/planning-file/{file_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Remove a planning file from a specific task. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Delete a planning file",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Task ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "File ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/project-file/{file_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Remove a project file from a specific task. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Delete a project file",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Task ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "File ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{id}/uploads": {
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Start a resumable upload of a planning file or project file to a task. Send the content with PATCH /uploads/{upload_id} in chunks of at least 5MB (the last chunk may be smaller), then finalize it and attach it with the planning_upload_id or project_upload_id field of the task update. The size and the number of files are checked against the upload policy now, the content type when the upload is finalized. Unfinished uploads expire after UPLOAD_SESSION_TTL. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Start a resumable upload",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Task ID parameter",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "File to upload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.CreateUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.UploadSession"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the upload"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Maximum number of files per task reached",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/task/{}:board_id}": {
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Create a new task and assign it to the authenticated user as the owner. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Create a new task and assign an owner",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Board ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "name_task",
+                        "description": "Name of the task",
+                        "name": "request",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/web.TaskCreateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Get a list of all tasks with their owners. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Get all tasks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/web.TaskResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{upload_id}": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Return the upload and the number of bytes received so far in the Upload-Offset header. A client that lost its connection resumes by sending the next chunk from that offset. HEAD returns the headers only. This endpoint requires cookie authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Get the progress of a resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID parameter",
+                        "name": "upload_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.UploadSession"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Upload-Length": {
+                                "type": "integer",
+                                "description": "Total size of the file"
+                            },
+                            "Upload-Offset": {
+                                "type": "integer",
+                                "description": "Bytes received"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Cancel an upload that has not been attached to its task and delete the data received so far. This endpoint requires cookie authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Cancel a resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID parameter",
+                        "name": "upload_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Upload already attached",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Append the request body to the upload. The Upload-Offset header must equal the number of bytes already received, otherwise 409 is returned and the client should ask for the current offset. Every chunk except the last must be at least 5MB. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/offset+octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Upload the next chunk of a resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID parameter",
+                        "name": "upload_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of this chunk",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.UploadSession"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Upload-Offset": {
+                                "type": "integer",
+                                "description": "Bytes received"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Offset mismatch or upload already finalized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Chunk exceeds the upload length",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{upload_id}/finalize": {
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Assemble the received chunks into one file and check its content against the upload policy of its file type and scan it for viruses. An infected file is quarantined and the task owner is notified. A rejected file is deleted together with the upload, a finalize that fails for another reason can be retried. A finalized upload can be attached to its task with the planning_upload_id or project_upload_id field of the task update. This endpoint requires cookie authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Finalize a resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID parameter",
+                        "name": "upload_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.UploadSession"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Upload incomplete or already finalized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "File type not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "File is infected and has been quarantined",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Virus scanner unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/forgot-password": {
+            "post": {
+                "description": "Send a reset code to the user's email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Initiate forgot password process",
+                "parameters": [
+                    {
+                        "description": "User's email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/login": {
+            "post": {
+                "description": "Login with user credentials",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Authenticate a user",
+                "parameters": [
+                    {
+                        "description": "User login credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.TokenResponse"
+                        },
+                        "headers": {
+                            "Set-Cookie": {
+                                "type": "string",
+                                "description": "Authorization"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/reset-password": {
+            "post": {
+                "description": "Reset the user's password using the provided reset code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Reset user's password",
+                "parameters": [
+                    {
+                        "description": "Password reset info",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/signup": {
+            "post": {
+                "description": "Create a new user account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User signup information",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.SignupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Retrieve information for all users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get all users",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/web.GetAllUsersResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/web.UserDetail"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Retrieve user details by user ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "User ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.GetUserByIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Update a user's information. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update a user",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "User ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User's email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/web.UpdateUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.UpdateUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Cookie authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    }
+                ],
+                "description": "Delete a user by their ID. This endpoint requires cookie authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "User ID parameter",
+                        "name": "request",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Cookie authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.CalendarConnection": {
+            "type": "object",
+            "properties": {
+                "connected_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.EmailData": {
+            "type": "object",
+            "properties": {
+                "boards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.EmailDigestBoard"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "days": {
+                    "description": "Days dan Hours adalah sisa waktu sampai tenggat pada email pengingat, hanya salah satu yang diisi",
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.EmailFile"
+                    }
+                },
+                "hours": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "task_name": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EmailDigestBoard": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.EmailDigestItem"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EmailDigestItem": {
+            "type": "object",
+            "properties": {
+                "task_name": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EmailFile": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.EscalationPolicy": {
+            "type": "object",
+            "properties": {
+                "board_id": {
+                    "type": "integer"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "manager_after_hours": {
+                    "type": "integer"
+                },
+                "owner_after_hours": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FileVersion": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_id": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "file_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "scan_status": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "uploaded_by": {
+                    "type": "integer"
+                },
+                "uploader_email": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.OutboxMessage": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "channel": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "data": {
+                    "$ref": "#/definitions/domain.EmailData"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "recipients": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "template": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.UploadSession": {
+            "type": "object",
+            "properties": {
+                "board_id": {
+                    "type": "integer"
+                },
+                "checksum": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "scan_status": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Webhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "board_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempt_log": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WebhookDeliveryAttempt"
+                    }
+                },
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "redelivery_of": {
+                    "type": "integer"
+                },
+                "response_status": {
+                    "type": "integer"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.WebhookDeliveryAttempt": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivery_id": {
+                    "type": "integer"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "response_body": {
+                    "type": "string"
+                },
+                "response_status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "helper.RenderedEmail": {
+            "type": "object",
+            "properties": {
+                "html": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "web.BoardResponse": {
+            "type": "object",
+            "properties": {
+                "board_created_by": {
+                    "type": "object",
+                    "properties": {
+                        "user_email": {
+                            "type": "string",
+                            "example": "user@example.com"
+                        },
+                        "user_id": {
+                            "type": "integer",
+                            "example": 1
+                        }
+                    }
+                },
+                "board_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name_board": {
+                    "type": "string",
+                    "example": "My Project Board"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.TaskInfo"
+                    }
+                }
+            }
+        },
+        "web.CalendarFeedResponse": {
+            "type": "object",
+            "properties": {
+                "board_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rotated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://manajementugas.com/calendar/6f1c0e2a9b.ics"
+                }
+            }
+        },
+        "web.ChatIntegrationRequest": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "status",
+                        "comment",
+                        "file"
+                    ]
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "slack"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://hooks.slack.com/services/T000/B000/XXXX"
+                }
+            }
+        },
+        "web.ChatIntegrationResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "board_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://hooks.slack.com/…"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "web.CreateUploadRequest": {
+            "type": "object",
+            "properties": {
+                "file_name": {
+                    "type": "string",
+                    "example": "design.pdf"
+                },
+                "file_type": {
+                    "type": "string",
+                    "enum": [
+                        "planning-file",
+                        "project-file"
+                    ],
+                    "example": "project-file"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 52428800
+                }
+            }
+        },
+        "web.CreateWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "task.created",
+                        "task.updated"
+                    ]
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "a-long-random-secret"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/tasks"
+                }
+            }
+        },
+        "web.CreatedWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "board_id": {
+                    "type": "integer"
                 },
-                "board_id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "name_board": {
-                    "type": "string",
-                    "example": "My Project Board"
-                },
-                "tasks": {
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/web.TaskInfo"
+                        "type": "string"
                     }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "3f0c9b..."
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
@@ -1680,6 +5989,40 @@ const docTemplate = `{
                 }
             }
         },
+        "web.EscalationPolicyRequest": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "manager_after_hours": {
+                    "type": "integer",
+                    "example": 24
+                },
+                "owner_after_hours": {
+                    "type": "integer",
+                    "example": 72
+                }
+            }
+        },
+        "web.FileDownloadResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:15:00Z"
+                },
+                "file_name": {
+                    "type": "string",
+                    "example": "project_report.pdf"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://bucket-name.s3.amazonaws.com/project_report.pdf?X-Amz-Signature=..."
+                }
+            }
+        },
         "web.FileResponse": {
             "type": "object",
             "properties": {
@@ -1690,6 +6033,49 @@ const docTemplate = `{
                 "file_url": {
                     "type": "string",
                     "example": "https://example.com/file.pdf"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "web.FileVersionDiff": {
+            "type": "object",
+            "properties": {
+                "content_changed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "content_type_changed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "file_name_changed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "from": {
+                    "$ref": "#/definitions/domain.FileVersion"
+                },
+                "size_delta": {
+                    "type": "integer",
+                    "example": 2048
+                },
+                "time_between": {
+                    "type": "string",
+                    "example": "26h5m0s"
+                },
+                "to": {
+                    "$ref": "#/definitions/domain.FileVersion"
+                },
+                "uploader_changed": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
@@ -1737,6 +6123,23 @@ const docTemplate = `{
                 }
             }
         },
+        "web.InboundMailResponse": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "The homepage layout is ready for review."
+                },
+                "task_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
         "web.InvitationResponse": {
             "type": "object",
             "properties": {
@@ -1801,6 +6204,60 @@ const docTemplate = `{
                 }
             }
         },
+        "web.MarkAllNotificationsReadResponse": {
+            "type": "object",
+            "properties": {
+                "updated": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "web.NotificationListResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OutboxMessage"
+                    }
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "web.NotificationPreferencesRequest": {
+            "type": "object",
+            "properties": {
+                "preferences": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "web.NotificationPreferencesResponse": {
+            "type": "object",
+            "properties": {
+                "preferences": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "web.Owner": {
             "type": "object",
             "properties": {
@@ -1817,51 +6274,178 @@ const docTemplate = `{
         "web.PlanningDescriptionFile": {
             "type": "object",
             "properties": {
+                "checksum": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-05-01T09:30:00+07:00"
+                },
                 "file_name": {
                     "type": "string",
                     "example": "planning_description.pdf"
                 },
                 "file_url": {
                     "type": "string",
-                    "example": "https://bucket-name.s3.amazonaws.com/planning_description.pdf"
+                    "example": "https://api.example.com/task/1/files/1/download?type=planning-description-file"
                 },
                 "id": {
                     "type": "integer",
                     "example": 1
+                },
+                "scan_status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "clean",
+                        "skipped"
+                    ],
+                    "example": "clean"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 248312
+                },
+                "task_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "uploaded_by": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "uploader_email": {
+                    "type": "string",
+                    "example": "manager@example.com"
                 }
             }
         },
         "web.PlanningFile": {
             "type": "object",
             "properties": {
+                "checksum": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-05-01T09:30:00+07:00"
+                },
                 "file_name": {
                     "type": "string",
                     "example": "planning_document.docx"
                 },
                 "file_url": {
                     "type": "string",
-                    "example": "https://bucket-name.s3.amazonaws.com/planning_document.docx"
+                    "example": "https://api.example.com/task/1/files/2/download?type=planning-file"
                 },
                 "id": {
                     "type": "integer",
                     "example": 2
+                },
+                "scan_status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "clean",
+                        "skipped"
+                    ],
+                    "example": "clean"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 248312
+                },
+                "task_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "thumbnail_url": {
+                    "type": "string",
+                    "example": "https://api.example.com/task/1/files/2/thumbnail?type=planning-file"
+                },
+                "uploaded_by": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "uploader_email": {
+                    "type": "string",
+                    "example": "manager@example.com"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
         "web.ProjectFile": {
             "type": "object",
             "properties": {
+                "checksum": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-05-01T09:30:00+07:00"
+                },
                 "file_name": {
                     "type": "string",
                     "example": "project_report.pdf"
                 },
                 "file_url": {
                     "type": "string",
-                    "example": "https://bucket-name.s3.amazonaws.com/project_report.pdf"
+                    "example": "https://api.example.com/task/1/files/3/download?type=project-file"
                 },
                 "id": {
                     "type": "integer",
                     "example": 3
+                },
+                "scan_status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "clean",
+                        "skipped"
+                    ],
+                    "example": "clean"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 248312
+                },
+                "task_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "thumbnail_url": {
+                    "type": "string",
+                    "example": "https://api.example.com/task/1/files/3/thumbnail?type=project-file"
+                },
+                "uploaded_by": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "uploader_email": {
+                    "type": "string",
+                    "example": "manager@example.com"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
@@ -2102,6 +6686,38 @@ const docTemplate = `{
                 }
             }
         },
+        "web.UnreadNotificationCountResponse": {
+            "type": "object",
+            "properties": {
+                "unread": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "web.UpdateChatIntegrationRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "status",
+                        "comment"
+                    ]
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "id"
+                }
+            }
+        },
         "web.UpdateResponseTask": {
             "type": "object",
             "properties": {
@@ -2201,6 +6817,14 @@ const docTemplate = `{
                 "email": {
                     "type": "string",
                     "example": "user@example.com"
+                },
+                "locale": {
+                    "type": "string",
+                    "enum": [
+                        "en",
+                        "id"
+                    ],
+                    "example": "id"
                 }
             }
         },
@@ -2214,6 +6838,72 @@ const docTemplate = `{
                 "id": {
                     "type": "integer",
                     "example": 1
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "en"
+                }
+            }
+        },
+        "web.UserNotificationListResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/web.UserNotificationResponse"
+                    }
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "unread": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "web.UserNotificationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string",
+                    "example": "task.comment_added"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "message": {
+                    "type": "string",
+                    "example": "A new comment has been added to the project"
+                },
+                "read": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "task_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "title": {
+                    "type": "string",
+                    "example": "New Project Comment Added"
                 }
             }
         },
@@ -2230,6 +6920,29 @@ const docTemplate = `{
                     "example": "Success message"
                 }
             }
+        },
+        "web.WebhookDeliveryListResponse": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WebhookDelivery"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
         }
     }
 }`
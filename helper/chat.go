@@ -0,0 +1,294 @@
+package helper
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"manajemen_tugas_master/model/domain"
+)
+
+// pesan chat disimpan di templates/chat/messages.<locale>.txt, satu template per event dengan nama event tersebut
+//
+//go:embed templates/chat
+var chatTemplateFS embed.FS
+
+// ChatEventTest adalah event pesan percobaan yang dikirim saat integrasi chat diuji
+const ChatEventTest = "chat.test"
+
+const (
+	// batas panjang teks section Slack dan deskripsi embed Discord
+	slackTextLimit      = 3000
+	discordTextLimit    = 4096
+	discordTitleLimit   = 256
+	discordEmbedColor   = 0x2f80ed
+	chatResponseLimit   = 512
+	chatTruncatedSuffix = "…"
+)
+
+var ErrChatTemplateNotFound = errors.New("Chat template not found")
+
+// defaultChatWebhookHosts adalah host incoming webhook resmi setiap layanan chat, dipakai jika CHAT_WEBHOOK_HOSTS kosong
+var defaultChatWebhookHosts = map[string][]string{
+	domain.ChatProviderSlack:   {"hooks.slack.com"},
+	domain.ChatProviderDiscord: {"discord.com", "discordapp.com", "ptb.discord.com", "canary.discord.com"},
+}
+
+// chatWebhookHosts adalah host yang boleh dipakai url integrasi chat. CHAT_WEBHOOK_HOSTS (dipisah koma) berlaku untuk
+// semua layanan, misalnya untuk Mattermost atau Rocket.Chat yang kompatibel dengan Slack
+func chatWebhookHosts(provider string) []string {
+	configured := os.Getenv("CHAT_WEBHOOK_HOSTS")
+	if strings.TrimSpace(configured) == "" {
+		return defaultChatWebhookHosts[provider]
+	}
+	var hosts []string
+	for _, host := range strings.Split(configured, ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// ChatResponseError adalah respons selain 2xx dari layanan chat. Body hanya untuk log, tidak dikembalikan ke client
+type ChatResponseError struct {
+	Status int
+	Body   string
+}
+
+func (e *ChatResponseError) Error() string {
+	return fmt.Sprintf("chat webhook responded with status %d: %s", e.Status, e.Body)
+}
+
+// ValidChatWebhookURL memeriksa url http atau https incoming webhook pada host yang diizinkan untuk layanan chat
+func ValidChatWebhookURL(provider string, rawURL string) bool {
+	if !ValidHTTPURL(rawURL) {
+		return false
+	}
+	parsed, _ := url.Parse(rawURL)
+	if parsed.User != nil {
+		return false
+	}
+	return slices.Contains(chatWebhookHosts(provider), strings.ToLower(parsed.Hostname()))
+}
+
+// ChatMessage adalah pesan chat yang siap diubah menjadi payload incoming webhook. Link hanya dipasang untuk url absolut,
+// sehingga tanpa APP_URL pesan dikirim tanpa link
+type ChatMessage struct {
+	Title    string
+	TitleURL string
+	// Text sudah diformat dan di-escape untuk layanan chat tujuan oleh RenderChatMessage
+	Text      string
+	Footer    string
+	Timestamp time.Time
+}
+
+// chatTemplateData adalah data template chat: data email event ditambah user yang melakukan perubahan
+type chatTemplateData struct {
+	domain.EmailData
+	Actor string
+}
+
+// chatTemplates berisi template per layanan chat dan bahasa, format bold, link dan kutipan berbeda untuk setiap layanan
+var chatTemplates = mustParseChatTemplates()
+
+func mustParseChatTemplates() map[string]map[string]*texttemplate.Template {
+	templates := make(map[string]map[string]*texttemplate.Template, len(domain.ChatProviders))
+	for _, provider := range domain.ChatProviders {
+		templates[provider] = make(map[string]*texttemplate.Template, len(SupportedLocales))
+		for _, locale := range SupportedLocales {
+			file := path.Join("templates/chat", fmt.Sprintf("messages.%s.txt", locale))
+			tmpl, err := texttemplate.New(path.Base(file)).Funcs(chatFuncs(provider)).ParseFS(chatTemplateFS, file)
+			if err != nil {
+				panic(fmt.Sprintf("chat template %s (%s): %v", provider, locale, err))
+			}
+			templates[provider][locale] = tmpl
+		}
+	}
+	return templates
+}
+
+func chatFuncs(provider string) texttemplate.FuncMap {
+	if provider == domain.ChatProviderDiscord {
+		return texttemplate.FuncMap{
+			"bold": func(value string) string { return wrapNonEmpty(value, "**") },
+			"link": func(url, label string) string {
+				if !ValidHTTPURL(url) {
+					return label
+				}
+				return fmt.Sprintf("[%s](%s)", label, url)
+			},
+			"quote": quoteLines,
+		}
+	}
+	return texttemplate.FuncMap{
+		"bold": func(value string) string { return wrapNonEmpty(value, "*") },
+		"link": func(url, label string) string {
+			if !ValidHTTPURL(url) {
+				return label
+			}
+			return fmt.Sprintf("<%s|%s>", url, label)
+		},
+		"quote": quoteLines,
+	}
+}
+
+func wrapNonEmpty(value, marker string) string {
+	if value == "" {
+		return ""
+	}
+	return marker + value + marker
+}
+
+func quoteLines(value string) string {
+	lines := strings.Split(strings.TrimSpace(value), "\n")
+	for i, line := range lines {
+		lines[i] = "> " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// escapeChat meng-escape teks dari user agar tidak dibaca sebagai format atau mention oleh layanan chat
+func escapeChat(provider string, value string) string {
+	if provider == domain.ChatProviderDiscord {
+		return discordEscaper.Replace(value)
+	}
+	return slackEscaper.Replace(value)
+}
+
+var (
+	slackEscaper   = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	discordEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`", "|", `\|`, ">", `\>`, "#", `\#`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`)
+)
+
+// RenderChatMessage merender isi pesan chat untuk event dengan bahasa integrasi. Event tanpa template chat
+// mengembalikan ErrChatTemplateNotFound
+func RenderChatMessage(provider, locale, event, actor string, data *domain.EmailData) (string, error) {
+	locales, ok := chatTemplates[provider]
+	if !ok {
+		return "", ErrChatTemplateNotFound
+	}
+	tmpl := locales[NormalizeLocale(locale)]
+	if tmpl.Lookup(event) == nil {
+		return "", ErrChatTemplateNotFound
+	}
+
+	escaped := chatTemplateData{Actor: escapeChat(provider, actor)}
+	if data != nil {
+		escaped.EmailData = *data
+		escaped.TaskName = escapeChat(provider, data.TaskName)
+		escaped.Value = escapeChat(provider, data.Value)
+		escaped.Status = escapeChat(provider, data.Status)
+		escaped.Detail = escapeChat(provider, data.Detail)
+		escaped.Description = escapeChat(provider, data.Description)
+		escaped.Files = make([]domain.EmailFile, len(data.Files))
+		for i, file := range data.Files {
+			file.Name = escapeChat(provider, file.Name)
+			escaped.Files[i] = file
+		}
+	}
+
+	var text bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&text, event, escaped); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(text.String()), nil
+}
+
+// ChatPayload menyusun body json incoming webhook Slack (blocks) atau Discord (embed)
+func ChatPayload(provider string, message ChatMessage) ([]byte, error) {
+	switch provider {
+	case domain.ChatProviderSlack:
+		text := message.Text
+		if message.Title != "" {
+			title := escapeChat(provider, message.Title)
+			if ValidHTTPURL(message.TitleURL) {
+				title = fmt.Sprintf("<%s|%s>", message.TitleURL, title)
+			}
+			text = "*" + title + "*\n" + text
+		}
+		text = truncateText(text, slackTextLimit)
+
+		blocks := []map[string]interface{}{
+			{"type": "section", "text": map[string]string{"type": "mrkdwn", "text": text}},
+		}
+		if message.Footer != "" {
+			blocks = append(blocks, map[string]interface{}{
+				"type":     "context",
+				"elements": []map[string]string{{"type": "mrkdwn", "text": escapeChat(provider, message.Footer)}},
+			})
+		}
+		return json.Marshal(map[string]interface{}{"text": text, "blocks": blocks})
+	case domain.ChatProviderDiscord:
+		embed := map[string]interface{}{
+			"title":       truncateText(message.Title, discordTitleLimit),
+			"description": truncateText(message.Text, discordTextLimit),
+			"color":       discordEmbedColor,
+		}
+		if ValidHTTPURL(message.TitleURL) {
+			embed["url"] = message.TitleURL
+		}
+		if message.Footer != "" {
+			embed["footer"] = map[string]string{"text": message.Footer}
+		}
+		if !message.Timestamp.IsZero() {
+			embed["timestamp"] = message.Timestamp.UTC().Format(time.RFC3339)
+		}
+		// mention pada teks tidak pernah memanggil user atau role
+		return json.Marshal(map[string]interface{}{
+			"embeds":           []interface{}{embed},
+			"allowed_mentions": map[string]interface{}{"parse": []string{}},
+		})
+	default:
+		return nil, fmt.Errorf("unsupported chat provider %q", provider)
+	}
+}
+
+func truncateText(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	return string(runes[:limit-len([]rune(chatTruncatedSuffix))]) + chatTruncatedSuffix
+}
+
+// SendChatMessage mengirim payload ke incoming webhook layanan chat. Status selain 2xx dikembalikan sebagai
+// ChatResponseError bersama awal body respons, misalnya "invalid_token" dari Slack
+func SendChatMessage(ctx context.Context, client *http.Client, target string, payload []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", webhookUserAgent)
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(response.Body, chatResponseLimit))
+	io.Copy(io.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return &ChatResponseError{Status: response.StatusCode, Body: strings.TrimSpace(string(body))}
+	}
+	return nil
+}
+
+// TaskURL adalah url endpoint detail task, diawali APP_URL jika diisi
+func TaskURL(taskID uint64) string {
+	return fmt.Sprintf("%s/task/%d", strings.TrimSuffix(os.Getenv("APP_URL"), "/"), taskID)
+}
@@ -0,0 +1,218 @@
+package helper
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"manajemen_tugas_master/model/domain"
+)
+
+// template email disimpan sebagai file di templates/email dengan nama <template>.<locale>.html dan
+// <template>.<locale>.txt, lalu dibungkus layout.<locale>.html dan layout.<locale>.txt milik bahasa yang sama
+//
+//go:embed templates/email
+var emailTemplateFS embed.FS
+
+const emailTemplateDir = "templates/email"
+
+// DefaultLocale adalah bahasa email untuk user yang belum memilih bahasa atau memilih bahasa yang tidak didukung
+const DefaultLocale = "en"
+
+// SupportedLocales adalah bahasa yang memiliki template email
+var SupportedLocales = []string{"en", "id"}
+
+var ErrEmailTemplateNotFound = errors.New("Email template not found")
+
+// RenderedEmail adalah hasil render template email dalam bentuk html dan teks biasa
+type RenderedEmail struct {
+	Subject  string `json:"subject"`
+	HTMLBody string `json:"html"`
+	TextBody string `json:"text"`
+}
+
+type emailTemplate struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// emailTemplates berisi template yang sudah di-parse per nama dan bahasa, parse dilakukan sekali saat start
+// sehingga template yang rusak langsung ketahuan
+var emailTemplates = mustParseEmailTemplates()
+
+func mustParseEmailTemplates() map[string]map[string]*emailTemplate {
+	files, err := fs.Glob(emailTemplateFS, path.Join(emailTemplateDir, "*.html"))
+	if err != nil {
+		panic(err)
+	}
+
+	templates := make(map[string]map[string]*emailTemplate)
+	for _, file := range files {
+		name, _, _ := strings.Cut(path.Base(file), ".")
+		if name == "layout" || templates[name] != nil {
+			continue
+		}
+
+		templates[name] = make(map[string]*emailTemplate, len(SupportedLocales))
+		for _, locale := range SupportedLocales {
+			html, err := htmltemplate.ParseFS(emailTemplateFS, emailTemplateFile("layout", locale, "html"), emailTemplateFile(name, locale, "html"))
+			if err != nil {
+				panic(fmt.Sprintf("email template %s (%s): %v", name, locale, err))
+			}
+			text, err := texttemplate.ParseFS(emailTemplateFS, emailTemplateFile("layout", locale, "txt"), emailTemplateFile(name, locale, "txt"))
+			if err != nil {
+				panic(fmt.Sprintf("email template %s (%s): %v", name, locale, err))
+			}
+			templates[name][locale] = &emailTemplate{html: html, text: text}
+		}
+	}
+	return templates
+}
+
+func emailTemplateFile(name, locale, extension string) string {
+	return path.Join(emailTemplateDir, fmt.Sprintf("%s.%s.%s", name, locale, extension))
+}
+
+// NormalizeLocale mengembalikan bahasa yang didukung, selain itu DefaultLocale
+func NormalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	// bahasa dengan region seperti id-ID atau en_US memakai bahasa utamanya
+	if base, _, found := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-"); found {
+		locale = base
+	}
+	for _, supported := range SupportedLocales {
+		if locale == supported {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// RenderEmail merender template email dengan bahasa penerima, nilai pada data di-escape otomatis oleh html/template
+func RenderEmail(name, locale string, data *domain.EmailData) (*RenderedEmail, error) {
+	locales, ok := emailTemplates[name]
+	if !ok {
+		return nil, ErrEmailTemplateNotFound
+	}
+	tmpl := locales[NormalizeLocale(locale)]
+	if data == nil {
+		data = &domain.EmailData{}
+	}
+
+	var subject, text bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := tmpl.text.ExecuteTemplate(&text, "layout", data); err != nil {
+		return nil, err
+	}
+	var html bytes.Buffer
+	if err := tmpl.html.ExecuteTemplate(&html, "layout", data); err != nil {
+		return nil, err
+	}
+
+	return &RenderedEmail{
+		Subject:  headerValue(subject.String()),
+		HTMLBody: html.String(),
+		TextBody: strings.TrimSpace(text.String()) + "\n",
+	}, nil
+}
+
+// RenderNotification merender judul dan isi notifikasi in-app dari versi teks template email, tanpa salam dan footer
+func RenderNotification(name, locale string, data *domain.EmailData) (title string, message string, err error) {
+	locales, ok := emailTemplates[name]
+	if !ok {
+		return "", "", ErrEmailTemplateNotFound
+	}
+	tmpl := locales[NormalizeLocale(locale)]
+	if data == nil {
+		data = &domain.EmailData{}
+	}
+
+	var subject, content bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.text.ExecuteTemplate(&content, "content", data); err != nil {
+		return "", "", err
+	}
+	return headerValue(subject.String()), strings.TrimSpace(content.String()), nil
+}
+
+// EmailTemplateNames adalah nama semua template email yang tersedia
+func EmailTemplateNames() []string {
+	names := make([]string, 0, len(emailTemplates))
+	for name := range emailTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EmailTemplateSample adalah contoh data untuk preview template email
+func EmailTemplateSample(name string) (*domain.EmailData, error) {
+	if _, ok := emailTemplates[name]; !ok {
+		return nil, ErrEmailTemplateNotFound
+	}
+
+	data := &domain.EmailData{TaskName: "Website Redesign"}
+	switch name {
+	case "task_renamed":
+		data.Value = "Website Redesign v2"
+	case "task_progress_updated":
+		data.Value = "75%"
+	case "planning_status_updated":
+		data.Status = "Approved"
+	case "project_status_updated":
+		data.Status = "Done"
+	case "comment_added":
+		data.Value = "The homepage layout is ready for <review>.\nPlease check the attached mockups."
+	case "files_added":
+		data.Files = []domain.EmailFile{
+			{Type: domain.FileTypePlanning, Name: "timeline.pdf", Version: 2, URL: FileDownloadURL(1, 1, domain.FileTypePlanning)},
+			{Type: domain.FileTypeProject, Name: "homepage.png", Version: 1, URL: FileDownloadURL(1, 2, domain.FileTypeProject)},
+		}
+	case "file_quarantined":
+		data.Value = "invoice.pdf.exe"
+		data.Detail = "Win.Test.EICAR_HDB-1"
+	case "invitation_received":
+		data.Value = "manager"
+	case "invitation_responded":
+		data.Value = "employee"
+		data.Status = "accepted"
+		data.Detail = "employee@example.com"
+	case "due_date_reminder":
+		data.Value = "20-10-2026"
+		data.Status = domain.DueTypePlanning
+		data.Days = 3
+	case "overdue_escalation":
+		data.Value = "15-10-2026"
+		data.Status = domain.EscalationLevelManager
+		data.Detail = "Working"
+	case "calendar_invite":
+		data.Description = "Planning due date for Website Redesign"
+	case "digest":
+		data.TaskName = ""
+		data.Value = "18-10-2026"
+		data.Boards = []domain.EmailDigestBoard{
+			{Name: "Marketing", Items: []domain.EmailDigestItem{
+				{TaskName: "Website Redesign", Title: "Planning Description Persen Updated", Time: "17-10-2026 09:12"},
+				{TaskName: "Website Redesign", Title: "New Project Comment Added", Time: "17-10-2026 14:40"},
+			}},
+			{Name: "Operations", Items: []domain.EmailDigestItem{
+				{TaskName: "Office Move", Title: "Task Files Updated", Time: "17-10-2026 16:05"},
+			}},
+		}
+	case "password_reset":
+		data.TaskName = ""
+		data.Code = "123456"
+	}
+	return data, nil
+}
@@ -0,0 +1,88 @@
+package helper
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// prefix versi format data terenkripsi agar kunci atau algoritma bisa diganti kemudian
+const encryptedPrefix = "v1:"
+
+var (
+	ErrEncryptionKeyMissing = errors.New("encryption key is not configured")
+	ErrInvalidCiphertext    = errors.New("invalid encrypted value")
+)
+
+// encryptionKey adalah kunci AES-256 dari ENCRYPTION_KEY (32 byte dalam base64). Jika kosong kunci diturunkan dari
+// SECRET, sehingga mengganti SECRET membuat data terenkripsi lama tidak bisa dibaca lagi
+func encryptionKey() ([]byte, error) {
+	if encoded := os.Getenv("ENCRYPTION_KEY"); encoded != "" {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("ENCRYPTION_KEY must be 32 bytes encoded in base64")
+		}
+		return key, nil
+	}
+	secret := os.Getenv("SECRET")
+	if secret == "" {
+		return nil, ErrEncryptionKeyMissing
+	}
+	key := sha256.Sum256([]byte("encryption:" + secret))
+	return key[:], nil
+}
+
+func encryptionCipher() (cipher.AEAD, error) {
+	key, err := encryptionKey()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptSecret mengenkripsi data rahasia (misalnya refresh token) dengan AES-256-GCM untuk disimpan di database
+func EncryptSecret(plaintext string) (string, error) {
+	gcm, err := encryptionCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptSecret membuka data yang dienkripsi EncryptSecret
+func DecryptSecret(ciphertext string) (string, error) {
+	if !strings.HasPrefix(ciphertext, encryptedPrefix) {
+		return "", ErrInvalidCiphertext
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(ciphertext, encryptedPrefix))
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+	gcm, err := encryptionCipher()
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+	return string(plaintext), nil
+}
@@ -3,6 +3,9 @@ package helper
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -30,3 +33,91 @@ func LoadEnv() {
 		log.Println("All required environment variables are set.")
 	}
 }
+
+// DurationFromEnv membaca durasi (misalnya "15m" atau "24h") dari environment, memakai fallback jika kosong atau tidak valid
+func DurationFromEnv(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		log.Printf("Invalid %s %q, using %s", key, value, fallback)
+		return fallback
+	}
+	return duration
+}
+
+// DurationsFromEnv membaca daftar durasi yang dipisahkan koma (misalnya "72h,24h,2h") dari environment,
+// memakai fallback jika kosong atau ada durasi yang tidak valid
+func DurationsFromEnv(key string, fallback []time.Duration) []time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	var durations []time.Duration
+	for _, part := range strings.Split(value, ",") {
+		duration, err := time.ParseDuration(strings.TrimSpace(part))
+		if err != nil || duration <= 0 {
+			log.Printf("Invalid %s %q, using %v", key, value, fallback)
+			return fallback
+		}
+		durations = append(durations, duration)
+	}
+	return durations
+}
+
+// BoolFromEnv membaca nilai boolean (true/false, 1/0) dari environment, memakai fallback jika kosong atau tidak valid
+func BoolFromEnv(key string, fallback bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid %s %q, using %t", key, value, fallback)
+		return fallback
+	}
+	return enabled
+}
+
+// IntFromEnv membaca bilangan bulat non-negatif dari environment, memakai fallback jika kosong atau tidak valid
+func IntFromEnv(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		log.Printf("Invalid %s %q, using %d", key, value, fallback)
+		return fallback
+	}
+	return number
+}
+
+// ByteSizeFromEnv membaca ukuran dalam byte (misalnya "512KB", "20MB" atau "1048576") dari environment, memakai fallback jika kosong atau tidak valid
+func ByteSizeFromEnv(key string, fallback int64) int64 {
+	value := strings.ToUpper(strings.TrimSpace(os.Getenv(key)))
+	if value == "" {
+		return fallback
+	}
+
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			multiplier = unit.size
+			break
+		}
+	}
+
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || size < 0 {
+		log.Printf("Invalid %s %q, using %d bytes", key, os.Getenv(key), fallback)
+		return fallback
+	}
+	return size * multiplier
+}
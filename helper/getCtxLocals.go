@@ -8,19 +8,28 @@ import (
 )
 
 func GetCtxLocals(ctx *fiber.Ctx) (uint64, error) {
+	user, err := GetCtxUser(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
+
+// GetCtxUser mengembalikan user yang login lewat JWT atau Google OAuth, disimpan pada context oleh middleware AuthUser
+func GetCtxUser(ctx *fiber.Ctx) (*domain.User, error) {
 	user := ctx.Locals("user")
 	if user != nil {
 		if u, ok := user.(*domain.User); ok {
-			return u.ID, nil
+			return u, nil
 		}
 	}
 
 	userOauth := ctx.Locals("userOauth")
 	if userOauth != nil {
 		if u, ok := userOauth.(*domain.User); ok {
-			return u.ID, nil
+			return u, nil
 		}
 	}
 
-	return 0, errors.New("User not found or invalid type")
+	return nil, errors.New("User not found or invalid type")
 }
@@ -0,0 +1,106 @@
+package helper
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	icsProductID = "-//Manajemen Tugas//Due Dates//EN"
+	// panjang baris maksimal RFC 5545 dalam octet, baris yang lebih panjang dilipat
+	icsLineLimit = 75
+	icsDate      = "20060102"
+	icsDateTime  = "20060102T150405Z"
+)
+
+// ICSEvent adalah event sepanjang hari pada feed iCalendar. UID harus tetap sama untuk event yang sama agar aplikasi
+// calendar memperbarui event tersebut, bukan menambah event baru
+type ICSEvent struct {
+	UID         string
+	Date        time.Time
+	Summary     string
+	Description string
+	URL         string
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// RenderICS menyusun VCALENDAR (RFC 5545) berisi event sepanjang hari. stamp dipakai sebagai DTSTAMP setiap event
+func RenderICS(name string, events []ICSEvent, stamp time.Time) []byte {
+	var buf bytes.Buffer
+	writeICSLine(&buf, "BEGIN:VCALENDAR")
+	writeICSLine(&buf, "VERSION:2.0")
+	writeICSLine(&buf, "PRODID:"+icsProductID)
+	writeICSLine(&buf, "CALSCALE:GREGORIAN")
+	writeICSLine(&buf, "METHOD:PUBLISH")
+	writeICSLine(&buf, "X-WR-CALNAME:"+icsEscaper.Replace(name))
+
+	for _, event := range events {
+		writeICSLine(&buf, "BEGIN:VEVENT")
+		writeICSLine(&buf, "UID:"+event.UID)
+		writeICSLine(&buf, "DTSTAMP:"+stamp.UTC().Format(icsDateTime))
+		writeICSLine(&buf, "DTSTART;VALUE=DATE:"+event.Date.Format(icsDate))
+		writeICSLine(&buf, "DTEND;VALUE=DATE:"+event.Date.AddDate(0, 0, 1).Format(icsDate))
+		writeICSLine(&buf, "SUMMARY:"+icsEscaper.Replace(event.Summary))
+		if event.Description != "" {
+			writeICSLine(&buf, "DESCRIPTION:"+icsEscaper.Replace(event.Description))
+		}
+		if ValidHTTPURL(event.URL) {
+			writeICSLine(&buf, "URL:"+event.URL)
+		}
+		// tenggat tidak membuat waktu user terlihat sibuk
+		writeICSLine(&buf, "TRANSP:TRANSPARENT")
+		writeICSLine(&buf, "END:VEVENT")
+	}
+
+	writeICSLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// writeICSLine menulis satu baris dengan CRLF, baris lebih dari 75 octet dilipat tanpa memotong karakter UTF-8
+func writeICSLine(buf *bytes.Buffer, line string) {
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// baris lanjutan diawali spasi sehingga isinya satu octet lebih pendek
+		limit = icsLineLimit - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+// TaskEventUID adalah UID event tenggat task pada feed iCalendar
+func TaskEventUID(taskID uint64, dueType string) string {
+	host := "manajemen-tugas"
+	if appURL, err := url.Parse(os.Getenv("APP_URL")); err == nil && appURL.Hostname() != "" {
+		host = appURL.Hostname()
+	}
+	return fmt.Sprintf("task-%d-%s@%s", taskID, dueType, host)
+}
+
+// GenerateFeedToken membuat token rahasia url feed calendar
+func GenerateFeedToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// CalendarFeedURL adalah url feed iCalendar untuk token, diawali APP_URL jika diisi
+func CalendarFeedURL(token string) string {
+	return fmt.Sprintf("%s/calendar/%s.ics", strings.TrimSuffix(os.Getenv("APP_URL"), "/"), token)
+}
@@ -0,0 +1,158 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// batas isi teks yang dibaca dari email masuk dan kedalaman multipart bersarang
+	inboundTextLimit      = 1 << 20
+	inboundMultipartDepth = 5
+)
+
+var ErrInvalidInboundMail = errors.New("Invalid email message")
+
+// InboundMail adalah email masuk yang sudah dibaca: pengirim, semua alamat penerima dan isi teksnya
+type InboundMail struct {
+	From       string
+	Recipients []string
+	Subject    string
+	Text       string
+	// AutoReply menandai balasan otomatis (misalnya out of office) yang tidak boleh menjadi komentar
+	AutoReply bool
+}
+
+// ParseInboundMail membaca email mentah (MIME). Isi diambil dari bagian text/plain, atau dari text/html jika email
+// tidak memiliki bagian teks. Penerima dibaca dari header Delivered-To, X-Original-To, To dan Cc
+func ParseInboundMail(raw []byte) (*InboundMail, error) {
+	message, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInboundMail, err)
+	}
+	from, err := mail.ParseAddress(message.Header.Get("From"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid sender: %v", ErrInvalidInboundMail, err)
+	}
+
+	inbound := &InboundMail{From: from.Address, AutoReply: isAutoReply(message.Header)}
+	decoder := new(mime.WordDecoder)
+	if subject, err := decoder.DecodeHeader(message.Header.Get("Subject")); err == nil {
+		inbound.Subject = subject
+	}
+	for _, key := range []string{"Delivered-To", "X-Original-To", "To", "Cc"} {
+		for _, value := range message.Header[key] {
+			addresses, err := mail.ParseAddressList(value)
+			if err != nil {
+				continue
+			}
+			for _, address := range addresses {
+				inbound.Recipients = append(inbound.Recipients, address.Address)
+			}
+		}
+	}
+
+	text, html, err := readMailBody(message.Header.Get("Content-Type"), message.Header.Get("Content-Transfer-Encoding"), message.Body, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInboundMail, err)
+	}
+	if strings.TrimSpace(text) == "" && html != "" {
+		text = HTMLToText(html)
+	}
+	inbound.Text = text
+	return inbound, nil
+}
+
+// isAutoReply mengenali balasan otomatis dari header Auto-Submitted (RFC 3834), Precedence dan X-Autoreply
+func isAutoReply(header mail.Header) bool {
+	if submitted := strings.ToLower(strings.TrimSpace(header.Get("Auto-Submitted"))); submitted != "" && submitted != "no" {
+		return true
+	}
+	switch strings.ToLower(strings.TrimSpace(header.Get("Precedence"))) {
+	case "bulk", "junk", "list", "auto_reply":
+		return true
+	}
+	return header.Get("X-Autoreply") != "" || header.Get("X-Autorespond") != ""
+}
+
+// readMailBody mengambil isi text/plain dan text/html pertama dari satu bagian email, lampiran dilewati
+func readMailBody(contentType string, encoding string, body io.Reader, depth int) (text string, html string, err error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if depth >= inboundMultipartDepth || params["boundary"] == "" {
+			return "", "", nil
+		}
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return text, html, nil
+			}
+			if err != nil {
+				return text, html, err
+			}
+			if disposition, _, _ := mime.ParseMediaType(part.Header.Get("Content-Disposition")); disposition == "attachment" {
+				continue
+			}
+			// multipart.Reader sudah men-decode quoted-printable, sehingga encoding hanya diteruskan untuk base64
+			partText, partHTML, err := readMailBody(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part, depth+1)
+			if err != nil {
+				return text, html, err
+			}
+			if text == "" {
+				text = partText
+			}
+			if html == "" {
+				html = partHTML
+			}
+		}
+	}
+	if mediaType != "text/plain" && mediaType != "text/html" {
+		return "", "", nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+	content, err := io.ReadAll(io.LimitReader(body, inboundTextLimit))
+	if err != nil {
+		return "", "", err
+	}
+	decoded := decodeCharset(content, params["charset"])
+	if mediaType == "text/html" {
+		return "", decoded, nil
+	}
+	return decoded, "", nil
+}
+
+// decodeCharset mengubah isi latin-1 menjadi UTF-8, charset lain dianggap sudah UTF-8
+func decodeCharset(content []byte, charset string) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "windows-1252":
+		runes := make([]rune, len(content))
+		for i, b := range content {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	}
+	if !utf8.Valid(content) {
+		return strings.ToValidUTF8(string(content), "�")
+	}
+	return string(content)
+}
@@ -0,0 +1,166 @@
+package helper
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+const (
+	inboundSMTPMessageLimit = 10 << 20
+	inboundSMTPRecipients   = 100
+	inboundSMTPTimeout      = 5 * time.Minute
+)
+
+// InboundMailHandler memproses satu email masuk beserta penerima dari envelope SMTP
+type InboundMailHandler func(recipients []string, raw []byte) error
+
+// ServeInboundSMTP menjalankan server SMTP sederhana tanpa TLS dan autentikasi untuk menerima balasan email saat
+// development. Email yang ditolak handler dijawab 550 dengan pesan error-nya
+func ServeInboundSMTP(address string, handler InboundMailHandler) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	log.Printf("Inbound SMTP listening on %s", listener.Addr())
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go serveInboundSMTPConn(conn, handler)
+	}
+}
+
+func serveInboundSMTPConn(conn net.Conn, handler InboundMailHandler) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	reply := func(code int, message string) error {
+		return text.PrintfLine("%d %s", code, message)
+	}
+
+	var sender string
+	var recipients []string
+	reset := func() {
+		sender = ""
+		recipients = nil
+	}
+
+	conn.SetDeadline(time.Now().Add(inboundSMTPTimeout))
+	if reply(220, "manajemen-tugas inbound ESMTP") != nil {
+		return
+	}
+	for {
+		conn.SetDeadline(time.Now().Add(inboundSMTPTimeout))
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		command, argument, _ := strings.Cut(line, " ")
+		argument = strings.TrimSpace(argument)
+
+		switch strings.ToUpper(command) {
+		case "HELO":
+			err = reply(250, "Hello")
+		case "EHLO":
+			err = text.PrintfLine("250-Hello\r\n250-SIZE %d\r\n250 8BITMIME", inboundSMTPMessageLimit)
+		case "MAIL":
+			address, ok := smtpPath(argument, "FROM:")
+			if !ok {
+				err = reply(501, "Syntax: MAIL FROM:<address>")
+				break
+			}
+			reset()
+			sender = address
+			err = reply(250, "OK")
+		case "RCPT":
+			address, ok := smtpPath(argument, "TO:")
+			switch {
+			case !ok || address == "":
+				err = reply(501, "Syntax: RCPT TO:<address>")
+			case len(recipients) >= inboundSMTPRecipients:
+				err = reply(452, "Too many recipients")
+			default:
+				recipients = append(recipients, address)
+				err = reply(250, "OK")
+			}
+		case "DATA":
+			if len(recipients) == 0 {
+				err = reply(503, "RCPT first")
+				break
+			}
+			if err = reply(354, "End data with <CR><LF>.<CR><LF>"); err != nil {
+				return
+			}
+			err = receiveInboundSMTPData(text, recipients, handler, reply)
+			log.Printf("Inbound SMTP: message from %s to %s", smtpPathString(sender), strings.Join(recipients, ", "))
+			reset()
+		case "RSET":
+			reset()
+			err = reply(250, "OK")
+		case "NOOP":
+			err = reply(250, "OK")
+		case "QUIT":
+			reply(221, "Bye")
+			return
+		default:
+			err = reply(502, "Command not implemented")
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// receiveInboundSMTPData membaca isi email sampai baris "." lalu meneruskannya ke handler
+func receiveInboundSMTPData(text *textproto.Conn, recipients []string, handler InboundMailHandler, reply func(int, string) error) error {
+	data := text.DotReader()
+	raw, err := io.ReadAll(io.LimitReader(data, inboundSMTPMessageLimit+1))
+	if err != nil {
+		return err
+	}
+	if len(raw) > inboundSMTPMessageLimit {
+		if _, err := io.Copy(io.Discard, data); err != nil {
+			return err
+		}
+		return reply(552, "Message too large")
+	}
+	if err := handler(recipients, raw); err != nil {
+		return reply(550, headerValue(err.Error()))
+	}
+	return reply(250, "OK")
+}
+
+// smtpPath membaca alamat dari argumen MAIL FROM:<...> dan RCPT TO:<...>, parameter ESMTP setelahnya diabaikan
+func smtpPath(argument string, prefix string) (string, bool) {
+	if len(argument) < len(prefix) || !strings.EqualFold(argument[:len(prefix)], prefix) {
+		return "", false
+	}
+	path := strings.TrimSpace(argument[len(prefix):])
+	if fields := strings.Fields(path); len(fields) > 0 {
+		path = fields[0]
+	}
+	path = strings.TrimSuffix(strings.TrimPrefix(path, "<"), ">")
+	if path == "" {
+		return "", true
+	}
+	address, err := mail.ParseAddress(path)
+	if err != nil {
+		return "", false
+	}
+	return address.Address, true
+}
+
+// smtpPathString dipakai untuk log agar alamat kosong (bounce) tetap terbaca
+func smtpPathString(address string) string {
+	if address == "" {
+		return "<>"
+	}
+	return fmt.Sprintf("<%s>", address)
+}
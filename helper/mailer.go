@@ -0,0 +1,242 @@
+package helper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const defaultMailFrom = "manajementugasapp@gmail.com"
+
+// ErrMailerNotConfigured dikembalikan driver smtp jika SMTP_HOST belum diisi
+var ErrMailerNotConfigured = errors.New("mailer is not configured")
+
+// MailMessage adalah satu email untuk satu penerima, From kosong berarti memakai alamat pengirim dari MAIL_FROM.
+// ReplyTo diisi dengan alamat balasan jika balasan email menjadi komentar task
+type MailMessage struct {
+	From     string
+	To       string
+	ReplyTo  string
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// Mailer adalah abstraksi pengiriman email, sehingga service tidak bergantung langsung pada SMTP atau AWS SDK
+type Mailer interface {
+	Send(ctx context.Context, message *MailMessage) error
+}
+
+// RecipientsError dikembalikan SendToEach jika sebagian penerima gagal dikirimi, Failed berisi penerima yang perlu dicoba ulang
+type RecipientsError struct {
+	Failed []string
+	Err    error
+}
+
+func (e *RecipientsError) Error() string {
+	return fmt.Sprintf("failed to send email to %s: %v", strings.Join(e.Failed, ", "), e.Err)
+}
+
+func (e *RecipientsError) Unwrap() error {
+	return e.Err
+}
+
+// NewMailerFromEnv membuat mailer sesuai MAIL_DRIVER: smtp (default), ses, file atau log
+func NewMailerFromEnv() (Mailer, error) {
+	from, err := mailFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	driver := strings.ToLower(os.Getenv("MAIL_DRIVER"))
+	switch driver {
+	case "", "smtp":
+		return NewSMTPMailer(from), nil
+	case "ses":
+		return NewSESMailer(from, os.Getenv("MAIL_SES_REGION"))
+	case "file":
+		dir := os.Getenv("MAIL_FILE_DIR")
+		if dir == "" {
+			dir = "mail"
+		}
+		return NewFileMailer(from, dir)
+	case "log":
+		return NewLogMailer(from), nil
+	default:
+		return nil, fmt.Errorf("unknown MAIL_DRIVER %q", driver)
+	}
+}
+
+// mailFromEnv membaca alamat pengirim dari MAIL_FROM dan nama pengirim dari MAIL_FROM_NAME
+func mailFromEnv() (string, error) {
+	address := os.Getenv("MAIL_FROM")
+	if address == "" {
+		address = defaultMailFrom
+	}
+	from, err := mail.ParseAddress(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid MAIL_FROM %q: %w", address, err)
+	}
+	if name := os.Getenv("MAIL_FROM_NAME"); name != "" {
+		from.Name = name
+	}
+	return from.String(), nil
+}
+
+// ReplyToFunc mengembalikan alamat Reply-To untuk satu penerima, kosong berarti tanpa Reply-To
+type ReplyToFunc func(recipient string) string
+
+// SendToEach mengirim email terpisah ke setiap penerima sehingga penerima tidak melihat alamat penerima lain.
+// Body teks dibuat dari body html jika kosong, replyTo boleh nil
+func SendToEach(ctx context.Context, mailer Mailer, to []string, subject string, htmlBody string, textBody string, replyTo ReplyToFunc) error {
+	if textBody == "" {
+		textBody = HTMLToText(htmlBody)
+	}
+
+	var (
+		failed  []string
+		lastErr error
+		seen    = make(map[string]bool, len(to))
+	)
+	for _, recipient := range to {
+		recipient = strings.TrimSpace(recipient)
+		if recipient == "" || seen[strings.ToLower(recipient)] {
+			continue
+		}
+		seen[strings.ToLower(recipient)] = true
+
+		message := &MailMessage{To: recipient, Subject: subject, HTMLBody: htmlBody, TextBody: textBody}
+		if replyTo != nil {
+			message.ReplyTo = replyTo(recipient)
+		}
+		err := mailer.Send(ctx, message)
+		if err != nil {
+			log.Printf("Failed to send email to %s: %v", recipient, err)
+			failed = append(failed, recipient)
+			lastErr = err
+		}
+	}
+
+	if len(failed) > 0 {
+		return &RecipientsError{Failed: failed, Err: lastErr}
+	}
+	return nil
+}
+
+// BuildMIMEMessage menyusun email multipart/alternative berisi bagian teks dan html, dikirim apa adanya oleh semua driver
+func BuildMIMEMessage(from string, message *MailMessage) ([]byte, error) {
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender %q: %w", from, err)
+	}
+	recipient, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient %q: %w", message.To, err)
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", message.TextBody},
+		{"text/html; charset=UTF-8", message.HTMLBody},
+	} {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoder := quotedprintable.NewWriter(partWriter)
+		if _, err := encoder.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	headers := [][2]string{
+		{"From", sender.String()},
+		{"To", recipient.String()},
+	}
+	if message.ReplyTo != "" {
+		replyTo, err := mail.ParseAddress(message.ReplyTo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reply-to %q: %w", message.ReplyTo, err)
+		}
+		headers = append(headers, [2]string{"Reply-To", replyTo.String()})
+	}
+	headers = append(headers, [][2]string{
+		{"Subject", mime.QEncoding.Encode("UTF-8", headerValue(message.Subject))},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%d.%s@%s>", time.Now().UnixNano(), GenerateRandomCode(12), addressDomain(sender.Address))},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", writer.Boundary())},
+	}...)
+
+	var raw bytes.Buffer
+	for _, header := range headers {
+		fmt.Fprintf(&raw, "%s: %s\r\n", header[0], header[1])
+	}
+	raw.WriteString("\r\n")
+	raw.Write(body.Bytes())
+
+	return raw.Bytes(), nil
+}
+
+// envelopeAddress adalah alamat email tanpa nama, dipakai sebagai pengirim dan penerima pada envelope SMTP dan SES
+func envelopeAddress(address string) (string, error) {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid address %q: %w", address, err)
+	}
+	return parsed.Address, nil
+}
+
+// headerValue menghapus baris baru agar nilai dari user tidak bisa menyisipkan header lain
+func headerValue(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func addressDomain(address string) string {
+	if at := strings.LastIndex(address, "@"); at >= 0 {
+		return address[at+1:]
+	}
+	return "localhost"
+}
+
+var (
+	htmlIgnoredBlock = regexp.MustCompile(`(?is)<(head|style|script)[^>]*>.*?</(head|style|script)>`)
+	htmlLineBreak    = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|h[1-6]|li|tr|ul|ol|table)>`)
+	htmlTag          = regexp.MustCompile(`(?s)<[^>]*>`)
+	blankLines       = regexp.MustCompile(`\n{3,}`)
+)
+
+// HTMLToText membuat alternatif teks sederhana dari body html untuk client email yang tidak menampilkan html
+func HTMLToText(htmlBody string) string {
+	text := htmlIgnoredBlock.ReplaceAllString(htmlBody, "")
+	text = htmlLineBreak.ReplaceAllString(text, "\n")
+	text = html.UnescapeString(htmlTag.ReplaceAllString(text, ""))
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
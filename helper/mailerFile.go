@@ -0,0 +1,60 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type fileMailer struct {
+	from string
+	dir  string
+}
+
+// NewFileMailer membuat mailer untuk development yang menulis setiap email sebagai file .eml pada dir,
+// file tersebut bisa dibuka dengan client email untuk melihat hasilnya
+func NewFileMailer(from string, dir string) (Mailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("Error creating mail directory: %w", err)
+	}
+	return &fileMailer{from: from, dir: dir}, nil
+}
+
+func (f *fileMailer) Send(ctx context.Context, message *MailMessage) error {
+	from := message.From
+	if from == "" {
+		from = f.from
+	}
+	raw, err := BuildMIMEMessage(from, message)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102T150405.000000000"), GenerateRandomCode(8))
+	return os.WriteFile(filepath.Join(f.dir, name), raw, 0o644)
+}
+
+type logMailer struct {
+	from string
+}
+
+// NewLogMailer membuat mailer untuk development yang hanya mencatat penerima, subjek dan body teks email pada log
+func NewLogMailer(from string) Mailer {
+	return &logMailer{from: from}
+}
+
+func (l *logMailer) Send(ctx context.Context, message *MailMessage) error {
+	from := message.From
+	if from == "" {
+		from = l.from
+	}
+	if _, err := BuildMIMEMessage(from, message); err != nil {
+		return err
+	}
+
+	log.Printf("Email from %s to %s: %s\n%s", from, message.To, message.Subject, message.TextBody)
+	return nil
+}
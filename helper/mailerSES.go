@@ -0,0 +1,54 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+)
+
+type sesMailer struct {
+	from   string
+	client *sesv2.Client
+}
+
+// NewSESMailer membuat mailer Amazon SES v2 dengan konfigurasi AWS default, region bisa diganti dengan MAIL_SES_REGION
+func NewSESMailer(from string, region string) (Mailer, error) {
+	var opts []func(*config.LoadOptions) error
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("Error loading AWS config: %w", err)
+	}
+
+	return &sesMailer{from: from, client: sesv2.NewFromConfig(cfg)}, nil
+}
+
+func (s *sesMailer) Send(ctx context.Context, message *MailMessage) error {
+	from := message.From
+	if from == "" {
+		from = s.from
+	}
+	raw, err := BuildMIMEMessage(from, message)
+	if err != nil {
+		return err
+	}
+	recipient, err := envelopeAddress(message.To)
+	if err != nil {
+		return err
+	}
+
+	// email dikirim sebagai raw MIME agar isinya sama persis dengan driver lain
+	_, err = s.client.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(from),
+		Destination:      &types.Destination{ToAddresses: []string{recipient}},
+		Content:          &types.EmailContent{Raw: &types.RawMessage{Data: raw}},
+	})
+	return err
+}
@@ -0,0 +1,142 @@
+package helper
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"os"
+)
+
+const defaultSMTPPort = "587"
+
+type smtpMailer struct {
+	from     string
+	host     string
+	port     string
+	username string
+	password string
+}
+
+// NewSMTPMailer membuat mailer SMTP (misalnya Brevo) dari SMTP_HOST, SMTP_PORT, SMTP_USERNAME dan SMTP_PASSWORD.
+// BREVO_USERNAME, BREVO_PASSWORD dan SMPTP_PORT yang lama masih dibaca jika nama baru tidak diisi
+func NewSMTPMailer(from string) Mailer {
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		if port = os.Getenv("SMPTP_PORT"); port != "" {
+			log.Println("SMPTP_PORT is deprecated, use SMTP_PORT")
+		} else {
+			port = defaultSMTPPort
+		}
+	}
+
+	return &smtpMailer{
+		from:     from,
+		host:     os.Getenv("SMTP_HOST"),
+		port:     port,
+		username: envWithFallback("SMTP_USERNAME", "BREVO_USERNAME"),
+		password: envWithFallback("SMTP_PASSWORD", "BREVO_PASSWORD"),
+	}
+}
+
+func (s *smtpMailer) Send(ctx context.Context, message *MailMessage) error {
+	if s.host == "" {
+		return fmt.Errorf("%w: SMTP_HOST is empty", ErrMailerNotConfigured)
+	}
+
+	from := message.From
+	if from == "" {
+		from = s.from
+	}
+	raw, err := BuildMIMEMessage(from, message)
+	if err != nil {
+		return err
+	}
+	sender, err := envelopeAddress(from)
+	if err != nil {
+		return err
+	}
+	recipient, err := envelopeAddress(message.To)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+
+	if err := s.sendMail(ctx, auth, sender, recipient, raw); err != nil {
+		// koneksi yang ditutup karena context selesai dilaporkan sebagai timeout atau pembatalan
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return err
+	}
+	return nil
+}
+
+// sendMail sama dengan smtp.SendMail, tetapi koneksinya dibuka dengan context dan diberi deadline context
+// sehingga server SMTP yang lambat tidak menahan pengiriman lewat dari batas waktunya
+func (s *smtpMailer) sendMail(ctx context.Context, auth smtp.Auth, sender string, recipient string, raw []byte) error {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", net.JoinHostPort(s.host, s.port))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	// context yang dibatalkan tanpa deadline tetap menghentikan pengiriman
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(sender); err != nil {
+		return err
+	}
+	if err := client.Rcpt(recipient); err != nil {
+		return err
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(raw); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func envWithFallback(key string, fallbackKey string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return os.Getenv(fallbackKey)
+}
@@ -0,0 +1,104 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress dikembalikan saat url yang didaftarkan user mengarah ke alamat internal server
+var ErrForbiddenAddress = errors.New("destination address is not allowed")
+
+// batas redirect yang diikuti client, sama dengan bawaan net/http
+const publicClientMaxRedirects = 10
+
+// alamat yang tidak termasuk pemeriksaan bawaan net.IP: "this network" dan shared address space (CGNAT) yang
+// dipakai beberapa layanan cloud untuk metadata
+var nonPublicNetworks = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// PublicIP mengembalikan false untuk alamat loopback, private, link-local, unspecified dan multicast
+func PublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// PublicWebhookURL memeriksa url http atau https yang host-nya bukan localhost atau alamat IP internal. Nama host
+// tetap diperiksa lagi saat koneksi dibuat oleh NewPublicHTTPClient karena hasil DNS bisa berubah
+func PublicWebhookURL(rawURL string) bool {
+	if !ValidHTTPURL(rawURL) {
+		return false
+	}
+	parsed, _ := url.Parse(rawURL)
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return PublicIP(ip)
+	}
+	return true
+}
+
+// publicDialControl menolak koneksi ke alamat internal setelah host di-resolve, sehingga DNS rebinding ikut tertolak
+func publicDialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !PublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	return nil
+}
+
+// NewPublicHTTPClient membuat client untuk url yang didaftarkan user (webhook, integrasi chat). Koneksi dan redirect
+// ke alamat internal ditolak, dan proxy dari environment tidak dipakai agar pemeriksaan alamat tidak terlewati
+func NewPublicHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   publicDialControl,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(request *http.Request, via []*http.Request) error {
+			if len(via) >= publicClientMaxRedirects {
+				return fmt.Errorf("stopped after %d redirects", publicClientMaxRedirects)
+			}
+			if !PublicWebhookURL(request.URL.String()) {
+				return fmt.Errorf("%w: redirect to %s", ErrForbiddenAddress, request.URL.Host)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,149 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/mail"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// alamat balasan berbentuk reply+<task id>.<user id>.<signature>@REPLY_DOMAIN
+	replyAddressPrefix = "reply+"
+	// signature alamat balasan adalah 12 byte pertama HMAC-SHA256 dalam hex agar local part tetap pendek
+	replySignatureBytes = 12
+)
+
+// ReplyEnabled mengembalikan true jika balasan email diaktifkan dengan REPLY_DOMAIN dan REPLY_SECRET untuk signature
+// alamat balasan diisi
+func ReplyEnabled() bool {
+	return replyDomain() != "" && replySecret() != ""
+}
+
+func replyDomain() string {
+	return strings.ToLower(strings.TrimSpace(os.Getenv("REPLY_DOMAIN")))
+}
+
+func replySecret() string {
+	return os.Getenv("REPLY_SECRET")
+}
+
+func replySignature(taskID uint64, userID uint64) string {
+	mac := hmac.New(sha256.New, []byte(replySecret()))
+	fmt.Fprintf(mac, "reply:%d:%d", taskID, userID)
+	return hex.EncodeToString(mac.Sum(nil)[:replySignatureBytes])
+}
+
+// ReplyAddress membuat alamat balasan bertanda tangan untuk satu user pada satu task, kosong jika balasan email
+// tidak diaktifkan
+func ReplyAddress(taskID uint64, userID uint64) string {
+	if !ReplyEnabled() || taskID == 0 || userID == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s%d.%d.%s@%s", replyAddressPrefix, taskID, userID, replySignature(taskID, userID), replyDomain())
+}
+
+// ParseReplyAddress membaca task dan user dari alamat balasan. ok bernilai false jika alamat bukan alamat balasan
+// atau signature-nya tidak cocok
+func ParseReplyAddress(address string) (taskID uint64, userID uint64, ok bool) {
+	if parsed, err := mail.ParseAddress(address); err == nil {
+		address = parsed.Address
+	}
+	at := strings.LastIndex(address, "@")
+	if at < 0 || !ReplyEnabled() || strings.ToLower(address[at+1:]) != replyDomain() {
+		return 0, 0, false
+	}
+	local := strings.ToLower(address[:at])
+	if !strings.HasPrefix(local, replyAddressPrefix) {
+		return 0, 0, false
+	}
+
+	parts := strings.Split(strings.TrimPrefix(local, replyAddressPrefix), ".")
+	if len(parts) != 3 {
+		return 0, 0, false
+	}
+	taskID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	userID, err = strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(replySignature(taskID, userID))) {
+		return 0, 0, false
+	}
+	return taskID, userID, true
+}
+
+var (
+	// baris pembuka kutipan dari Gmail, Apple Mail, Outlook dan penanda balasan pada email notifikasi
+	replyQuoteHeaders = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)^on\s.+\swrote:$`),
+		regexp.MustCompile(`(?i)^pada\s.+\smenulis:$`),
+		regexp.MustCompile(`(?i)^-+\s*(original message|pesan asli)\s*-+$`),
+		regexp.MustCompile(`^_{10,}$`),
+		regexp.MustCompile(`^##-.*-##$`),
+	}
+	// blok header email yang dikutip Outlook, misalnya "From: ..." diikuti "Sent: ..."
+	replyQuotedFrom    = regexp.MustCompile(`(?i)^(from|dari):\s`)
+	replyQuotedSent    = regexp.MustCompile(`(?i)^(sent|date|dikirim|tanggal):\s`)
+	replyMobileFooter  = regexp.MustCompile(`(?i)^(sent from my|dikirim dari)\s`)
+	replySignatureLine = regexp.MustCompile(`^--\s?$`)
+)
+
+// StripQuotedReply mengambil teks balasan saja dari isi email: kutipan email sebelumnya, tanda tangan dan
+// footer aplikasi email di ponsel dibuang
+func StripQuotedReply(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	end := len(lines)
+	for i := 0; i < len(lines) && end == len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		next := ""
+		if i+1 < len(lines) {
+			next = strings.TrimSpace(lines[i+1])
+		}
+		switch {
+		case replySignatureLine.MatchString(lines[i]):
+			end = i
+		case replyQuotedFrom.MatchString(line) && replyQuotedSent.MatchString(next):
+			end = i
+		case matchesAny(replyQuoteHeaders, line):
+			end = i
+		// Gmail memecah baris "On ... wrote:" yang panjang menjadi dua baris
+		case next != "" && matchesAny(replyQuoteHeaders, line+" "+next):
+			end = i
+		}
+	}
+
+	var reply []string
+	for _, line := range lines[:end] {
+		if strings.HasPrefix(strings.TrimSpace(line), ">") {
+			continue
+		}
+		reply = append(reply, strings.TrimRight(line, " \t"))
+	}
+	for len(reply) > 0 {
+		last := strings.TrimSpace(reply[len(reply)-1])
+		if last != "" && !replyMobileFooter.MatchString(last) {
+			break
+		}
+		reply = reply[:len(reply)-1]
+	}
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(reply, "\n"), "\n\n"))
+}
+
+func matchesAny(patterns []*regexp.Regexp, value string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
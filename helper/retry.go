@@ -0,0 +1,16 @@
+package helper
+
+import "time"
+
+// RetryBackoff adalah jeda sebelum percobaan berikutnya: base setelah percobaan pertama, lalu dua kalinya, empat kalinya
+// dan seterusnya sampai paling lama maxDelay
+func RetryBackoff(base time.Duration, maxDelay time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
@@ -0,0 +1,130 @@
+package helper
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+var ErrScannerUnavailable = errors.New("Virus scanner unavailable")
+
+// ScanResult adalah hasil pemindaian satu file, Skipped berarti file tidak benar-benar dipindai
+type ScanResult struct {
+	Infected  bool
+	Signature string
+	Skipped   bool
+}
+
+// Scanner memindai isi file sebelum file dilampirkan pada task
+type Scanner interface {
+	Scan(ctx context.Context, body io.Reader) (*ScanResult, error)
+}
+
+// NewScannerFromEnv memilih scanner berdasarkan SCANNER_DRIVER: "none" (default, untuk development) atau "clamav"
+func NewScannerFromEnv() (Scanner, error) {
+	driver := strings.ToLower(os.Getenv("SCANNER_DRIVER"))
+	switch driver {
+	case "", "none", "noop":
+		return NoopScanner{}, nil
+	case "clamav", "clamd":
+		address := os.Getenv("CLAMD_ADDRESS")
+		if address == "" {
+			address = "localhost:3310"
+		}
+		return NewClamdScanner(address, DurationFromEnv("CLAMD_TIMEOUT", time.Minute)), nil
+	default:
+		return nil, fmt.Errorf("unknown SCANNER_DRIVER %q", driver)
+	}
+}
+
+// NoopScanner tidak memindai apapun, setiap file ditandai skipped
+type NoopScanner struct{}
+
+func (NoopScanner) Scan(ctx context.Context, body io.Reader) (*ScanResult, error) {
+	return &ScanResult{Skipped: true}, nil
+}
+
+type clamdScanner struct {
+	address string
+	timeout time.Duration
+}
+
+// NewClamdScanner memindai file melalui perintah INSTREAM pada clamd lewat TCP
+func NewClamdScanner(address string, timeout time.Duration) Scanner {
+	return &clamdScanner{address: address, timeout: timeout}
+}
+
+// ukuran potongan yang dikirim ke clamd, harus lebih kecil dari StreamMaxLength pada clamd.conf
+const clamdChunkSize = 64 << 10
+
+func (c *clamdScanner) Scan(ctx context.Context, body io.Reader) (*ScanResult, error) {
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrScannerUnavailable, err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(c.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	conn.SetDeadline(deadline)
+
+	// protokol INSTREAM: perintah diakhiri null, lalu potongan dengan panjang 4 byte big-endian, ditutup potongan kosong
+	writer := bufio.NewWriter(conn)
+	if _, err := writer.WriteString("zINSTREAM\x00"); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrScannerUnavailable, err)
+	}
+	chunk := make([]byte, clamdChunkSize)
+	for {
+		n, readErr := body.Read(chunk)
+		if n > 0 {
+			if err := binary.Write(writer, binary.BigEndian, uint32(n)); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrScannerUnavailable, err)
+			}
+			if _, err := writer.Write(chunk[:n]); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrScannerUnavailable, err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
+	}
+	if err := binary.Write(writer, binary.BigEndian, uint32(0)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrScannerUnavailable, err)
+	}
+	if err := writer.Flush(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrScannerUnavailable, err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadBytes(0)
+	if err != nil && !(errors.Is(err, io.EOF) && len(reply) > 0) {
+		return nil, fmt.Errorf("%w: %v", ErrScannerUnavailable, err)
+	}
+	return parseClamdReply(string(bytes.TrimRight(reply, "\x00\n")))
+}
+
+// parseClamdReply membaca balasan clamd seperti "stream: OK" atau "stream: Eicar-Signature FOUND"
+func parseClamdReply(reply string) (*ScanResult, error) {
+	result := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+	switch {
+	case result == "OK":
+		return &ScanResult{}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return &ScanResult{Infected: true, Signature: strings.TrimSuffix(result, " FOUND")}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrScannerUnavailable, reply)
+	}
+}
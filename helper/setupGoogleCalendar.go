@@ -3,21 +3,35 @@ package helper
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"net/http"
+	"net/url"
 	"os"
-	"os/exec"
-	"runtime"
+	"strings"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 )
 
-func createCalendarService(userEmail string) (*calendar.Service, error) {
+const (
+	googleUserInfoURL = "https://www.googleapis.com/oauth2/v2/userinfo"
+	googleRevokeURL   = "https://oauth2.googleapis.com/revoke"
+)
+
+// ErrGoogleCalendarNotConfigured dikembalikan jika GOOGLE_CALENDAR_CREDENTIALS kosong
+var ErrGoogleCalendarNotConfigured = errors.New("GOOGLE_CALENDAR_CREDENTIALS is empty")
+
+// GoogleCalendarConfig membuat konfigurasi OAuth Google Calendar dari client pada GOOGLE_CALENDAR_CREDENTIALS.
+// Redirect url diambil dari GOOGLE_CALENDAR_REDIRECT_URL, atau APP_URL + /calendar/google/callback jika kosong
+func GoogleCalendarConfig() (*oauth2.Config, error) {
 	credentials := os.Getenv("GOOGLE_CALENDAR_CREDENTIALS")
+	if credentials == "" {
+		return nil, ErrGoogleCalendarNotConfigured
+	}
 
 	var credentialsJSON map[string]interface{}
 
@@ -43,116 +57,71 @@ func createCalendarService(userEmail string) (*calendar.Service, error) {
 		return nil, fmt.Errorf("unable to marshal credentials: %v", err)
 	}
 
-	config, err := google.ConfigFromJSON(credentialsBytes, calendar.CalendarScope)
+	// email dibutuhkan untuk menampilkan akun Google yang terhubung
+	config, err := google.ConfigFromJSON(credentialsBytes, calendar.CalendarEventsScope, "email")
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse client secret to config: %v", err)
 	}
 
-	ctx := context.Background()
-	client := getClient(config, userEmail)
-
-	srv, err := calendar.NewService(ctx, option.WithHTTPClient(client))
-	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Calendar client: %v", err)
-	}
-
-	return srv, nil
-}
-
-func openBrowser(url string) error {
-	var cmd string
-	var args []string
-
-	switch runtime.GOOS {
-	case "linux":
-		cmd = "xdg-open"
-	case "windows":
-		cmd = "rundll32"
-		args = append(args, "url.dll,FileProtocolHandler")
-	case "darwin":
-		cmd = "open"
-	default:
-		return fmt.Errorf("unsupported platform")
+	config.RedirectURL = os.Getenv("GOOGLE_CALENDAR_REDIRECT_URL")
+	if config.RedirectURL == "" {
+		config.RedirectURL = strings.TrimSuffix(os.Getenv("APP_URL"), "/") + "/calendar/google/callback"
 	}
-
-	args = append(args, url)
-	return exec.Command(cmd, args...).Start()
+	return config, nil
 }
 
-func getTokenFromWeb(config *oauth2.Config) *oauth2.Token {
-	authURL := config.AuthCodeURL("state-token", oauth2.AccessTypeOffline, oauth2.ApprovalForce)
-	fmt.Printf("Go to the following link in your browser: \n%v\n", authURL)
-
-	err := openBrowser(authURL)
+// GoogleAccountEmail mengambil alamat email akun Google pemilik token
+func GoogleAccountEmail(ctx context.Context, client *http.Client) (string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, googleUserInfoURL, nil)
 	if err != nil {
-		log.Fatalf("Unable to open browser: %v", err)
-	}
-
-	// Start a local server to handle the callback
-	codeChan := make(chan string)
-	srv := &http.Server{Addr: "localhost:4040"}
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		code := r.URL.Query().Get("code")
-		if code != "" {
-			fmt.Fprintf(w, "Authorization code received. You can close this window.")
-			codeChan <- code
-		} else {
-			fmt.Fprintf(w, "No authorization code received.")
-		}
-	})
-	go func() {
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Unable to start server: %v", err)
-		}
-	}()
-
-	code := <-codeChan
-	srv.Shutdown(context.TODO())
-
-	tok, err := config.Exchange(context.TODO(), code)
+		return "", err
+	}
+	response, err := client.Do(request)
 	if err != nil {
-		log.Fatalf("Unable to retrieve token from web: %v", err)
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("userinfo responded with status %d", response.StatusCode)
 	}
-	return tok
-}
 
-func getClient(config *oauth2.Config, userEmail string) *http.Client {
-	tokFile := fmt.Sprintf("token_%s.json", userEmail)
-	tok, err := tokenFromFile(tokFile)
-	if err != nil {
-		tok = getTokenFromWeb(config)
-		saveToken(tokFile, tok)
+	var userInfo struct {
+		Email string `json:"email"`
 	}
-	return config.Client(context.Background(), tok)
+	if err := json.NewDecoder(response.Body).Decode(&userInfo); err != nil {
+		return "", err
+	}
+	return userInfo.Email, nil
 }
 
-func tokenFromFile(file string) (*oauth2.Token, error) {
-	f, err := os.Open(file)
+// RevokeGoogleToken mencabut refresh token di Google sehingga aplikasi hilang dari akun Google user
+func RevokeGoogleToken(ctx context.Context, token string) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, googleRevokeURL, strings.NewReader(url.Values{"token": {token}}.Encode()))
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer f.Close()
-	tok := &oauth2.Token{}
-	err = json.NewDecoder(f).Decode(tok)
-	return tok, err
-}
-
-func saveToken(path string, token *oauth2.Token) {
-	fmt.Printf("Saving credential file to: %s\n", path)
-	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	response, err := http.DefaultClient.Do(request)
 	if err != nil {
-		log.Fatalf("Unable to cache oauth token: %v", err)
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("revoke responded with status %d", response.StatusCode)
 	}
-	defer f.Close()
-	json.NewEncoder(f).Encode(token)
+	return nil
 }
 
-func CreateGoogleCalendarEvent(senderEmail, summary, description, startDateTime, endDateTime, timeZone string, attendees []string) (*calendar.Event, error) {
-	srv, err := createCalendarService(senderEmail)
+// NewGoogleCalendarService membuat client Google Calendar yang memakai token user, token di-refresh otomatis oleh tokenSource
+func NewGoogleCalendarService(ctx context.Context, tokenSource oauth2.TokenSource) (*calendar.Service, error) {
+	srv, err := calendar.NewService(ctx, option.WithTokenSource(tokenSource))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unable to retrieve Calendar client: %v", err)
 	}
+	return srv, nil
+}
 
+func CreateGoogleCalendarEvent(srv *calendar.Service, senderEmail, summary, description, startDateTime, endDateTime, timeZone string, attendees []string) (*calendar.Event, error) {
 	event := &calendar.Event{
 		Summary:     summary,
 		Description: description,
@@ -164,21 +133,63 @@ func CreateGoogleCalendarEvent(senderEmail, summary, description, startDateTime,
 			DateTime: endDateTime,
 			TimeZone: timeZone,
 		},
-		Attendees: make([]*calendar.EventAttendee, 0, len(attendees)),
-	}
-
-	// Hanya tambahkan attendee jika bukan pengirim
-	for _, email := range attendees {
-		if email != senderEmail {
-			event.Attendees = append(event.Attendees, &calendar.EventAttendee{Email: email})
-		}
+		Attendees: googleCalendarAttendees(senderEmail, attendees),
 	}
 
 	calendarId := "primary"
-	event, err = srv.Events.Insert(calendarId, event).Do()
+	event, err := srv.Events.Insert(calendarId, event).Do()
 	if err != nil {
-		return nil, fmt.Errorf("unable to create event: %v", err)
+		return nil, fmt.Errorf("unable to create event: %w", err)
+	}
+
+	return event, nil
+}
+
+// UpdateGoogleCalendarEvent memperbarui event yang sudah ada. Daftar attendee selalu diganti sehingga attendee yang
+// tidak ada lagi di daftar ikut dihapus; waktu mulai atau selesai yang kosong tidak diubah
+func UpdateGoogleCalendarEvent(srv *calendar.Service, eventID, senderEmail, summary, description, startDateTime, endDateTime, timeZone string, attendees []string) (*calendar.Event, error) {
+	event := &calendar.Event{
+		Summary:     summary,
+		Description: description,
+		Attendees:   googleCalendarAttendees(senderEmail, attendees),
+		// daftar attendee kosong tetap dikirim agar semua attendee dihapus
+		ForceSendFields: []string{"Attendees"},
+	}
+	if startDateTime != "" {
+		event.Start = &calendar.EventDateTime{DateTime: startDateTime, TimeZone: timeZone}
+	}
+	if endDateTime != "" {
+		event.End = &calendar.EventDateTime{DateTime: endDateTime, TimeZone: timeZone}
 	}
 
+	event, err := srv.Events.Patch("primary", eventID, event).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to update event: %w", err)
+	}
 	return event, nil
 }
+
+// DeleteGoogleCalendarEvent membatalkan event, event yang sudah dihapus di Google dianggap berhasil
+func DeleteGoogleCalendarEvent(srv *calendar.Service, eventID string) error {
+	if err := srv.Events.Delete("primary", eventID).Do(); err != nil && !GoogleCalendarEventGone(err) {
+		return fmt.Errorf("unable to delete event: %w", err)
+	}
+	return nil
+}
+
+// GoogleCalendarEventGone memeriksa apakah error berasal dari event yang tidak ditemukan atau sudah dihapus di Google
+func GoogleCalendarEventGone(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && (apiErr.Code == http.StatusNotFound || apiErr.Code == http.StatusGone)
+}
+
+// googleCalendarAttendees menyusun attendee event, pemilik calendar tidak ditambahkan sebagai attendee
+func googleCalendarAttendees(senderEmail string, attendees []string) []*calendar.EventAttendee {
+	result := make([]*calendar.EventAttendee, 0, len(attendees))
+	for _, email := range attendees {
+		if !strings.EqualFold(email, senderEmail) {
+			result = append(result, &calendar.EventAttendee{Email: email})
+		}
+	}
+	return result
+}
@@ -0,0 +1,124 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrObjectNotFound        = errors.New("object not found")
+	ErrSignedURLNotSupported = errors.New("storage driver does not support signed urls")
+)
+
+// ObjectInfo adalah metadata dari sebuah objek yang tersimpan pada storage
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+// Storage adalah abstraksi penyimpanan file, sehingga controller dan service tidak bergantung langsung pada AWS SDK
+type Storage interface {
+	// Put menyimpan body secara privat dengan key tertentu
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+	// SignedURL mengembalikan url download sementara, atau ErrSignedURLNotSupported jika objek harus di-stream oleh server
+	SignedURL(ctx context.Context, key string, fileName string, expires time.Duration) (string, error)
+
+	// CreateMultipartUpload memulai upload bertahap untuk key, part di-upload terpisah lalu digabung oleh CompleteMultipartUpload
+	CreateMultipartUpload(ctx context.Context, key string, contentType string) (string, error)
+	// UploadPart menyimpan satu part (dimulai dari 1) dan mengembalikan etag-nya
+	UploadPart(ctx context.Context, key string, uploadID string, partNumber int, body io.Reader, size int64) (string, error)
+	CompleteMultipartUpload(ctx context.Context, key string, uploadID string, parts []UploadedPart) error
+	AbortMultipartUpload(ctx context.Context, key string, uploadID string) error
+}
+
+// UploadedPart adalah part dari multipart upload yang sudah tersimpan
+type UploadedPart struct {
+	PartNumber int
+	ETag       string
+}
+
+// FileDownloadURL adalah url endpoint download file pada task, diawali APP_URL jika diisi
+func FileDownloadURL(taskID uint64, fileID uint64, fileType string) string {
+	return fmt.Sprintf("%s/task/%d/files/%d/download?type=%s", strings.TrimSuffix(os.Getenv("APP_URL"), "/"), taskID, fileID, fileType)
+}
+
+// FileThumbnailURL adalah url endpoint thumbnail file pada task, bisa langsung dipakai sebagai src gambar
+func FileThumbnailURL(taskID uint64, fileID uint64, fileType string) string {
+	return fmt.Sprintf("%s/task/%d/files/%d/thumbnail?type=%s", strings.TrimSuffix(os.Getenv("APP_URL"), "/"), taskID, fileID, fileType)
+}
+
+// FileVersionDownloadURL adalah url endpoint download untuk versi tertentu dari file pada task
+func FileVersionDownloadURL(taskID uint64, fileID uint64, version int, fileType string) string {
+	return fmt.Sprintf("%s/task/%d/files/%d/versions/%d/download?type=%s", strings.TrimSuffix(os.Getenv("APP_URL"), "/"), taskID, fileID, version, fileType)
+}
+
+// ObjectKey membuat key objek yang unik per board, task dan jenis file berdasarkan hash isi file,
+// nama file asli hanya dipakai untuk mengambil ekstensi
+func ObjectKey(boardID uint64, taskID uint64, fileType string, checksum string, fileName string) string {
+	ext := strings.ToLower(path.Ext(fileName))
+	if !objectKeyExt.MatchString(ext) {
+		ext = ""
+	}
+	return fmt.Sprintf("boards/%d/tasks/%d/%s/%s%s", boardID, taskID, fileType, checksum, ext)
+}
+
+// QuarantineKey adalah key objek untuk file terinfeksi, berada di luar prefix boards/ sehingga tidak bisa diunduh melalui task
+// dan tidak dihapus garbage collector
+func QuarantineKey(checksum string, fileName string) string {
+	ext := strings.ToLower(path.Ext(fileName))
+	if !objectKeyExt.MatchString(ext) {
+		ext = ""
+	}
+	return fmt.Sprintf("quarantine/%s%s", checksum, ext)
+}
+
+// UploadObjectKey membuat key objek untuk resumable upload, hash isi file belum diketahui saat upload dimulai
+// sehingga id upload yang dipakai agar key tetap unik
+func UploadObjectKey(boardID uint64, taskID uint64, fileType string, uploadID string, fileName string) string {
+	return ObjectKey(boardID, taskID, fileType, "uploads/"+uploadID, fileName)
+}
+
+var objectKeyExt = regexp.MustCompile(`^\.[a-z0-9]{1,10}$`)
+
+// NewStorageFromEnv memilih driver storage berdasarkan STORAGE_DRIVER (s3, s3-compatible, local)
+func NewStorageFromEnv() (Storage, error) {
+	bucket := os.Getenv("STORAGE_BUCKET")
+	if bucket == "" {
+		bucket = "manajemen-tugas"
+	}
+
+	driver := strings.ToLower(os.Getenv("STORAGE_DRIVER"))
+	switch driver {
+	case "", "s3":
+		return NewS3Storage(bucket)
+	case "s3-compatible", "minio":
+		return NewS3CompatibleStorage(
+			os.Getenv("STORAGE_ENDPOINT"),
+			os.Getenv("STORAGE_REGION"),
+			bucket,
+			os.Getenv("STORAGE_ACCESS_KEY_ID"),
+			os.Getenv("STORAGE_SECRET_ACCESS_KEY"),
+		)
+	case "local":
+		dir := os.Getenv("STORAGE_LOCAL_DIR")
+		if dir == "" {
+			dir = "storage"
+		}
+		return NewLocalStorage(dir)
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_DRIVER %q", driver)
+	}
+}
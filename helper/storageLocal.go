@@ -0,0 +1,249 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type localStorage struct {
+	root string
+}
+
+// NewLocalStorage menyimpan objek sebagai file biasa di bawah direktori root, dipakai untuk staging dan development tanpa AWS
+func NewLocalStorage(root string) (Storage, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(absRoot, 0o755); err != nil {
+		return nil, fmt.Errorf("Error creating storage directory: %w", err)
+	}
+	return &localStorage{root: absRoot}, nil
+}
+
+func (l *localStorage) path(key string) (string, error) {
+	if key == "" {
+		return "", errors.New("Parameter key diperlukan")
+	}
+	clean := filepath.Clean(filepath.FromSlash("/" + key))
+	path := filepath.Join(l.root, clean)
+	if !strings.HasPrefix(path, l.root+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return path, nil
+}
+
+func (l *localStorage) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// tulis ke file sementara terlebih dahulu agar objek tidak pernah terbaca setengah jadi
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("Error writing object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return nil
+}
+
+func (l *localStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	return file, err
+}
+
+func (l *localStorage) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("Error deleting object: %w", err)
+	}
+	return nil
+}
+
+func (l *localStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	err := filepath.WalkDir(l.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == localMultipartDir {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+		rel, err := filepath.Rel(l.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, ObjectInfo{
+			Key:          key,
+			Size:         info.Size(),
+			ContentType:  mime.TypeByExtension(filepath.Ext(key)),
+			LastModified: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error listing objects: %w", err)
+	}
+	return objects, nil
+}
+
+func (l *localStorage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(key))
+	if contentType == "" {
+		contentType = sniffFileContentType(path)
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         info.Size(),
+		ContentType:  contentType,
+		LastModified: info.ModTime(),
+	}, nil
+}
+
+// file lokal tidak memiliki url publik, sehingga selalu di-stream oleh server
+func (l *localStorage) SignedURL(ctx context.Context, key string, fileName string, expires time.Duration) (string, error) {
+	return "", ErrSignedURLNotSupported
+}
+
+// upload bertahap pada driver lokal disimpan sebagai file part di bawah direktori tersembunyi, lalu digabung saat selesai
+const localMultipartDir = ".multipart"
+
+func (l *localStorage) CreateMultipartUpload(ctx context.Context, key string, contentType string) (string, error) {
+	if _, err := l.path(key); err != nil {
+		return "", err
+	}
+	uploadID := GenerateRandomCode(32)
+	if err := os.MkdirAll(l.multipartPath(uploadID), 0o755); err != nil {
+		return "", fmt.Errorf("Error creating multipart upload: %w", err)
+	}
+	return uploadID, nil
+}
+
+func (l *localStorage) UploadPart(ctx context.Context, key string, uploadID string, partNumber int, body io.Reader, size int64) (string, error) {
+	dir := l.multipartPath(uploadID)
+	if _, err := os.Stat(dir); err != nil {
+		return "", ErrObjectNotFound
+	}
+
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	written, err := io.Copy(tmp, body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && written != size {
+		err = fmt.Errorf("expected %d bytes, received %d", size, written)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("Error uploading part %d: %w", partNumber, err)
+	}
+
+	// part yang di-upload ulang dengan nomor yang sama menggantikan part sebelumnya
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, fmt.Sprintf("%05d", partNumber))); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return fmt.Sprintf("%d-%d", partNumber, written), nil
+}
+
+func (l *localStorage) CompleteMultipartUpload(ctx context.Context, key string, uploadID string, parts []UploadedPart) error {
+	dir := l.multipartPath(uploadID)
+
+	var readers []io.Reader
+	for _, part := range parts {
+		file, err := os.Open(filepath.Join(dir, fmt.Sprintf("%05d", part.PartNumber)))
+		if err != nil {
+			return fmt.Errorf("Error completing multipart upload: %w", err)
+		}
+		defer file.Close()
+		readers = append(readers, file)
+	}
+
+	if err := l.Put(ctx, key, io.MultiReader(readers...), ""); err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+func (l *localStorage) AbortMultipartUpload(ctx context.Context, key string, uploadID string) error {
+	return os.RemoveAll(l.multipartPath(uploadID))
+}
+
+func (l *localStorage) multipartPath(uploadID string) string {
+	return filepath.Join(l.root, localMultipartDir, filepath.Base(uploadID))
+}
+
+func sniffFileContentType(path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return "application/octet-stream"
+	}
+	defer file.Close()
+
+	buf := make([]byte, 512)
+	n, _ := io.ReadFull(file, buf)
+	return http.DetectContentType(buf[:n])
+}
@@ -0,0 +1,163 @@
+package helper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+var ErrThumbnailNotSupported = errors.New("Thumbnail not supported")
+
+const (
+	// gambar yang lebih besar dari ini tidak di-decode agar file kecil berisi gambar raksasa tidak menghabiskan memori
+	maxThumbnailSourcePixels = 50_000_000
+
+	thumbnailSuffix = ".thumb.png"
+)
+
+// ThumbnailKey adalah key thumbnail yang disimpan di samping objek aslinya
+func ThumbnailKey(objectKey string) string {
+	return objectKey + thumbnailSuffix
+}
+
+// ThumbnailSourceKey mengembalikan key objek asli dari key thumbnail, false jika key bukan thumbnail
+func ThumbnailSourceKey(key string) (string, bool) {
+	return strings.CutSuffix(key, thumbnailSuffix)
+}
+
+// ThumbnailContentTypes: thumbnail dibuat untuk gambar yang bisa di-decode library standar dan halaman pertama pdf
+var ThumbnailContentTypes = []string{"image/png", "image/jpeg", "image/gif", "application/pdf"}
+
+func CanGenerateThumbnail(contentType string) bool {
+	for _, thumbnailType := range ThumbnailContentTypes {
+		if thumbnailType == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateThumbnail membuat thumbnail png dengan sisi terpanjang paling besar size piksel.
+// Halaman pertama pdf dirender dengan pdfCommand (pdftoppm dari poppler), jika perintah tersebut tidak tersedia
+// ErrThumbnailNotSupported dikembalikan
+func GenerateThumbnail(ctx context.Context, source io.Reader, contentType string, size int, pdfCommand string) ([]byte, error) {
+	if contentType == "application/pdf" {
+		rendered, err := renderPDFPage(ctx, source, size, pdfCommand)
+		if err != nil {
+			return nil, err
+		}
+		source = bytes.NewReader(rendered)
+	} else if !CanGenerateThumbnail(contentType) {
+		return nil, ErrThumbnailNotSupported
+	}
+
+	content, err := io.ReadAll(source)
+	if err != nil {
+		return nil, err
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding image: %w", err)
+	}
+	if config.Width*config.Height > maxThumbnailSourcePixels {
+		return nil, fmt.Errorf("%w: image of %dx%d pixels is too large", ErrThumbnailNotSupported, config.Width, config.Height)
+	}
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding image: %w", err)
+	}
+
+	var thumbnail bytes.Buffer
+	if err := png.Encode(&thumbnail, scaleImage(img, size)); err != nil {
+		return nil, err
+	}
+	return thumbnail.Bytes(), nil
+}
+
+func renderPDFPage(ctx context.Context, source io.Reader, size int, pdfCommand string) ([]byte, error) {
+	command, err := exec.LookPath(pdfCommand)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s is not installed", ErrThumbnailNotSupported, pdfCommand)
+	}
+
+	dir, err := os.MkdirTemp("", "thumbnail-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "source.pdf")
+	file, err := os.Create(input)
+	if err != nil {
+		return nil, err
+	}
+	_, err = io.Copy(file, source)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	output := filepath.Join(dir, "page")
+	cmd := exec.CommandContext(ctx, command, "-f", "1", "-l", "1", "-singlefile", "-png", "-scale-to", fmt.Sprint(size), input, output)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("Error rendering pdf: %v: %s", err, bytes.TrimSpace(out))
+	}
+	return os.ReadFile(output + ".png")
+}
+
+// scaleImage mengecilkan gambar dengan merata-ratakan piksel sumber pada setiap piksel tujuan, gambar yang sudah kecil tidak diperbesar
+func scaleImage(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return src
+	}
+
+	dstWidth, dstHeight := size, size
+	if width > height {
+		dstHeight = max(1, height*size/width)
+	} else {
+		dstWidth = max(1, width*size/height)
+	}
+
+	// konversi ke RGBA sekali agar pembacaan piksel tidak melalui interface image.Image
+	rgba := image.NewRGBA(bounds)
+	draw.Draw(rgba, bounds, src, bounds.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0, y1 := y*height/dstHeight, max((y+1)*height/dstHeight, y*height/dstHeight+1)
+		for x := 0; x < dstWidth; x++ {
+			x0, x1 := x*width/dstWidth, max((x+1)*width/dstWidth, x*width/dstWidth+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				offset := rgba.PixOffset(bounds.Min.X+x0, bounds.Min.Y+sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(rgba.Pix[offset])
+					g += uint64(rgba.Pix[offset+1])
+					b += uint64(rgba.Pix[offset+2])
+					a += uint64(rgba.Pix[offset+3])
+					offset += 4
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{uint8(r / n), uint8(g / n), uint8(b / n), uint8(a / n)})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,202 @@
+package helper
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"manajemen_tugas_master/model/domain"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+// UploadPolicy adalah batasan upload untuk satu jenis file pada task
+type UploadPolicy struct {
+	// MaxSize adalah ukuran maksimal satu file dalam byte
+	MaxSize int64
+	// AllowedTypes adalah content type yang diizinkan, dicocokkan dengan hasil deteksi isi file
+	AllowedTypes []string
+	// MaxFilesPerTask adalah jumlah file maksimal per task, 0 berarti tidak dibatasi
+	MaxFilesPerTask int
+}
+
+const (
+	contentTypeOLE   = "application/x-ole-storage"
+	contentTypeOOXML = "application/vnd.openxmlformats-officedocument"
+)
+
+var (
+	documentContentTypes = []string{
+		"application/pdf",
+		"text/plain",
+		"application/msword",
+		"application/vnd.ms-excel",
+		"application/vnd.ms-powerpoint",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+		"application/vnd.oasis.opendocument.text",
+		"application/vnd.oasis.opendocument.spreadsheet",
+		"application/vnd.oasis.opendocument.presentation",
+	}
+	archiveContentTypes = []string{
+		"application/zip",
+		"application/x-gzip",
+		"application/x-rar-compressed",
+		"application/x-7z-compressed",
+	}
+	imageContentTypes = []string{
+		"image/png",
+		"image/jpeg",
+		"image/gif",
+		"image/webp",
+		"image/bmp",
+	}
+)
+
+// DefaultUploadPolicies: planning file dan planning description file hanya dokumen,
+// project file boleh berupa dokumen, arsip maupun gambar
+var DefaultUploadPolicies = map[string]UploadPolicy{
+	domain.FileTypePlanningDescription: {MaxSize: 20 << 20, AllowedTypes: documentContentTypes, MaxFilesPerTask: 10},
+	domain.FileTypePlanning:            {MaxSize: 20 << 20, AllowedTypes: documentContentTypes, MaxFilesPerTask: 20},
+	domain.FileTypeProject:             {MaxSize: 100 << 20, AllowedTypes: concatContentTypes(documentContentTypes, archiveContentTypes, imageContentTypes), MaxFilesPerTask: 50},
+}
+
+// UploadPolicyFromEnv membaca policy suatu jenis file, misalnya untuk project-file:
+// UPLOAD_PROJECT_FILE_MAX_SIZE, UPLOAD_PROJECT_FILE_ALLOWED_TYPES (dipisah koma) dan UPLOAD_PROJECT_FILE_MAX_FILES
+func UploadPolicyFromEnv(fileType string) UploadPolicy {
+	policy := DefaultUploadPolicies[fileType]
+	prefix := "UPLOAD_" + strings.ToUpper(strings.ReplaceAll(fileType, "-", "_"))
+
+	policy.MaxSize = ByteSizeFromEnv(prefix+"_MAX_SIZE", policy.MaxSize)
+	policy.MaxFilesPerTask = IntFromEnv(prefix+"_MAX_FILES", policy.MaxFilesPerTask)
+	if value := strings.TrimSpace(os.Getenv(prefix + "_ALLOWED_TYPES")); value != "" {
+		var allowed []string
+		for _, contentType := range strings.Split(value, ",") {
+			if contentType = strings.ToLower(strings.TrimSpace(contentType)); contentType != "" {
+				allowed = append(allowed, contentType)
+			}
+		}
+		policy.AllowedTypes = allowed
+	}
+
+	return policy
+}
+
+// Allows memeriksa content type hasil deteksi terhadap allowlist, parameter seperti charset diabaikan
+func (p UploadPolicy) Allows(contentType string) bool {
+	contentType = strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+	for _, allowed := range p.AllowedTypes {
+		if allowed == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// SniffContentType mendeteksi content type dari isi file, bukan dari ekstensi atau header yang dikirim client.
+// Format kontainer (zip dan OLE) diperiksa lebih lanjut untuk membedakan dokumen office dari arsip biasa
+func SniffContentType(file io.ReaderAt, size int64, fileName string) string {
+	header := make([]byte, 512)
+	n, err := file.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return "application/octet-stream"
+	}
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, []byte("7z\xBC\xAF\x27\x1C")):
+		return "application/x-7z-compressed"
+	case bytes.HasPrefix(header, []byte("\xD0\xCF\x11\xE0\xA1\xB1\x1A\xE1")):
+		return sniffOLEContentType(fileName)
+	}
+
+	contentType := http.DetectContentType(header)
+	if contentType == "application/zip" {
+		return sniffZipContentType(file, size)
+	}
+	return contentType
+}
+
+// sniffZipContentType membedakan dokumen OOXML (docx, xlsx, pptx) dan OpenDocument dari arsip zip berdasarkan isi arsipnya
+func sniffZipContentType(file io.ReaderAt, size int64) string {
+	archive, err := zip.NewReader(file, size)
+	if err != nil {
+		return "application/zip"
+	}
+
+	hasContentTypes := false
+	for _, entry := range archive.File {
+		switch {
+		case entry.Name == "mimetype":
+			mimetype, err := readZipEntry(entry, 128)
+			if err == nil && strings.HasPrefix(mimetype, "application/vnd.oasis.opendocument.") {
+				return mimetype
+			}
+		case entry.Name == "[Content_Types].xml":
+			hasContentTypes = true
+		}
+	}
+	if !hasContentTypes {
+		return "application/zip"
+	}
+
+	for _, entry := range archive.File {
+		switch {
+		case strings.HasPrefix(entry.Name, "word/"):
+			return contentTypeOOXML + ".wordprocessingml.document"
+		case strings.HasPrefix(entry.Name, "xl/"):
+			return contentTypeOOXML + ".spreadsheetml.sheet"
+		case strings.HasPrefix(entry.Name, "ppt/"):
+			return contentTypeOOXML + ".presentationml.presentation"
+		}
+	}
+	return "application/zip"
+}
+
+func readZipEntry(entry *zip.File, limit int64) (string, error) {
+	reader, err := entry.Open()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(io.LimitReader(reader, limit))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// sniffOLEContentType: dokumen office lama (doc, xls, ppt) memakai kontainer OLE yang sama,
+// isinya sudah dipastikan OLE sehingga ekstensi hanya dipakai untuk memilih jenisnya
+func sniffOLEContentType(fileName string) string {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".doc", ".dot":
+		return "application/msword"
+	case ".xls", ".xlt":
+		return "application/vnd.ms-excel"
+	case ".ppt", ".pps", ".pot":
+		return "application/vnd.ms-powerpoint"
+	default:
+		return contentTypeOLE
+	}
+}
+
+// UploadBodyLimit adalah batas ukuran request body, cukup untuk satu file terbesar dari tiap jenis file ditambah field form lainnya
+func UploadBodyLimit() int {
+	var limit int64 = 1 << 20
+	for fileType := range DefaultUploadPolicies {
+		limit += UploadPolicyFromEnv(fileType).MaxSize
+	}
+	return int(limit)
+}
+
+func concatContentTypes(groups ...[]string) []string {
+	var contentTypes []string
+	for _, group := range groups {
+		contentTypes = append(contentTypes, group...)
+	}
+	return contentTypes
+}
@@ -0,0 +1,88 @@
+package helper
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// WebhookSignatureHeader berisi "sha256=" diikuti HMAC-SHA256 payload dengan secret webhook dalam hex
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	webhookUserAgent       = "manajemen-tugas-webhook/1.0"
+	// respons penerima hanya disimpan sebagian untuk catatan percobaan pengiriman
+	webhookResponseLimit = 2048
+)
+
+// WebhookResponse adalah hasil satu percobaan pengiriman webhook
+type WebhookResponse struct {
+	Status   int
+	Body     string
+	Duration time.Duration
+}
+
+// SignWebhookPayload menghitung signature payload webhook: "sha256=" + hex(HMAC-SHA256(secret, payload))
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// GenerateWebhookSecret membuat secret acak untuk webhook yang didaftarkan tanpa secret
+func GenerateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// ValidHTTPURL mengembalikan true untuk url http atau https absolut
+func ValidHTTPURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// SendWebhook mengirim payload json ke url webhook. Status selain 2xx dikembalikan sebagai error bersama responsnya
+func SendWebhook(ctx context.Context, client *http.Client, target string, event string, deliveryID uint64, payload []byte, signature string) (*WebhookResponse, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", webhookUserAgent)
+	request.Header.Set(WebhookEventHeader, event)
+	request.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(deliveryID, 10))
+	request.Header.Set(WebhookSignatureHeader, signature)
+
+	start := time.Now()
+	response, err := client.Do(request)
+	if err != nil {
+		return &WebhookResponse{Duration: time.Since(start)}, err
+	}
+	defer response.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(response.Body, webhookResponseLimit))
+	// sisa body dibaca agar koneksi bisa dipakai ulang
+	io.Copy(io.Discard, response.Body)
+
+	result := &WebhookResponse{Status: response.StatusCode, Body: string(body), Duration: time.Since(start)}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return result, fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+	return result, nil
+}
@@ -31,14 +31,17 @@ func main() {
 		log.Fatal(err.Error())
 	}
 
-	fiberApp := fiber.New()
+	fiberApp := fiber.New(fiber.Config{
+		// batas per file diatur oleh upload policy, body limit hanya perlu cukup untuk menampung semuanya
+		BodyLimit: helper.UploadBodyLimit(),
+	})
 	fiberApp.Get("/swagger/*", swagger.HandlerDefault)
 
 	fiberApp.Use(cors.New(cors.Config{
 		AllowOrigins:     "https://master.d3nck08c8eblbc.amplifyapp.com,http://127.0.0.1:5173,https://manajementugas.com,https://www.manajementugas.com",
 		AllowMethods:     "GET,POST,HEAD,PUT,DELETE,PATCH",
-		AllowHeaders:     "Origin,Content-Type,Accept,Authorization, GoogleAuthorization",
-		ExposeHeaders:    "Content-Length,Set-Cookie,Authorization, GoogleAuthorization",
+		AllowHeaders:     "Origin,Content-Type,Accept,Authorization, GoogleAuthorization,Upload-Offset",
+		ExposeHeaders:    "Content-Length,Set-Cookie,Authorization, GoogleAuthorization,Location,Upload-Offset,Upload-Length",
 		AllowCredentials: true,
 		MaxAge:           int((12 * time.Hour).Seconds()),
 	}))
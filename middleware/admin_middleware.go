@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"manajemen_tugas_master/helper"
+	"os"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminUser membatasi route untuk user yang email-nya terdaftar pada ADMIN_EMAILS (dipisah koma), dipasang setelah AuthUser.
+// Jika ADMIN_EMAILS kosong tidak ada user yang bisa mengakses route admin
+func AdminUser() fiber.Handler {
+	admins := make(map[string]bool)
+	for _, email := range strings.Split(os.Getenv("ADMIN_EMAILS"), ",") {
+		if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+			admins[email] = true
+		}
+	}
+
+	return func(ctx *fiber.Ctx) error {
+		user, err := helper.GetCtxUser(ctx)
+		if err != nil {
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+		}
+		if !admins[strings.ToLower(user.Email)] {
+			return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Only for admin"})
+		}
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// CalendarConnection adalah akun Google Calendar yang dihubungkan user lewat OAuth. Token disimpan terenkripsi
+// dan access token diperbarui otomatis dengan refresh token
+type CalendarConnection struct {
+	ID           uint64     `json:"-" gorm:"primaryKey"`
+	UserID       uint64     `json:"user_id" gorm:"uniqueIndex"`
+	Email        string     `json:"email" gorm:"size:255"`
+	RefreshToken string     `json:"-" gorm:"type:text"`
+	AccessToken  string     `json:"-" gorm:"type:text"`
+	TokenExpiry  *time.Time `json:"-"`
+	CreatedAt    time.Time  `json:"connected_at"`
+	UpdatedAt    time.Time  `json:"-"`
+}
+
+// jenis tenggat task yang dibuatkan event calendar, planning untuk manager dan project untuk employee
+const (
+	CalendarDueTypePlanning = "planning"
+	CalendarDueTypeProject  = "project"
+)
+
+// TaskCalendarEvent mencatat event Google Calendar untuk satu tenggat task pada calendar user yang membuatnya,
+// sehingga perubahan tenggat atau anggota task memperbarui event yang sama dan penghapusan task membatalkannya
+type TaskCalendarEvent struct {
+	ID        uint64    `json:"-" gorm:"primaryKey"`
+	TaskID    uint64    `json:"task_id" gorm:"uniqueIndex:idx_task_calendar_event,priority:1"`
+	DueType   string    `json:"due_type" gorm:"size:20;uniqueIndex:idx_task_calendar_event,priority:2"`
+	UserID    uint64    `json:"user_id"`
+	EventID   string    `json:"event_id" gorm:"size:1024"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// CalendarFeed adalah url rahasia feed iCalendar. Feed dengan BoardID 0 berisi tenggat semua task tempat user menjadi
+// owner, manager atau employee; feed board berisi tenggat semua task pada board dan dikelola owner board
+type CalendarFeed struct {
+	ID        uint64    `json:"-" gorm:"primaryKey"`
+	UserID    uint64    `json:"user_id" gorm:"uniqueIndex:idx_calendar_feed,priority:1"`
+	BoardID   uint64    `json:"board_id" gorm:"uniqueIndex:idx_calendar_feed,priority:2"`
+	Token     string    `json:"-" gorm:"size:64;uniqueIndex"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// layanan chat yang didukung, keduanya menerima pesan lewat incoming webhook
+const (
+	ChatProviderSlack   = "slack"
+	ChatProviderDiscord = "discord"
+)
+
+var ChatProviders = []string{ChatProviderSlack, ChatProviderDiscord}
+
+// ChatIntegration menghubungkan board dengan incoming webhook Slack atau Discord. Event task pada board dikirim sebagai
+// pesan chat untuk jenis event yang dipilih (jenis event yang sama dengan preferensi notifikasi)
+type ChatIntegration struct {
+	ID        uint64    `json:"id" gorm:"primaryKey"`
+	BoardID   uint64    `json:"board_id" gorm:"index"`
+	UserID    uint64    `json:"user_id"`
+	Provider  string    `json:"provider" gorm:"size:20"`
+	URL       string    `json:"-" gorm:"size:2048"`
+	Locale    string    `json:"locale" gorm:"size:10;default:'en'"`
+	Events    []string  `json:"events" gorm:"type:text;serializer:json"`
+	Active    bool      `json:"active" gorm:"default:true"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Subscribed mengembalikan true jika integrasi menerima pesan untuk jenis event tersebut
+func (c *ChatIntegration) Subscribed(eventType string) bool {
+	for _, subscribed := range c.Events {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// jenis tenggat task: planning untuk manager dan project untuk employee
+const (
+	DueTypePlanning = "planning"
+	DueTypeProject  = "project"
+)
+
+// DueDateReminder mencatat pengingat tenggat yang sudah dikirim. Unique index membuat setiap pengingat untuk satu
+// tenggat dan jarak waktu hanya dikirim sekali walaupun aplikasi di-restart atau berjalan di beberapa instance.
+// Tenggat yang diubah mendapat pengingat baru karena tanggalnya ikut menjadi bagian kunci
+type DueDateReminder struct {
+	ID            uint64 `json:"id" gorm:"primaryKey"`
+	TaskID        uint64 `json:"task_id" gorm:"uniqueIndex:idx_due_date_reminder,priority:1"`
+	DueType       string `json:"due_type" gorm:"size:20;uniqueIndex:idx_due_date_reminder,priority:2"`
+	DueDate       string `json:"due_date" gorm:"size:20;uniqueIndex:idx_due_date_reminder,priority:3"`
+	OffsetMinutes int    `json:"offset_minutes" gorm:"uniqueIndex:idx_due_date_reminder,priority:4"`
+	// Skipped berarti waktu pengingat terlewat (misalnya aplikasi mati) dan diganti pengingat yang lebih dekat ke tenggat
+	Skipped   bool      `json:"skipped"`
+	CreatedAt time.Time `json:"created_at"`
+}
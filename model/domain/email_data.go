@@ -0,0 +1,39 @@
+package domain
+
+// EmailData adalah data yang dirender ke template email, disimpan bersama pesan outbox agar bisa dirender
+// ulang sesuai bahasa masing-masing penerima saat dikirim
+type EmailData struct {
+	TaskName    string `json:"task_name,omitempty"`
+	Value       string `json:"value,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Detail      string `json:"detail,omitempty"`
+	Description string `json:"description,omitempty"`
+	Code        string `json:"code,omitempty"`
+	// Days dan Hours adalah sisa waktu sampai tenggat pada email pengingat, hanya salah satu yang diisi
+	Days   int                `json:"days,omitempty"`
+	Hours  int                `json:"hours,omitempty"`
+	Files  []EmailFile        `json:"files,omitempty"`
+	Boards []EmailDigestBoard `json:"boards,omitempty"`
+	// Reply diisi saat email dirender jika penerima bisa membalas email untuk menambah komentar task
+	Reply bool `json:"-"`
+}
+
+// EmailFile adalah file yang dicantumkan pada email perubahan file task
+type EmailFile struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version int    `json:"version,omitempty"`
+	URL     string `json:"url"`
+}
+
+// EmailDigestBoard adalah perubahan pada satu board yang dirangkum dalam email digest
+type EmailDigestBoard struct {
+	Name  string            `json:"name"`
+	Items []EmailDigestItem `json:"items"`
+}
+
+type EmailDigestItem struct {
+	TaskName string `json:"task_name"`
+	Title    string `json:"title"`
+	Time     string `json:"time"`
+}
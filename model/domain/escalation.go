@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// tahap eskalasi task yang melewati tenggat project, berurutan dari employee ke manager lalu owner
+const (
+	EscalationLevelEmployee = "employee"
+	EscalationLevelManager  = "manager"
+	EscalationLevelOwner    = "owner"
+)
+
+// jarak waktu default setelah tenggat project sebelum manager dan owner ikut diberi tahu
+const (
+	DefaultEscalationManagerAfterHours = 24
+	DefaultEscalationOwnerAfterHours   = 72
+)
+
+// EscalationPolicy adalah aturan eskalasi satu board. Board tanpa aturan tersimpan memakai aturan default yang aktif.
+// Jarak waktu dihitung dari akhir hari tenggat project
+type EscalationPolicy struct {
+	ID                uint64    `json:"-" gorm:"primaryKey"`
+	BoardID           uint64    `json:"board_id" gorm:"uniqueIndex"`
+	Enabled           bool      `json:"enabled"`
+	ManagerAfterHours int       `json:"manager_after_hours"`
+	OwnerAfterHours   int       `json:"owner_after_hours"`
+	CreatedAt         time.Time `json:"-"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// DefaultEscalationPolicy adalah aturan eskalasi board yang belum diatur owner-nya
+func DefaultEscalationPolicy(boardID uint64) EscalationPolicy {
+	return EscalationPolicy{
+		BoardID:           boardID,
+		Enabled:           true,
+		ManagerAfterHours: DefaultEscalationManagerAfterHours,
+		OwnerAfterHours:   DefaultEscalationOwnerAfterHours,
+	}
+}
+
+// TaskEscalation mencatat satu tahap eskalasi task. Unique index membuat setiap tahap untuk satu tenggat hanya dikirim
+// sekali; eskalasi selesai (ResolvedAt) saat project Done atau tenggatnya diubah
+type TaskEscalation struct {
+	ID         uint64     `json:"id" gorm:"primaryKey"`
+	TaskID     uint64     `json:"task_id" gorm:"uniqueIndex:idx_task_escalation,priority:1"`
+	DueDate    string     `json:"due_date" gorm:"size:20;uniqueIndex:idx_task_escalation,priority:2"`
+	Level      string     `json:"level" gorm:"size:20;uniqueIndex:idx_task_escalation,priority:3"`
+	Recipients []string   `json:"recipients" gorm:"serializer:json"`
+	CreatedAt  time.Time  `json:"escalated_at"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+}
@@ -0,0 +1,8 @@
+package domain
+
+// jenis file yang bisa dilampirkan pada task, sama dengan segmen route delete file
+const (
+	FileTypePlanningDescription = "planning-description-file"
+	FileTypePlanning            = "planning-file"
+	FileTypeProject             = "project-file"
+)
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// FileVersion adalah satu versi dari planning file atau project file, setiap upload ulang dengan nama yang sama menjadi versi baru
+type FileVersion struct {
+	ID            uint64    `json:"id" gorm:"primaryKey"`
+	FileType      string    `json:"file_type" gorm:"size:50;index:idx_file_versions_file"`
+	FileID        uint64    `json:"file_id" gorm:"index:idx_file_versions_file"`
+	Version       int       `json:"version"`
+	FileKey       string    `json:"-" gorm:"size:255"`
+	FileName      string    `json:"file_name" gorm:"size:255"`
+	Size          int64     `json:"size"`
+	ContentType   string    `json:"content_type" gorm:"size:255"`
+	Checksum      string    `json:"checksum" gorm:"size:64"`
+	ScanStatus    string    `json:"scan_status" gorm:"size:20"`
+	UploadedBy    uint64    `json:"uploaded_by"`
+	UploaderEmail string    `json:"uploader_email" gorm:"->;-:migration"`
+	CreatedAt     time.Time `json:"created_at"`
+	FileUrl       string    `json:"file_url" gorm:"-"`
+}
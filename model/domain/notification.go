@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// Notification adalah notifikasi in-app milik satu user, dicatat bersama pesan outbox dari event yang sama.
+// Judul dan isinya dirender dari template email sesuai bahasa user saat dibaca
+type Notification struct {
+	ID       uint64     `json:"id" gorm:"primaryKey"`
+	UserID   uint64     `json:"user_id" gorm:"index:idx_notification_user_read,priority:1"`
+	TaskID   uint64     `json:"task_id" gorm:"index"`
+	Event    string     `json:"event" gorm:"size:100"`
+	Template string     `json:"template" gorm:"size:100"`
+	Data     EmailData  `json:"data" gorm:"type:text;serializer:json"`
+	ReadAt   *time.Time `json:"read_at" gorm:"index:idx_notification_user_read,priority:2"`
+	// Digest menandai notifikasi yang dirangkum dalam email harian, DigestedAt terisi setelah masuk email digest
+	Digest     bool       `json:"-" gorm:"index:idx_notification_digest,priority:1"`
+	DigestedAt *time.Time `json:"-" gorm:"index:idx_notification_digest,priority:2"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// DigestNotification adalah notifikasi yang menunggu email digest beserta penerima dan board task-nya
+type DigestNotification struct {
+	Notification
+	Email     string
+	Locale    string
+	BoardName string
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// jenis event yang bisa diatur user pada preferensi notifikasi
+const (
+	NotificationEventName       = "name"
+	NotificationEventStatus     = "status"
+	NotificationEventComment    = "comment"
+	NotificationEventFile       = "file"
+	NotificationEventDueDate    = "due_date"
+	NotificationEventInvitation = "invitation"
+)
+
+// cara notifikasi diterima: instant email sekaligus in-app, in_app tanpa email, digest in-app lalu dirangkum
+// dalam email harian, none tidak menerima notifikasi sama sekali
+const (
+	NotificationModeInstant = "instant"
+	NotificationModeInApp   = "in_app"
+	NotificationModeDigest  = "digest"
+	NotificationModeNone    = "none"
+)
+
+// DefaultNotificationMode dipakai untuk jenis event yang belum diatur user
+const DefaultNotificationMode = NotificationModeInstant
+
+var NotificationEventTypes = []string{
+	NotificationEventName,
+	NotificationEventStatus,
+	NotificationEventComment,
+	NotificationEventFile,
+	NotificationEventDueDate,
+	NotificationEventInvitation,
+}
+
+var NotificationModes = []string{
+	NotificationModeInstant,
+	NotificationModeInApp,
+	NotificationModeDigest,
+	NotificationModeNone,
+}
+
+// NotificationPreference adalah pilihan user untuk satu jenis event, hanya jenis event yang diubah dari default yang disimpan
+type NotificationPreference struct {
+	ID        uint64    `json:"-" gorm:"primaryKey"`
+	UserID    uint64    `json:"-" gorm:"uniqueIndex:idx_notification_preference_user_event,priority:1"`
+	EventType string    `json:"event_type" gorm:"size:30;uniqueIndex:idx_notification_preference_user_event,priority:2"`
+	Mode      string    `json:"mode" gorm:"size:20"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+// NotificationEventType mengelompokkan event notifikasi ke jenis event pada preferensi. Event yang tidak diatur
+// preferensinya seperti email digest mengembalikan string kosong
+func NotificationEventType(event string) string {
+	switch {
+	case event == "task.name_updated":
+		return NotificationEventName
+	case event == "task.planning_description_persen_updated", strings.HasSuffix(event, "_status_updated"):
+		return NotificationEventStatus
+	case event == "task.comment_added":
+		return NotificationEventComment
+	case event == "task.files_added", event == "file.quarantined":
+		return NotificationEventFile
+	case strings.HasSuffix(event, "_due_date_updated"), strings.HasSuffix(event, "_due_date_reminder"),
+		event == "task.overdue_escalation":
+		return NotificationEventDueDate
+	case strings.HasPrefix(event, "task.invitation_"):
+		return NotificationEventInvitation
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// status pesan pada notification outbox: pending menunggu dikirim atau dicoba ulang, sending sedang dikirim worker,
+// sent berhasil dan dead gagal sampai batas percobaan sehingga hanya dikirim ulang lewat endpoint admin
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSending = "sending"
+	OutboxStatusSent    = "sent"
+	OutboxStatusDead    = "dead"
+)
+
+// channel pengiriman pesan outbox: email ke alamat penerima, chat ke integrasi chat board yang id-nya dicatat sebagai penerima
+const (
+	OutboxChannelEmail = "email"
+	OutboxChannelChat  = "chat"
+)
+
+// OutboxMessage adalah notifikasi yang dicatat bersama perubahan yang memicunya lalu dikirim oleh worker di belakang layar
+type OutboxMessage struct {
+	ID            uint64     `json:"id" gorm:"primaryKey"`
+	Event         string     `json:"event" gorm:"size:100;index"`
+	TaskID        uint64     `json:"task_id" gorm:"index"`
+	Channel       string     `json:"channel" gorm:"size:20;default:'email'"`
+	Recipients    []string   `json:"recipients" gorm:"type:text;serializer:json"`
+	Template      string     `json:"template" gorm:"size:100"`
+	Data          EmailData  `json:"data" gorm:"type:text;serializer:json"`
+	Subject       string     `json:"subject" gorm:"size:255"`
+	Body          string     `json:"body" gorm:"type:longtext"`
+	Status        string     `json:"status" gorm:"size:20;index:idx_outbox_status_next_attempt,priority:1;default:'pending'"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"index:idx_outbox_status_next_attempt,priority:2"`
+	LastError     string     `json:"last_error" gorm:"type:text"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TaskRecipients adalah nama task dan email anggotanya saat notifikasi disusun
+type TaskRecipients struct {
+	TaskName       string
+	OwnerEmail     string
+	ManagerEmails  []string
+	EmployeeEmails []string
+}
+
+// Members adalah email owner, manager dan employee task tanpa duplikat
+func (r TaskRecipients) Members() []string {
+	emails := append([]string{r.OwnerEmail}, r.ManagerEmails...)
+	return uniqueEmails(append(emails, r.EmployeeEmails...))
+}
+
+func uniqueEmails(emails []string) []string {
+	seen := make(map[string]bool, len(emails))
+	unique := make([]string, 0, len(emails))
+	for _, email := range emails {
+		if email == "" || seen[email] {
+			continue
+		}
+		seen[email] = true
+		unique = append(unique, email)
+	}
+	return unique
+}
@@ -1,7 +1,20 @@
 package domain
 
+import "time"
+
 type PlanningFile struct {
-	ID       uint64 `json:"id" gorm:"primaryKey"`
-	FileUrl  string `json:"file_url" gorm:"size:255"`
-	FileName string `json:"file_name" gorm:"size:255"`
+	ID            uint64    `json:"id" gorm:"primaryKey"`
+	TaskID        uint64    `json:"task_id" gorm:"index"`
+	FileKey       string    `json:"-" gorm:"size:255"`
+	FileUrl       string    `json:"file_url" gorm:"-"`
+	ThumbnailUrl  string    `json:"thumbnail_url" gorm:"-"`
+	Version       int       `json:"version" gorm:"default:1"`
+	FileName      string    `json:"file_name" gorm:"size:255"`
+	Size          int64     `json:"size"`
+	ContentType   string    `json:"content_type" gorm:"size:255"`
+	Checksum      string    `json:"checksum" gorm:"size:64"`
+	ScanStatus    string    `json:"scan_status" gorm:"size:20"`
+	UploadedBy    uint64    `json:"uploaded_by"`
+	UploaderEmail string    `json:"uploader_email" gorm:"-"`
+	CreatedAt     time.Time `json:"created_at"`
 }
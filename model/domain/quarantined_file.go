@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// status pemindaian virus pada file, skipped berarti file tidak dipindai karena scanner dinonaktifkan
+// atau file sudah tersimpan sebelum pemindaian ada
+const (
+	ScanStatusPending  = "pending"
+	ScanStatusClean    = "clean"
+	ScanStatusInfected = "infected"
+	ScanStatusSkipped  = "skipped"
+)
+
+// QuarantinedFile adalah file terinfeksi yang ditolak, isinya dipindahkan ke prefix quarantine pada storage untuk diperiksa admin
+type QuarantinedFile struct {
+	ID        uint64    `json:"id" gorm:"primaryKey"`
+	TaskID    uint64    `json:"task_id" gorm:"index"`
+	FileType  string    `json:"file_type" gorm:"size:50"`
+	FileName  string    `json:"file_name" gorm:"size:255"`
+	ObjectKey string    `json:"-" gorm:"size:255"`
+	Checksum  string    `json:"checksum" gorm:"size:64"`
+	Signature string    `json:"signature" gorm:"size:255"`
+	CreatedAt time.Time `json:"created_at"`
+}
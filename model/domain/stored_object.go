@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// status thumbnail stored object, kosong untuk jenis file yang tidak dibuatkan thumbnail.
+// Unsupported berarti thumbnail tidak bisa dibuat di server ini, misalnya pdf tanpa pdftoppm
+const (
+	ThumbnailStatusPending     = "pending"
+	ThumbnailStatusReady       = "ready"
+	ThumbnailStatusFailed      = "failed"
+	ThumbnailStatusUnsupported = "unsupported"
+)
+
+// StoredObject adalah blob pada storage yang bisa dipakai bersama oleh beberapa file dengan isi yang sama.
+// Thumbnail gambar dan pdf dibuat di belakang layar dan disimpan di samping objeknya
+type StoredObject struct {
+	ID              uint64    `json:"id" gorm:"primaryKey"`
+	ObjectKey       string    `json:"object_key" gorm:"size:255;uniqueIndex"`
+	Checksum        string    `json:"checksum" gorm:"size:64;index"`
+	Size            int64     `json:"size"`
+	ContentType     string    `json:"content_type" gorm:"size:255"`
+	RefCount        int64     `json:"ref_count"`
+	ThumbnailStatus string    `json:"thumbnail_status" gorm:"size:20;index"`
+	ScanStatus      string    `json:"scan_status" gorm:"size:20;index"`
+	CreatedAt       time.Time `json:"created_at"`
+}
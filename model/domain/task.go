@@ -19,4 +19,5 @@ type Task struct {
 	ProjectDueDate            string                    `json:"project_due_date" gorm:"size:255"`
 	Priority                  string                    `json:"priority" gorm:"type:enum('High','Medium','Low');default:'Medium'"`
 	ProjectComment            string                    `json:"project_comment"`
+	Escalations               []TaskEscalation          `json:"escalations" gorm:"foreignKey:TaskID;references:ID"`
 }
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// TaskFile adalah satu file yang terhubung dengan task, dari jenis file apapun
+type TaskFile struct {
+	TaskID    uint64
+	FileType  string
+	FileName  string
+	FileKey   string
+	CreatedAt time.Time
+}
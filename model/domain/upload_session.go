@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// status resumable upload: uploading selama part masih dikirim, assembled setelah part digabung tetapi isinya belum
+// selesai diperiksa, completed setelah finalize dan attached setelah file dilampirkan pada task
+const (
+	UploadStatusUploading = "uploading"
+	UploadStatusAssembled = "assembled"
+	UploadStatusCompleted = "completed"
+	UploadStatusAttached  = "attached"
+)
+
+// UploadSession adalah resumable upload yang dikirim bertahap, setelah selesai memegang satu referensi stored object
+// sampai dilampirkan pada task sebagai planning file atau project file
+type UploadSession struct {
+	ID              string    `json:"id" gorm:"primaryKey;size:64"`
+	TaskID          uint64    `json:"task_id" gorm:"index"`
+	BoardID         uint64    `json:"board_id"`
+	UserID          uint64    `json:"user_id" gorm:"index"`
+	FileType        string    `json:"file_type" gorm:"size:50"`
+	FileName        string    `json:"file_name" gorm:"size:255"`
+	Size            int64     `json:"size"`
+	Offset          int64     `json:"offset" gorm:"column:upload_offset"`
+	ObjectKey       string    `json:"-" gorm:"size:255"`
+	StorageUploadID string    `json:"-" gorm:"size:1024"`
+	Status          string    `json:"status" gorm:"size:20;index;default:'uploading'"`
+	ContentType     string    `json:"content_type" gorm:"size:255"`
+	Checksum        string    `json:"checksum" gorm:"size:64"`
+	ScanStatus      string    `json:"scan_status" gorm:"size:20"`
+	ExpiresAt       time.Time `json:"expires_at" gorm:"index"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// UploadSessionPart adalah satu potongan resumable upload yang sudah tersimpan sebagai part multipart upload pada storage
+type UploadSessionPart struct {
+	ID         uint64 `gorm:"primaryKey"`
+	SessionID  string `gorm:"size:64;uniqueIndex:idx_upload_session_parts_part"`
+	PartNumber int    `gorm:"uniqueIndex:idx_upload_session_parts_part"`
+	ETag       string `gorm:"size:255"`
+	Size       int64
+}
@@ -4,4 +4,6 @@ type User struct {
 	ID       uint64 `json:"id" gorm:"primaryKey"`
 	Email    string `json:"email" gorm:"size:255" validate:"email"`
 	Password string `json:"password" gorm:"size:255"`
+	// Locale adalah bahasa email yang diterima user, en atau id
+	Locale string `json:"locale" gorm:"size:10;default:'en'" validate:"omitempty,oneof=en id"`
 }
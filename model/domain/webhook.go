@@ -0,0 +1,112 @@
+package domain
+
+import "time"
+
+// event yang bisa dilanggan webhook
+const (
+	WebhookEventTaskCreated        = "task.created"
+	WebhookEventTaskUpdated        = "task.updated"
+	WebhookEventTaskDeleted        = "task.deleted"
+	WebhookEventInvitationAccepted = "invitation.accepted"
+	WebhookEventBoardDeleted       = "board.deleted"
+)
+
+var WebhookEvents = []string{
+	WebhookEventTaskCreated,
+	WebhookEventTaskUpdated,
+	WebhookEventTaskDeleted,
+	WebhookEventInvitationAccepted,
+	WebhookEventBoardDeleted,
+}
+
+// status pengiriman webhook: pending menunggu dikirim atau dicoba ulang, sending sedang dikirim worker,
+// delivered diterima dengan status 2xx dan failed gagal sampai batas percobaan
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySending   = "sending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook adalah url milik sistem luar yang menerima event pada satu board, didaftarkan oleh owner board
+type Webhook struct {
+	ID        uint64    `json:"id" gorm:"primaryKey"`
+	BoardID   uint64    `json:"board_id" gorm:"index"`
+	UserID    uint64    `json:"user_id"`
+	URL       string    `json:"url" gorm:"size:2048"`
+	Secret    string    `json:"-" gorm:"size:255"`
+	Events    []string  `json:"events" gorm:"type:text;serializer:json"`
+	Active    bool      `json:"active" gorm:"default:true"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Subscribed mengembalikan true jika webhook melanggan event tersebut
+func (w *Webhook) Subscribed(event string) bool {
+	for _, subscribed := range w.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery adalah satu event yang dikirim ke webhook. Url, payload dan signature disimpan saat event terjadi
+// sehingga pengiriman dan pengiriman ulang tetap sama walaupun webhook atau board-nya sudah dihapus
+type WebhookDelivery struct {
+	ID             uint64                   `json:"id" gorm:"primaryKey"`
+	WebhookID      uint64                   `json:"webhook_id" gorm:"index"`
+	Event          string                   `json:"event" gorm:"size:100"`
+	URL            string                   `json:"url" gorm:"size:2048"`
+	Payload        string                   `json:"payload" gorm:"type:longtext"`
+	Signature      string                   `json:"signature" gorm:"size:100"`
+	Status         string                   `json:"status" gorm:"size:20;index:idx_webhook_delivery_status_next_attempt,priority:1;default:'pending'"`
+	Attempts       int                      `json:"attempts"`
+	NextAttemptAt  time.Time                `json:"next_attempt_at" gorm:"index:idx_webhook_delivery_status_next_attempt,priority:2"`
+	ResponseStatus int                      `json:"response_status"`
+	LastError      string                   `json:"last_error" gorm:"type:text"`
+	RedeliveryOf   *uint64                  `json:"redelivery_of"`
+	DeliveredAt    *time.Time               `json:"delivered_at"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+	AttemptLog     []WebhookDeliveryAttempt `json:"attempt_log,omitempty" gorm:"foreignKey:DeliveryID;references:ID"`
+}
+
+// WebhookDeliveryAttempt adalah catatan satu percobaan pengiriman webhook beserta respons penerima
+type WebhookDeliveryAttempt struct {
+	ID             uint64    `json:"id" gorm:"primaryKey"`
+	DeliveryID     uint64    `json:"delivery_id" gorm:"index"`
+	Attempt        int       `json:"attempt"`
+	ResponseStatus int       `json:"response_status"`
+	ResponseBody   string    `json:"response_body" gorm:"type:text"`
+	Error          string    `json:"error" gorm:"type:text"`
+	DurationMs     int64     `json:"duration_ms"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// WebhookTask adalah data task yang dikirim pada payload webhook
+type WebhookTask struct {
+	ID                        uint64 `json:"id"`
+	BoardID                   uint64 `json:"board_id"`
+	NameTask                  string `json:"name_task"`
+	PlanningDescriptionPersen string `json:"planning_description_persen,omitempty"`
+	PlanningStatus            string `json:"planning_status,omitempty"`
+	ProjectStatus             string `json:"project_status,omitempty"`
+	PlanningDueDate           string `json:"planning_due_date,omitempty"`
+	ProjectDueDate            string `json:"project_due_date,omitempty"`
+	Priority                  string `json:"priority,omitempty"`
+}
+
+func NewWebhookTask(task *Task) *WebhookTask {
+	return &WebhookTask{
+		ID:                        task.ID,
+		BoardID:                   task.BoardID,
+		NameTask:                  task.NameTask,
+		PlanningDescriptionPersen: task.PlanningDescriptionPersen,
+		PlanningStatus:            task.PlanningStatus,
+		ProjectStatus:             task.ProjectStatus,
+		PlanningDueDate:           task.PlanningDueDate,
+		ProjectDueDate:            task.ProjectDueDate,
+		Priority:                  task.Priority,
+	}
+}
@@ -0,0 +1,9 @@
+package web
+
+import "time"
+
+type CalendarFeedResponse struct {
+	URL       string    `json:"url" example:"https://manajementugas.com/calendar/6f1c0e2a9b.ics"`
+	BoardID   uint64    `json:"board_id,omitempty" example:"1"`
+	RotatedAt time.Time `json:"rotated_at"`
+}
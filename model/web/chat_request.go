@@ -0,0 +1,18 @@
+package web
+
+// ChatIntegrationRequest menghubungkan board dengan incoming webhook Slack atau Discord. Locale kosong berarti
+// bahasa default, events kosong berarti semua jenis event
+type ChatIntegrationRequest struct {
+	Provider string   `json:"provider" example:"slack"`
+	URL      string   `json:"url" example:"https://hooks.slack.com/services/T000/B000/XXXX"`
+	Locale   string   `json:"locale" example:"en"`
+	Events   []string `json:"events" example:"status,comment,file"`
+}
+
+// UpdateChatIntegrationRequest mengubah pengaturan integrasi chat, field yang tidak dikirim tidak berubah.
+// Events yang dikirim kosong berarti semua jenis event
+type UpdateChatIntegrationRequest struct {
+	Locale string   `json:"locale" example:"id"`
+	Events []string `json:"events" example:"status,comment"`
+	Active *bool    `json:"active" example:"true"`
+}
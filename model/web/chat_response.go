@@ -0,0 +1,9 @@
+package web
+
+import "manajemen_tugas_master/model/domain"
+
+// ChatIntegrationResponse adalah integrasi chat dengan url webhook yang disamarkan, url lengkapnya berfungsi sebagai secret
+type ChatIntegrationResponse struct {
+	domain.ChatIntegration
+	URL string `json:"url" example:"https://hooks.slack.com/…"`
+}
@@ -0,0 +1,9 @@
+package web
+
+// EscalationPolicyRequest mengubah aturan eskalasi board, field yang tidak dikirim tidak berubah. Jarak waktu dalam jam
+// dihitung dari akhir hari tenggat project
+type EscalationPolicyRequest struct {
+	Enabled           *bool `json:"enabled" example:"true"`
+	ManagerAfterHours *int  `json:"manager_after_hours" example:"24"`
+	OwnerAfterHours   *int  `json:"owner_after_hours" example:"72"`
+}
@@ -0,0 +1,24 @@
+package web
+
+import (
+	"manajemen_tugas_master/model/domain"
+	"time"
+)
+
+type FileDownloadResponse struct {
+	FileName  string    `json:"file_name" example:"project_report.pdf"`
+	Url       string    `json:"url" example:"https://bucket-name.s3.amazonaws.com/project_report.pdf?X-Amz-Signature=..."`
+	ExpiresAt time.Time `json:"expires_at" example:"2024-01-01T00:15:00Z"`
+}
+
+// FileVersionDiff adalah perbedaan metadata antara dua versi file
+type FileVersionDiff struct {
+	From               domain.FileVersion `json:"from"`
+	To                 domain.FileVersion `json:"to"`
+	SizeDelta          int64              `json:"size_delta" example:"2048"`
+	ContentChanged     bool               `json:"content_changed" example:"true"`
+	ContentTypeChanged bool               `json:"content_type_changed" example:"false"`
+	FileNameChanged    bool               `json:"file_name_changed" example:"false"`
+	UploaderChanged    bool               `json:"uploader_changed" example:"true"`
+	TimeBetween        string             `json:"time_between" example:"26h5m0s"`
+}
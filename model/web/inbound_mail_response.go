@@ -0,0 +1,8 @@
+package web
+
+// InboundMailResponse adalah hasil balasan email yang ditambahkan sebagai komentar task
+type InboundMailResponse struct {
+	TaskID  uint64 `json:"task_id" example:"1"`
+	UserID  uint64 `json:"user_id" example:"2"`
+	Comment string `json:"comment" example:"The homepage layout is ready for review."`
+}
@@ -0,0 +1,6 @@
+package web
+
+// NotificationPreferencesRequest berisi cara notifikasi diterima per jenis event, jenis event yang tidak dikirim tidak berubah
+type NotificationPreferencesRequest struct {
+	Preferences map[string]string `json:"preferences"`
+}
@@ -0,0 +1,49 @@
+package web
+
+import (
+	"manajemen_tugas_master/model/domain"
+	"time"
+)
+
+// NotificationListResponse adalah satu halaman pesan notification outbox untuk admin
+type NotificationListResponse struct {
+	Total         int64                  `json:"total" example:"42"`
+	Limit         int                    `json:"limit" example:"50"`
+	Offset        int                    `json:"offset" example:"0"`
+	Notifications []domain.OutboxMessage `json:"notifications"`
+}
+
+// UserNotificationResponse adalah notifikasi in-app yang sudah dirender dalam bahasa user
+type UserNotificationResponse struct {
+	ID        uint64     `json:"id" example:"1"`
+	TaskID    uint64     `json:"task_id" example:"1"`
+	Event     string     `json:"event" example:"task.comment_added"`
+	Title     string     `json:"title" example:"New Project Comment Added"`
+	Message   string     `json:"message" example:"A new comment has been added to the project"`
+	Read      bool       `json:"read" example:"false"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// UserNotificationListResponse adalah satu halaman notifikasi in-app user, terbaru lebih dulu
+type UserNotificationListResponse struct {
+	Total         int64                      `json:"total" example:"12"`
+	Unread        int64                      `json:"unread" example:"3"`
+	Limit         int                        `json:"limit" example:"50"`
+	Offset        int                        `json:"offset" example:"0"`
+	Notifications []UserNotificationResponse `json:"notifications"`
+}
+
+type UnreadNotificationCountResponse struct {
+	Unread int64 `json:"unread" example:"3"`
+}
+
+type MarkAllNotificationsReadResponse struct {
+	Updated int64 `json:"updated" example:"3"`
+}
+
+// NotificationPreferencesResponse berisi cara notifikasi diterima untuk setiap jenis event:
+// instant, in_app, digest atau none
+type NotificationPreferencesResponse struct {
+	Preferences map[string]string `json:"preferences"`
+}
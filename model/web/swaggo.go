@@ -39,12 +39,14 @@ type GetAllUsersResponse struct {
 }
 
 type UserDetail struct {
-	ID    uint   `json:"id" example:"1"`
-	Email string `json:"email" example:"user@example.com"`
+	ID     uint   `json:"id" example:"1"`
+	Email  string `json:"email" example:"user@example.com"`
+	Locale string `json:"locale" example:"en"`
 }
 
 type UpdateUser struct {
-	Email string `json:"email" example:"user@example.com"`
+	Email  string `json:"email" example:"user@example.com"`
+	Locale string `json:"locale" example:"id" enums:"en,id"`
 }
 
 type ErrorResponse struct {
@@ -104,21 +106,49 @@ type EmployeeWithInvitation struct {
 }
 
 type PlanningDescriptionFile struct {
-	ID       uint64 `json:"id" example:"1"`
-	FileName string `json:"file_name" example:"planning_description.pdf"`
-	FileURL  string `json:"file_url" example:"https://bucket-name.s3.amazonaws.com/planning_description.pdf"`
+	ID            uint64 `json:"id" example:"1"`
+	TaskID        uint64 `json:"task_id" example:"1"`
+	FileName      string `json:"file_name" example:"planning_description.pdf"`
+	FileURL       string `json:"file_url" example:"https://api.example.com/task/1/files/1/download?type=planning-description-file"`
+	Size          int64  `json:"size" example:"248312"`
+	ContentType   string `json:"content_type" example:"application/pdf"`
+	Checksum      string `json:"checksum" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	ScanStatus    string `json:"scan_status" example:"clean" enums:"pending,clean,skipped"`
+	UploadedBy    uint64 `json:"uploaded_by" example:"2"`
+	UploaderEmail string `json:"uploader_email" example:"manager@example.com"`
+	CreatedAt     string `json:"created_at" example:"2024-05-01T09:30:00+07:00"`
 }
 
 type PlanningFile struct {
-	ID       uint64 `json:"id" example:"2"`
-	FileName string `json:"file_name" example:"planning_document.docx"`
-	FileURL  string `json:"file_url" example:"https://bucket-name.s3.amazonaws.com/planning_document.docx"`
+	ID            uint64 `json:"id" example:"2"`
+	TaskID        uint64 `json:"task_id" example:"1"`
+	FileName      string `json:"file_name" example:"planning_document.docx"`
+	FileURL       string `json:"file_url" example:"https://api.example.com/task/1/files/2/download?type=planning-file"`
+	ThumbnailURL  string `json:"thumbnail_url" example:"https://api.example.com/task/1/files/2/thumbnail?type=planning-file"`
+	Version       int    `json:"version" example:"1"`
+	Size          int64  `json:"size" example:"248312"`
+	ContentType   string `json:"content_type" example:"application/vnd.openxmlformats-officedocument.wordprocessingml.document"`
+	Checksum      string `json:"checksum" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	ScanStatus    string `json:"scan_status" example:"clean" enums:"pending,clean,skipped"`
+	UploadedBy    uint64 `json:"uploaded_by" example:"2"`
+	UploaderEmail string `json:"uploader_email" example:"manager@example.com"`
+	CreatedAt     string `json:"created_at" example:"2024-05-01T09:30:00+07:00"`
 }
 
 type ProjectFile struct {
-	ID       uint64 `json:"id" example:"3"`
-	FileName string `json:"file_name" example:"project_report.pdf"`
-	FileURL  string `json:"file_url" example:"https://bucket-name.s3.amazonaws.com/project_report.pdf"`
+	ID            uint64 `json:"id" example:"3"`
+	TaskID        uint64 `json:"task_id" example:"1"`
+	FileName      string `json:"file_name" example:"project_report.pdf"`
+	FileURL       string `json:"file_url" example:"https://api.example.com/task/1/files/3/download?type=project-file"`
+	ThumbnailURL  string `json:"thumbnail_url" example:"https://api.example.com/task/1/files/3/thumbnail?type=project-file"`
+	Version       int    `json:"version" example:"1"`
+	Size          int64  `json:"size" example:"248312"`
+	ContentType   string `json:"content_type" example:"application/pdf"`
+	Checksum      string `json:"checksum" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	ScanStatus    string `json:"scan_status" example:"clean" enums:"pending,clean,skipped"`
+	UploadedBy    uint64 `json:"uploaded_by" example:"2"`
+	UploaderEmail string `json:"uploader_email" example:"manager@example.com"`
+	CreatedAt     string `json:"created_at" example:"2024-05-01T09:30:00+07:00"`
 }
 
 type CreateBoardRequest struct {
@@ -194,6 +224,8 @@ type EmployeeResponse struct {
 }
 
 type FileResponse struct {
+	ID       uint64 `json:"id" example:"1"`
 	FileUrl  string `json:"file_url" example:"https://example.com/file.pdf"`
 	FileName string `json:"file_name" example:"document.pdf"`
+	Version  int    `json:"version" example:"1"`
 }
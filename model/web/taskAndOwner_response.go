@@ -5,20 +5,16 @@ import (
 )
 
 type UpdateResponse struct {
-	NameTask                  string `json:"name_task,omitempty"`
-	PlanningDescriptionPersen string `json:"planning_description_persen,omitempty"`
-	PlanningDescriptionFile   struct {
-		ID       uint64 `json:"id,omitempty"`
-		FileUrl  string `json:"file_url,omitempty"`
-		FileName string `json:"file_name,omitempty"`
-	} `json:"planning_description_file,omitempty"`
-	PlanningStatus  string `json:"planning_status,omitempty"`
-	ProjectStatus   string `json:"project_status,omitempty"`
-	PlanningDueDate string `json:"planning_due_date,omitempty"`
-	ProjectDueDate  string `json:"project_due_date,omitempty"`
-	Priority        string `json:"priority,omitempty"`
-	ProjectComment  string `json:"project_comment,omitempty"`
-	Manager         struct {
+	NameTask                  string               `json:"name_task,omitempty"`
+	PlanningDescriptionPersen string               `json:"planning_description_persen,omitempty"`
+	PlanningDescriptionFiles  []UpdateResponseFile `json:"planning_description_files,omitempty"`
+	PlanningStatus            string               `json:"planning_status,omitempty"`
+	ProjectStatus             string               `json:"project_status,omitempty"`
+	PlanningDueDate           string               `json:"planning_due_date,omitempty"`
+	ProjectDueDate            string               `json:"project_due_date,omitempty"`
+	Priority                  string               `json:"priority,omitempty"`
+	ProjectComment            string               `json:"project_comment,omitempty"`
+	Manager                   struct {
 		ID               uint64 `json:"id,omitempty"`
 		Email            string `json:"email,omitempty"`
 		UserID           uint64 `json:"user_id,omitempty"`
@@ -32,17 +28,17 @@ type UpdateResponse struct {
 		InvitationStatus string `json:"invitation_status,omitempty"`
 		InvitationID     uint64 `json:"invitation_id,omitempty"`
 	} `json:"employee,omitempty"`
-	PlanningFile struct {
-		ID       uint64 `json:"id,omitempty"`
-		FileUrl  string `json:"file_url,omitempty"`
-		FileName string `json:"file_name,omitempty"`
-	} `json:"planning_file,omitempty"`
-	ProjectFile struct {
-		ID       uint64 `json:"id,omitempty"`
-		FileUrl  string `json:"file_url,omitempty"`
-		FileName string `json:"file_name,omitempty"`
-	} `json:"project_file,omitempty"`
-	EmailsSent []string `json:"emails_sent,omitempty"`
+	PlanningFiles []UpdateResponseFile `json:"planning_files,omitempty"`
+	ProjectFiles  []UpdateResponseFile `json:"project_files,omitempty"`
+	EmailsSent    []string             `json:"emails_sent,omitempty"`
+}
+
+// UpdateResponseFile adalah file yang ditambahkan pada satu request update task
+type UpdateResponseFile struct {
+	ID       uint64 `json:"id,omitempty"`
+	FileUrl  string `json:"file_url,omitempty"`
+	FileName string `json:"file_name,omitempty"`
+	Version  int    `json:"version,omitempty"`
 }
 
 func CreateResponseTask(taskModel *domain.TaskWithInvitation) WebResponse {
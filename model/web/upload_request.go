@@ -0,0 +1,8 @@
+package web
+
+// CreateUploadRequest adalah body untuk memulai resumable upload
+type CreateUploadRequest struct {
+	FileName string `json:"file_name" example:"design.pdf"`
+	FileType string `json:"file_type" example:"project-file" enums:"planning-file,project-file"`
+	Size     int64  `json:"size" example:"52428800"`
+}
@@ -9,8 +9,9 @@ func CreateResponseUser(userModel *domain.User) WebResponse {
 		Code:    200,
 		Message: "Success",
 		Data: domain.User{
-			ID:    userModel.ID,
-			Email: userModel.Email,
+			ID:     userModel.ID,
+			Email:  userModel.Email,
+			Locale: userModel.Locale,
 		},
 	}
 }
@@ -0,0 +1,9 @@
+package web
+
+// CreateWebhookRequest mendaftarkan url webhook pada board. Secret dibuat otomatis jika kosong,
+// events kosong berarti melanggan semua event
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" example:"https://example.com/hooks/tasks"`
+	Secret string   `json:"secret" example:"a-long-random-secret"`
+	Events []string `json:"events" example:"task.created,task.updated"`
+}
@@ -0,0 +1,17 @@
+package web
+
+import "manajemen_tugas_master/model/domain"
+
+// CreatedWebhookResponse adalah webhook yang baru didaftarkan, secret hanya ditampilkan sekali pada respons ini
+type CreatedWebhookResponse struct {
+	domain.Webhook
+	Secret string `json:"secret" example:"3f0c9b..."`
+}
+
+// WebhookDeliveryListResponse adalah satu halaman riwayat pengiriman webhook, terbaru lebih dulu
+type WebhookDeliveryListResponse struct {
+	Total      int64                    `json:"total" example:"42"`
+	Limit      int                      `json:"limit" example:"50"`
+	Offset     int                      `json:"offset" example:"0"`
+	Deliveries []domain.WebhookDelivery `json:"deliveries"`
+}
@@ -47,6 +47,7 @@ func (b *boardRepository) FindById(id uint64) (*domain.Board, error) {
 
 	// Fetch invitation information for each task
 	for i, task := range board.Tasks {
+		setTaskFileDetails(b.db, &board.Tasks[i])
 		var managersWithInvitation []domain.ManagerWithInvitation
 		var employeesWithInvitation []domain.EmployeeWithInvitation
 
@@ -102,6 +103,7 @@ func (b *boardRepository) GetAllBoards() ([]*domain.Board, error) {
 
 	for _, board := range boards {
 		for i, task := range board.Tasks {
+			setTaskFileDetails(b.db, &task)
 			taskWithInvitation := domain.TaskWithInvitation{Task: task}
 
 			// Fetch and set invitation status for managers
@@ -159,17 +161,61 @@ func (b *boardRepository) DeleteById(id uint64) (*gorm.DB, int64, int64, int64,
 			return err
 		}
 
-		// Count and delete associated records in task_planning_files
-		tx.Model(&domain.PlanningFile{}).Where("task_id IN (?)", taskIDs).Count(&countPlanningFiles)
+		// Delete associated planning files
+		var planningFileIDs []uint64
+		if err := tx.Table("task_planning_files").Where("task_id IN (?)", taskIDs).Pluck("planning_file_id", &planningFileIDs).Error; err != nil {
+			return err
+		}
+		countPlanningFiles = int64(len(planningFileIDs))
 		if err := tx.Exec("DELETE FROM task_planning_files WHERE task_id IN (?)", taskIDs).Error; err != nil {
 			return err
 		}
+		if _, err := deleteFileVersions(tx, domain.FileTypePlanning, planningFileIDs); err != nil {
+			return err
+		}
+		if err := tx.Where("id IN (?)", planningFileIDs).Delete(&domain.PlanningFile{}).Error; err != nil {
+			return err
+		}
 
-		// Count and delete associated records in task_project_files
-		tx.Model(&domain.ProjectFile{}).Where("task_id IN (?)", taskIDs).Count(&countProjectFiles)
+		// Delete associated project files
+		var projectFileIDs []uint64
+		if err := tx.Table("task_project_files").Where("task_id IN (?)", taskIDs).Pluck("project_file_id", &projectFileIDs).Error; err != nil {
+			return err
+		}
+		countProjectFiles = int64(len(projectFileIDs))
 		if err := tx.Exec("DELETE FROM task_project_files WHERE task_id IN (?)", taskIDs).Error; err != nil {
 			return err
 		}
+		if _, err := deleteFileVersions(tx, domain.FileTypeProject, projectFileIDs); err != nil {
+			return err
+		}
+		if err := tx.Where("id IN (?)", projectFileIDs).Delete(&domain.ProjectFile{}).Error; err != nil {
+			return err
+		}
+
+		// Delete associated planning description files
+		var planningDescriptionFileIDs []uint64
+		if err := tx.Table("task_planning_description_files").Where("task_id IN (?)", taskIDs).Pluck("planning_description_file_id", &planningDescriptionFileIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM task_planning_description_files WHERE task_id IN (?)", taskIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id IN (?)", planningDescriptionFileIDs).Delete(&domain.PlanningDescriptionFile{}).Error; err != nil {
+			return err
+		}
+
+		// Delete pengingat tenggat task pada board
+		if err := tx.Where("task_id IN (?)", taskIDs).Delete(&domain.DueDateReminder{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("task_id IN (?)", taskIDs).Delete(&domain.TaskEscalation{}).Error; err != nil {
+			return err
+		}
+		// event calendar dibatalkan oleh service, catatannya dihapus agar tidak dipakai task baru dengan id yang sama
+		if err := tx.Where("task_id IN (?)", taskIDs).Delete(&domain.TaskCalendarEvent{}).Error; err != nil {
+			return err
+		}
 
 		// Count and delete associated tasks
 		tx.Model(&domain.Task{}).Where("board_id = ?", id).Count(&countTasks)
@@ -177,6 +223,21 @@ func (b *boardRepository) DeleteById(id uint64) (*gorm.DB, int64, int64, int64,
 			return err
 		}
 
+		// Delete integrasi chat board agar board baru dengan id yang sama tidak mengirim ke channel lama
+		if err := tx.Where("board_id = ?", id).Delete(&domain.ChatIntegration{}).Error; err != nil {
+			return err
+		}
+
+		// Delete aturan eskalasi board
+		if err := tx.Where("board_id = ?", id).Delete(&domain.EscalationPolicy{}).Error; err != nil {
+			return err
+		}
+
+		// Delete feed calendar board agar url lama tidak menampilkan board baru dengan id yang sama
+		if err := tx.Where("board_id = ?", id).Delete(&domain.CalendarFeed{}).Error; err != nil {
+			return err
+		}
+
 		// Delete the board
 		if err := tx.Delete(&domain.Board{}, id).Error; err != nil {
 			return err
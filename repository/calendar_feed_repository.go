@@ -0,0 +1,13 @@
+package repository
+
+import "manajemen_tugas_master/model/domain"
+
+type CalendarFeedRepository interface {
+	FindBoard(boardID uint64) (*domain.Board, error)
+	FindFeed(userID uint64, boardID uint64) (*domain.CalendarFeed, error)
+	FindFeedByToken(token string) (*domain.CalendarFeed, error)
+	CreateFeed(feed *domain.CalendarFeed) error
+	SaveFeed(feed *domain.CalendarFeed) error
+	FindUserDueTasks(userID uint64) ([]domain.Task, error)
+	FindBoardDueTasks(boardID uint64) ([]domain.Task, error)
+}
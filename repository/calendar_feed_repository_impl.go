@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"manajemen_tugas_master/model/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type calendarFeedRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarFeedRepository(db *gorm.DB) CalendarFeedRepository {
+	return &calendarFeedRepository{db}
+}
+
+// FindBoard mengambil nama dan owner board untuk feed board
+func (c *calendarFeedRepository) FindBoard(boardID uint64) (*domain.Board, error) {
+	var board domain.Board
+	if err := c.db.Select("id", "name_board", "user_id").First(&board, boardID).Error; err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+func (c *calendarFeedRepository) FindFeed(userID uint64, boardID uint64) (*domain.CalendarFeed, error) {
+	var feed domain.CalendarFeed
+	if err := c.db.Where("user_id = ? AND board_id = ?", userID, boardID).First(&feed).Error; err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+func (c *calendarFeedRepository) FindFeedByToken(token string) (*domain.CalendarFeed, error) {
+	var feed domain.CalendarFeed
+	if err := c.db.Where("token = ?", token).First(&feed).Error; err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+// CreateFeed membuat feed jika belum ada, feed yang dibuat bersamaan oleh request lain dibiarkan
+func (c *calendarFeedRepository) CreateFeed(feed *domain.CalendarFeed) error {
+	return c.db.Clauses(clause.OnConflict{DoNothing: true}).Create(feed).Error
+}
+
+// SaveFeed menyimpan feed dengan token baru, token lama tidak berlaku lagi
+func (c *calendarFeedRepository) SaveFeed(feed *domain.CalendarFeed) error {
+	return c.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "board_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token", "updated_at"}),
+	}).Create(feed).Error
+}
+
+// FindUserDueTasks mengambil task bertenggat tempat user menjadi owner, manager atau employee
+func (c *calendarFeedRepository) FindUserDueTasks(userID uint64) ([]domain.Task, error) {
+	owners := c.db.Model(&domain.Owner{}).Select("id").Where("user_id = ?", userID)
+	managers := c.db.Table("task_managers").Select("task_managers.task_id").
+		Joins("JOIN managers ON managers.id = task_managers.manager_id").
+		Where("managers.user_id = ?", userID)
+	employees := c.db.Table("task_employees").Select("task_employees.task_id").
+		Joins("JOIN employees ON employees.id = task_employees.employee_id").
+		Where("employees.user_id = ?", userID)
+
+	return c.findDueTasks(c.db.Where("owner_id IN (?) OR id IN (?) OR id IN (?)", owners, managers, employees))
+}
+
+func (c *calendarFeedRepository) FindBoardDueTasks(boardID uint64) ([]domain.Task, error) {
+	return c.findDueTasks(c.db.Where("board_id = ?", boardID))
+}
+
+func (c *calendarFeedRepository) findDueTasks(query *gorm.DB) ([]domain.Task, error) {
+	var tasks []domain.Task
+	err := query.
+		Select("id", "board_id", "name_task", "planning_status", "project_status", "planning_due_date", "project_due_date", "priority").
+		Preload("Board", func(db *gorm.DB) *gorm.DB { return db.Select("id", "name_board") }).
+		Where("planning_due_date <> '' OR project_due_date <> ''").
+		Order("id").
+		Find(&tasks).Error
+	return tasks, err
+}
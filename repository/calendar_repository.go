@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"manajemen_tugas_master/model/domain"
+	"time"
+)
+
+type CalendarRepository interface {
+	FindConnection(userID uint64) (*domain.CalendarConnection, error)
+	SaveConnection(connection *domain.CalendarConnection) error
+	UpdateTokens(userID uint64, accessToken string, refreshToken string, expiry *time.Time) error
+	DeleteConnection(userID uint64) error
+	FindTaskEvent(taskID uint64, dueType string) (*domain.TaskCalendarEvent, error)
+	FindTaskEvents(taskID uint64) ([]domain.TaskCalendarEvent, error)
+	FindBoardEvents(boardID uint64) ([]domain.TaskCalendarEvent, error)
+	SaveTaskEvent(event *domain.TaskCalendarEvent) error
+	DeleteTaskEvent(taskID uint64, dueType string) error
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"manajemen_tugas_master/model/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type calendarRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarRepository(db *gorm.DB) CalendarRepository {
+	return &calendarRepository{db}
+}
+
+func (c *calendarRepository) FindConnection(userID uint64) (*domain.CalendarConnection, error) {
+	var connection domain.CalendarConnection
+	if err := c.db.Where("user_id = ?", userID).First(&connection).Error; err != nil {
+		return nil, err
+	}
+	return &connection, nil
+}
+
+// SaveConnection menyimpan koneksi calendar user, koneksi sebelumnya diganti saat user menghubungkan ulang
+func (c *calendarRepository) SaveConnection(connection *domain.CalendarConnection) error {
+	return c.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email", "refresh_token", "access_token", "token_expiry", "created_at", "updated_at"}),
+	}).Create(connection).Error
+}
+
+// UpdateTokens menyimpan token hasil refresh, refresh token kosong berarti Google tidak menggantinya
+func (c *calendarRepository) UpdateTokens(userID uint64, accessToken string, refreshToken string, expiry *time.Time) error {
+	updates := map[string]interface{}{
+		"access_token": accessToken,
+		"token_expiry": expiry,
+		"updated_at":   time.Now(),
+	}
+	if refreshToken != "" {
+		updates["refresh_token"] = refreshToken
+	}
+	return c.db.Model(&domain.CalendarConnection{}).Where("user_id = ?", userID).Updates(updates).Error
+}
+
+func (c *calendarRepository) DeleteConnection(userID uint64) error {
+	return c.db.Where("user_id = ?", userID).Delete(&domain.CalendarConnection{}).Error
+}
+
+func (c *calendarRepository) FindTaskEvent(taskID uint64, dueType string) (*domain.TaskCalendarEvent, error) {
+	var event domain.TaskCalendarEvent
+	if err := c.db.Where("task_id = ? AND due_type = ?", taskID, dueType).First(&event).Error; err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (c *calendarRepository) FindTaskEvents(taskID uint64) ([]domain.TaskCalendarEvent, error) {
+	var events []domain.TaskCalendarEvent
+	err := c.db.Where("task_id = ?", taskID).Order("id").Find(&events).Error
+	return events, err
+}
+
+func (c *calendarRepository) FindBoardEvents(boardID uint64) ([]domain.TaskCalendarEvent, error) {
+	var events []domain.TaskCalendarEvent
+	err := c.db.Where("task_id IN (?)", c.db.Model(&domain.Task{}).Select("id").Where("board_id = ?", boardID)).
+		Order("id").
+		Find(&events).Error
+	return events, err
+}
+
+// SaveTaskEvent menyimpan event calendar tenggat task, event sebelumnya untuk tenggat yang sama diganti
+func (c *calendarRepository) SaveTaskEvent(event *domain.TaskCalendarEvent) error {
+	return c.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "task_id"}, {Name: "due_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "event_id", "updated_at"}),
+	}).Create(event).Error
+}
+
+func (c *calendarRepository) DeleteTaskEvent(taskID uint64, dueType string) error {
+	return c.db.Where("task_id = ? AND due_type = ?", taskID, dueType).Delete(&domain.TaskCalendarEvent{}).Error
+}
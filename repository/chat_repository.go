@@ -0,0 +1,13 @@
+package repository
+
+import "manajemen_tugas_master/model/domain"
+
+type ChatRepository interface {
+	FindBoard(boardID uint64) (*domain.Board, error)
+	Create(integration *domain.ChatIntegration) error
+	Update(integration *domain.ChatIntegration) error
+	FindByBoard(boardID uint64) ([]domain.ChatIntegration, error)
+	FindByID(boardID uint64, id uint64) (*domain.ChatIntegration, error)
+	FindByIDs(ids []uint64) ([]domain.ChatIntegration, error)
+	Delete(id uint64) error
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"manajemen_tugas_master/model/domain"
+
+	"gorm.io/gorm"
+)
+
+type chatRepository struct {
+	db *gorm.DB
+}
+
+func NewChatRepository(db *gorm.DB) ChatRepository {
+	return &chatRepository{db}
+}
+
+// FindBoard mengambil nama dan owner board untuk memeriksa akses dan menulis footer pesan chat
+func (c *chatRepository) FindBoard(boardID uint64) (*domain.Board, error) {
+	var board domain.Board
+	if err := c.db.Select("id", "name_board", "user_id").First(&board, boardID).Error; err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+func (c *chatRepository) Create(integration *domain.ChatIntegration) error {
+	return c.db.Create(integration).Error
+}
+
+func (c *chatRepository) Update(integration *domain.ChatIntegration) error {
+	return c.db.Model(integration).Select("locale", "events", "active", "updated_at").Updates(integration).Error
+}
+
+func (c *chatRepository) FindByBoard(boardID uint64) ([]domain.ChatIntegration, error) {
+	var integrations []domain.ChatIntegration
+	err := c.db.Where("board_id = ?", boardID).Order("id").Find(&integrations).Error
+	return integrations, err
+}
+
+func (c *chatRepository) FindByID(boardID uint64, id uint64) (*domain.ChatIntegration, error) {
+	var integration domain.ChatIntegration
+	if err := c.db.Where("board_id = ?", boardID).First(&integration, id).Error; err != nil {
+		return nil, err
+	}
+	return &integration, nil
+}
+
+// FindByIDs mengambil integrasi tujuan pesan chat pada outbox, integrasi yang sudah dihapus tidak dikembalikan
+func (c *chatRepository) FindByIDs(ids []uint64) ([]domain.ChatIntegration, error) {
+	var integrations []domain.ChatIntegration
+	if len(ids) == 0 {
+		return integrations, nil
+	}
+	err := c.db.Where("id IN ?", ids).Find(&integrations).Error
+	return integrations, err
+}
+
+func (c *chatRepository) Delete(id uint64) error {
+	return c.db.Delete(&domain.ChatIntegration{}, id).Error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"manajemen_tugas_master/model/domain"
+	"time"
+)
+
+type EscalationRepository interface {
+	FindBoard(boardID uint64) (*domain.Board, error)
+	FindPolicy(boardID uint64) (*domain.EscalationPolicy, error)
+	FindPolicies(boardIDs []uint64) ([]domain.EscalationPolicy, error)
+	SavePolicy(policy *domain.EscalationPolicy) error
+	FindOverdueTasks() ([]domain.Task, error)
+	FindEscalations(taskIDs []uint64) ([]domain.TaskEscalation, error)
+	CreateEscalation(escalation *domain.TaskEscalation, message *domain.OutboxMessage) (bool, error)
+	ResolveEscalations(resolvedAt time.Time) (int64, error)
+}
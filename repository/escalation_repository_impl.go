@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"manajemen_tugas_master/model/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type escalationRepository struct {
+	db *gorm.DB
+}
+
+func NewEscalationRepository(db *gorm.DB) EscalationRepository {
+	return &escalationRepository{db}
+}
+
+// FindBoard mengambil owner board untuk memeriksa akses aturan eskalasi
+func (e *escalationRepository) FindBoard(boardID uint64) (*domain.Board, error) {
+	var board domain.Board
+	if err := e.db.Select("id", "name_board", "user_id").First(&board, boardID).Error; err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+func (e *escalationRepository) FindPolicy(boardID uint64) (*domain.EscalationPolicy, error) {
+	var policy domain.EscalationPolicy
+	if err := e.db.Where("board_id = ?", boardID).First(&policy).Error; err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (e *escalationRepository) FindPolicies(boardIDs []uint64) ([]domain.EscalationPolicy, error) {
+	var policies []domain.EscalationPolicy
+	if len(boardIDs) == 0 {
+		return policies, nil
+	}
+	err := e.db.Where("board_id IN ?", boardIDs).Find(&policies).Error
+	return policies, err
+}
+
+// SavePolicy menyimpan aturan eskalasi board, aturan yang sudah ada diganti
+func (e *escalationRepository) SavePolicy(policy *domain.EscalationPolicy) error {
+	return e.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "board_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "manager_after_hours", "owner_after_hours", "updated_at"}),
+	}).Create(policy).Error
+}
+
+// FindOverdueTasks mengambil task dengan tenggat project yang project-nya masih Working atau Undone. Tanggal tenggat
+// disimpan sebagai teks sehingga apakah tenggat sudah lewat diperiksa oleh service
+func (e *escalationRepository) FindOverdueTasks() ([]domain.Task, error) {
+	var tasks []domain.Task
+	err := e.db.Select("id", "board_id", "name_task", "project_due_date", "project_status").
+		Where("project_due_date <> '' AND project_status IN ?", []string{"Working", "Undone"}).
+		Order("id").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (e *escalationRepository) FindEscalations(taskIDs []uint64) ([]domain.TaskEscalation, error) {
+	var escalations []domain.TaskEscalation
+	if len(taskIDs) == 0 {
+		return escalations, nil
+	}
+	err := e.db.Where("task_id IN ?", taskIDs).Find(&escalations).Error
+	return escalations, err
+}
+
+// CreateEscalation mencatat tahap eskalasi beserta emailnya pada outbox dalam satu transaksi. Jika tahap yang sama
+// sudah dicatat tidak ada yang dicatat dan hasilnya false
+func (e *escalationRepository) CreateEscalation(escalation *domain.TaskEscalation, message *domain.OutboxMessage) (bool, error) {
+	created := false
+	err := e.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(escalation)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		created = true
+		return createOutboxMessages(tx, []*domain.OutboxMessage{message})
+	})
+	return created && err == nil, err
+}
+
+// ResolveEscalations menyelesaikan eskalasi task yang project-nya sudah Done atau tenggatnya sudah diubah
+func (e *escalationRepository) ResolveEscalations(resolvedAt time.Time) (int64, error) {
+	result := e.db.Model(&domain.TaskEscalation{}).
+		Where("resolved_at IS NULL").
+		Where("EXISTS (SELECT 1 FROM tasks WHERE tasks.id = task_escalations.task_id AND (tasks.project_status = ? OR tasks.project_due_date <> task_escalations.due_date))", "Done").
+		Update("resolved_at", resolvedAt)
+	return result.RowsAffected, result.Error
+}
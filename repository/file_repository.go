@@ -0,0 +1,29 @@
+package repository
+
+import "manajemen_tugas_master/model/domain"
+
+type FileRepository interface {
+	FindTaskFile(taskID uint64, fileID uint64, fileType string) (fileKey string, fileName string, err error)
+	AcquireObject(object *domain.StoredObject) (*domain.StoredObject, error)
+	ReleaseObject(objectKey string) (bool, error)
+	FindTaskFileKeys(taskID uint64) ([]string, error)
+	FindBoardFileKeys(boardID uint64) ([]string, error)
+	CountFileReferences() (map[string]int64, error)
+	FindStoredObjects() ([]domain.StoredObject, error)
+	UpdateObjectRefCount(objectKey string, refCount int64) error
+	DeleteStoredObject(objectKey string) error
+	FindPendingThumbnails(limit int) ([]domain.StoredObject, error)
+	UpdateThumbnailStatus(objectKey string, status string) error
+	FindObjectScanStatus(objectKey string) (string, error)
+	FindPendingScans(limit int) ([]domain.StoredObject, error)
+	UpdateScanStatus(objectKey string, status string) error
+	FindObjectTaskFiles(objectKey string) ([]domain.TaskFile, error)
+	CreateQuarantinedFile(file *domain.QuarantinedFile) error
+	FindFileVersions(fileType string, fileID uint64) ([]domain.FileVersion, error)
+	FindFileVersion(fileType string, fileID uint64, version int) (*domain.FileVersion, error)
+	RestoreFileVersion(fileType string, fileID uint64, version int, restoredBy uint64) (*domain.FileVersion, error)
+	CountTaskFiles(taskID uint64, fileType string) (int64, error)
+	FindTaskFiles(taskIDs []uint64) ([]domain.TaskFile, error)
+	FindBoardWithTasks(boardID uint64) (*domain.Board, error)
+	TaskFileExists(taskID uint64, fileType string, fileName string) (bool, error)
+}
@@ -0,0 +1,660 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/domain"
+	"sort"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// taskFileTable adalah nama tabel file dan tabel penghubungnya dengan task
+type taskFileTable struct {
+	table     string
+	joinTable string
+	joinKey   string
+}
+
+var taskFileTables = map[string]taskFileTable{
+	domain.FileTypePlanningDescription: {"planning_description_files", "task_planning_description_files", "planning_description_file_id"},
+	domain.FileTypePlanning:            {"planning_files", "task_planning_files", "planning_file_id"},
+	domain.FileTypeProject:             {"project_files", "task_project_files", "project_file_id"},
+}
+
+type fileRepository struct {
+	db *gorm.DB
+}
+
+func NewFileRepository(db *gorm.DB) FileRepository {
+	return &fileRepository{db}
+}
+
+// CountTaskFiles menghitung file dengan jenis tertentu yang terhubung dengan task
+func (f *fileRepository) CountTaskFiles(taskID uint64, fileType string) (int64, error) {
+	tables, ok := taskFileTables[fileType]
+	if !ok {
+		return 0, fmt.Errorf("invalid file type %q", fileType)
+	}
+
+	var count int64
+	if err := f.db.Table(tables.joinTable).Where("task_id = ?", taskID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindTaskFiles mengambil semua file dari task-task tersebut, diurutkan per task, jenis file dan nama file
+func (f *fileRepository) FindTaskFiles(taskIDs []uint64) ([]domain.TaskFile, error) {
+	var files []domain.TaskFile
+	for _, fileType := range []string{domain.FileTypePlanningDescription, domain.FileTypePlanning, domain.FileTypeProject} {
+		tables := taskFileTables[fileType]
+
+		var rows []domain.TaskFile
+		err := f.db.Table(tables.table).
+			Select(tables.joinTable+".task_id, ? AS file_type, "+tables.table+".file_name, "+tables.table+".file_key, "+tables.table+".created_at", fileType).
+			Joins("JOIN "+tables.joinTable+" ON "+tables.joinTable+"."+tables.joinKey+" = "+tables.table+".id").
+			Where(tables.joinTable+".task_id IN ?", taskIDs).
+			// file yang belum selesai dipindai atau terinfeksi tidak ikut diunduh
+			Where(tables.table+".scan_status IS NULL OR "+tables.table+".scan_status NOT IN ?", []string{domain.ScanStatusPending, domain.ScanStatusInfected}).
+			Order(tables.joinTable + ".task_id, " + tables.table + ".file_name, " + tables.table + ".id").
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, rows...)
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].TaskID < files[j].TaskID
+	})
+	return files, nil
+}
+
+// FindBoardWithTasks mengambil board beserta id dan nama task-tasknya
+func (f *fileRepository) FindBoardWithTasks(boardID uint64) (*domain.Board, error) {
+	var board domain.Board
+	err := f.db.Preload("Tasks", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id, board_id, name_task").Order("id")
+	}).First(&board, boardID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// TaskFileExists memeriksa apakah task sudah memiliki file dengan nama yang sama, upload ulang file tersebut menjadi versi baru
+func (f *fileRepository) TaskFileExists(taskID uint64, fileType string, fileName string) (bool, error) {
+	fileID, _, err := findTaskDocument(f.db, taskID, fileType, fileName)
+	if err != nil {
+		return false, err
+	}
+	return fileID != 0, nil
+}
+
+func (f *fileRepository) FindTaskFile(taskID uint64, fileID uint64, fileType string) (string, string, error) {
+	tables, ok := taskFileTables[fileType]
+	if !ok {
+		return "", "", fmt.Errorf("invalid file type %q", fileType)
+	}
+
+	// file hanya ditemukan jika benar-benar terhubung dengan task tersebut
+	var file struct {
+		FileKey  string
+		FileName string
+	}
+	err := f.db.Table(tables.table).
+		Select(tables.table+".file_key, "+tables.table+".file_name").
+		Joins("JOIN "+tables.joinTable+" ON "+tables.joinTable+"."+tables.joinKey+" = "+tables.table+".id").
+		Where(tables.joinTable+".task_id = ? AND "+tables.table+".id = ?", taskID, fileID).
+		Take(&file).Error
+	if err != nil {
+		return "", "", err
+	}
+
+	return file.FileKey, file.FileName, nil
+}
+
+// AcquireObject menambah satu referensi ke objek dengan checksum yang sama, atau mencatat objek baru jika belum ada.
+// Objek yang dikembalikan bisa memiliki key berbeda dari object.ObjectKey jika isi file yang sama sudah tersimpan
+func (f *fileRepository) AcquireObject(object *domain.StoredObject) (*domain.StoredObject, error) {
+	var stored domain.StoredObject
+	err := f.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("checksum = ?", object.Checksum).
+			First(&stored).Error
+		if err == nil {
+			stored.RefCount++
+			// hasil pemindaian yang bersih menggantikan status objek yang belum pasti
+			if object.ScanStatus == domain.ScanStatusClean && stored.ScanStatus != domain.ScanStatusClean && stored.ScanStatus != domain.ScanStatusInfected {
+				stored.ScanStatus = domain.ScanStatusClean
+				if err := updateScanStatus(tx, stored.ObjectKey, stored.ScanStatus); err != nil {
+					return err
+				}
+			}
+			return tx.Model(&stored).Update("ref_count", stored.RefCount).Error
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		stored = *object
+		stored.RefCount = 1
+		return tx.Create(&stored).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to save stored object: %v", err)
+	}
+
+	return &stored, nil
+}
+
+// ReleaseObject mengurangi satu referensi dan mengembalikan true jika objek sudah tidak dipakai dan boleh dihapus dari storage
+func (f *fileRepository) ReleaseObject(objectKey string) (bool, error) {
+	remove := false
+	err := f.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		remove, err = releaseObjectReference(tx, objectKey)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("Failed to release stored object: %v", err)
+	}
+
+	return remove, nil
+}
+
+func releaseObjectReference(tx *gorm.DB, objectKey string) (bool, error) {
+	var stored domain.StoredObject
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("object_key = ?", objectKey).
+		First(&stored).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// objek tanpa catatan tidak dipakai bersama, sehingga langsung dihapus
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if stored.RefCount > 1 {
+		return false, tx.Model(&stored).Update("ref_count", stored.RefCount-1).Error
+	}
+
+	return true, tx.Delete(&stored).Error
+}
+
+// addObjectReference menambah satu referensi untuk record baru yang memakai objek yang sudah tersimpan
+func addObjectReference(tx *gorm.DB, objectKey string) error {
+	result := tx.Model(&domain.StoredObject{}).
+		Where("object_key = ?", objectKey).
+		Update("ref_count", gorm.Expr("ref_count + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		// objek lama tanpa catatan sudah dipakai satu record, ditambah record baru
+		return tx.Create(&domain.StoredObject{ObjectKey: objectKey, RefCount: 2}).Error
+	}
+	return nil
+}
+
+// findTaskDocument mencari file dengan nama yang sama pada task, dipakai untuk menentukan apakah upload adalah versi baru
+func findTaskDocument(db *gorm.DB, taskID uint64, fileType string, fileName string) (uint64, string, error) {
+	tables, ok := taskFileTables[fileType]
+	if !ok {
+		return 0, "", fmt.Errorf("invalid file type %q", fileType)
+	}
+
+	var file struct {
+		ID      uint64
+		FileKey string
+	}
+	err := db.Table(tables.table).
+		Select(tables.table+".id, "+tables.table+".file_key").
+		Joins("JOIN "+tables.joinTable+" ON "+tables.joinTable+"."+tables.joinKey+" = "+tables.table+".id").
+		Where(tables.joinTable+".task_id = ? AND "+tables.table+".file_name = ?", taskID, fileName).
+		Order(tables.table + ".id DESC").
+		Take(&file).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, "", nil
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	return file.ID, file.FileKey, nil
+}
+
+// createFileVersion mencatat versi file beserta metadata objeknya, versi memegang satu referensi ke objek
+func createFileVersion(tx *gorm.DB, version *domain.FileVersion) error {
+	var stored domain.StoredObject
+	if err := tx.Where("object_key = ?", version.FileKey).Take(&stored).Error; err == nil {
+		version.Size = stored.Size
+		version.ContentType = stored.ContentType
+		version.Checksum = stored.Checksum
+		version.ScanStatus = stored.ScanStatus
+	}
+	if err := tx.Create(version).Error; err != nil {
+		return fmt.Errorf("Failed to save file version: %v", err)
+	}
+	return addObjectReference(tx, version.FileKey)
+}
+
+// addFileVersion menjadikan objek newKey sebagai versi terbaru dari file. Referensi record file untuk newKey harus sudah dimiliki pemanggil,
+// referensi record file ke objek lama dilepas karena objek lama tetap dipegang oleh versinya
+func addFileVersion(tx *gorm.DB, fileType string, fileID uint64, newKey string, newName string, uploadedBy uint64) (*domain.FileVersion, error) {
+	tables, ok := taskFileTables[fileType]
+	if !ok {
+		return nil, fmt.Errorf("invalid file type %q", fileType)
+	}
+
+	var current struct {
+		FileKey  string
+		FileName string
+	}
+	if err := tx.Table(tables.table).Select("file_key, file_name").Where("id = ?", fileID).Take(&current).Error; err != nil {
+		return nil, err
+	}
+
+	var latest int
+	if err := tx.Model(&domain.FileVersion{}).
+		Where("file_type = ? AND file_id = ?", fileType, fileID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).Error; err != nil {
+		return nil, err
+	}
+
+	// file yang di-upload sebelum ada riwayat versi dicatat sebagai versi 1
+	if latest == 0 {
+		initial := &domain.FileVersion{FileType: fileType, FileID: fileID, Version: 1, FileKey: current.FileKey, FileName: current.FileName}
+		if err := createFileVersion(tx, initial); err != nil {
+			return nil, err
+		}
+		latest = 1
+	}
+
+	version := &domain.FileVersion{FileType: fileType, FileID: fileID, Version: latest + 1, FileKey: newKey, FileName: newName, UploadedBy: uploadedBy}
+	if err := createFileVersion(tx, version); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Table(tables.table).Where("id = ?", fileID).Updates(map[string]interface{}{
+		"file_key":     newKey,
+		"file_name":    newName,
+		"version":      version.Version,
+		"size":         version.Size,
+		"content_type": version.ContentType,
+		"checksum":     version.Checksum,
+		"scan_status":  version.ScanStatus,
+		"uploaded_by":  uploadedBy,
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	if _, err := releaseObjectReference(tx, current.FileKey); err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+func (f *fileRepository) FindFileVersions(fileType string, fileID uint64) ([]domain.FileVersion, error) {
+	var versions []domain.FileVersion
+	err := f.db.Model(&domain.FileVersion{}).
+		Select("file_versions.*, users.email AS uploader_email").
+		Joins("LEFT JOIN users ON users.id = file_versions.uploaded_by").
+		Where("file_versions.file_type = ? AND file_versions.file_id = ?", fileType, fileID).
+		Order("file_versions.version").
+		Find(&versions).Error
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func (f *fileRepository) FindFileVersion(fileType string, fileID uint64, version int) (*domain.FileVersion, error) {
+	var fileVersion domain.FileVersion
+	err := f.db.Model(&domain.FileVersion{}).
+		Select("file_versions.*, users.email AS uploader_email").
+		Joins("LEFT JOIN users ON users.id = file_versions.uploaded_by").
+		Where("file_versions.file_type = ? AND file_versions.file_id = ? AND file_versions.version = ?", fileType, fileID, version).
+		Take(&fileVersion).Error
+	if err != nil {
+		return nil, err
+	}
+	return &fileVersion, nil
+}
+
+// RestoreFileVersion memulihkan versi lama dengan menjadikannya versi terbaru, sehingga riwayat versi tidak pernah berubah
+func (f *fileRepository) RestoreFileVersion(fileType string, fileID uint64, version int, restoredBy uint64) (*domain.FileVersion, error) {
+	var restored *domain.FileVersion
+	err := f.db.Transaction(func(tx *gorm.DB) error {
+		var source domain.FileVersion
+		if err := tx.Where("file_type = ? AND file_id = ? AND version = ?", fileType, fileID, version).Take(&source).Error; err != nil {
+			return err
+		}
+
+		// record file akan memakai objek versi lama, sehingga perlu referensi sendiri
+		if err := addObjectReference(tx, source.FileKey); err != nil {
+			return err
+		}
+
+		var err error
+		restored, err = addFileVersion(tx, fileType, fileID, source.FileKey, source.FileName, restoredBy)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restored, nil
+}
+
+// deleteFileVersions menghapus riwayat versi dari file yang dihapus dan mengembalikan key objek yang referensinya harus dilepas
+func deleteFileVersions(tx *gorm.DB, fileType string, fileIDs interface{}) ([]string, error) {
+	var keys []string
+	if err := tx.Model(&domain.FileVersion{}).Where("file_type = ? AND file_id IN (?)", fileType, fileIDs).Pluck("file_key", &keys).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("file_type = ? AND file_id IN (?)", fileType, fileIDs).Delete(&domain.FileVersion{}).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// FindTaskFileKeys mengambil key objek dari semua file yang terhubung dengan task
+func (f *fileRepository) FindTaskFileKeys(taskID uint64) ([]string, error) {
+	return f.findFileKeys("task_id = ?", taskID)
+}
+
+// FindBoardFileKeys mengambil key objek dari semua file pada task-task di dalam board
+func (f *fileRepository) FindBoardFileKeys(boardID uint64) ([]string, error) {
+	return f.findFileKeys("task_id IN (?)", f.db.Model(&domain.Task{}).Select("id").Where("board_id = ?", boardID))
+}
+
+func (f *fileRepository) findFileKeys(query string, args ...interface{}) ([]string, error) {
+	var fileKeys []string
+	for fileType, tables := range taskFileTables {
+		var keys []string
+		err := f.db.Table(tables.table).
+			Joins("JOIN "+tables.joinTable+" ON "+tables.joinTable+"."+tables.joinKey+" = "+tables.table+".id").
+			Where(tables.joinTable+"."+query, args...).
+			Where(tables.table+".file_key <> ''").
+			Pluck(tables.table+".file_key", &keys).Error
+		if err != nil {
+			return nil, fmt.Errorf("Failed to retrieve file keys of %s: %v", tables.table, err)
+		}
+		fileKeys = append(fileKeys, keys...)
+
+		// setiap versi juga memegang referensi ke objeknya
+		err = f.db.Model(&domain.FileVersion{}).
+			Joins("JOIN "+tables.joinTable+" ON "+tables.joinTable+"."+tables.joinKey+" = file_versions.file_id").
+			Where("file_versions.file_type = ?", fileType).
+			Where(tables.joinTable+"."+query, args...).
+			Pluck("file_versions.file_key", &keys).Error
+		if err != nil {
+			return nil, fmt.Errorf("Failed to retrieve file version keys of %s: %v", tables.table, err)
+		}
+		fileKeys = append(fileKeys, keys...)
+	}
+	return fileKeys, nil
+}
+
+// CountFileReferences menghitung jumlah file dan versi file yang memakai setiap key objek
+func (f *fileRepository) CountFileReferences() (map[string]int64, error) {
+	references := make(map[string]int64)
+	for fileType, tables := range taskFileTables {
+		var rows []struct {
+			FileKey string
+			Total   int64
+		}
+		err := f.db.Table(tables.table).
+			Select("file_key, COUNT(*) AS total").
+			Where("file_key <> ''").
+			Group("file_key").
+			Scan(&rows).Error
+		if err != nil {
+			return nil, fmt.Errorf("Failed to count file references of %s: %v", tables.table, err)
+		}
+		for _, row := range rows {
+			references[row.FileKey] += row.Total
+		}
+
+		// versi dari file yang sudah tidak ada tidak dihitung
+		rows = nil
+		err = f.db.Model(&domain.FileVersion{}).
+			Select("file_versions.file_key, COUNT(*) AS total").
+			Joins("JOIN "+tables.table+" ON "+tables.table+".id = file_versions.file_id").
+			Where("file_versions.file_type = ?", fileType).
+			Group("file_versions.file_key").
+			Scan(&rows).Error
+		if err != nil {
+			return nil, fmt.Errorf("Failed to count file version references of %s: %v", tables.table, err)
+		}
+		for _, row := range rows {
+			references[row.FileKey] += row.Total
+		}
+	}
+
+	// resumable upload yang sudah digabung atau selesai tetapi belum dilampirkan juga memegang referensi
+	var rows []struct {
+		ObjectKey string
+		Total     int64
+	}
+	err := f.db.Model(&domain.UploadSession{}).
+		Select("object_key, COUNT(*) AS total").
+		Where("status IN ?", []string{domain.UploadStatusAssembled, domain.UploadStatusCompleted}).
+		Group("object_key").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("Failed to count upload references: %v", err)
+	}
+	for _, row := range rows {
+		references[row.ObjectKey] += row.Total
+	}
+
+	return references, nil
+}
+
+func (f *fileRepository) FindStoredObjects() ([]domain.StoredObject, error) {
+	var objects []domain.StoredObject
+	if err := f.db.Find(&objects).Error; err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// FindPendingThumbnails mengembalikan objek yang thumbnail-nya belum dibuat, yang paling lama lebih dulu.
+// Objek yang belum lolos pemindaian virus ditunda
+func (f *fileRepository) FindPendingThumbnails(limit int) ([]domain.StoredObject, error) {
+	var objects []domain.StoredObject
+	err := f.db.Where("thumbnail_status = ?", domain.ThumbnailStatusPending).
+		Where("scan_status IS NULL OR scan_status NOT IN ?", []string{domain.ScanStatusPending, domain.ScanStatusInfected}).
+		Order("id").Limit(limit).Find(&objects).Error
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+func (f *fileRepository) UpdateThumbnailStatus(objectKey string, status string) error {
+	return f.db.Model(&domain.StoredObject{}).Where("object_key = ?", objectKey).Update("thumbnail_status", status).Error
+}
+
+// FindObjectScanStatus mengembalikan status pemindaian objek, kosong untuk objek lama tanpa catatan
+func (f *fileRepository) FindObjectScanStatus(objectKey string) (string, error) {
+	var statuses []string
+	if err := f.db.Model(&domain.StoredObject{}).Where("object_key = ?", objectKey).Limit(1).Pluck("scan_status", &statuses).Error; err != nil {
+		return "", err
+	}
+	if len(statuses) == 0 {
+		return "", nil
+	}
+	return statuses[0], nil
+}
+
+// FindPendingScans mengembalikan objek yang belum bisa dipindai saat di-upload karena scanner tidak tersedia
+func (f *fileRepository) FindPendingScans(limit int) ([]domain.StoredObject, error) {
+	var objects []domain.StoredObject
+	if err := f.db.Where("scan_status = ?", domain.ScanStatusPending).Order("id").Limit(limit).Find(&objects).Error; err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// UpdateScanStatus menyimpan hasil pemindaian pada objek dan pada semua file, versi dan upload yang memakai objek tersebut
+func (f *fileRepository) UpdateScanStatus(objectKey string, status string) error {
+	return f.db.Transaction(func(tx *gorm.DB) error {
+		return updateScanStatus(tx, objectKey, status)
+	})
+}
+
+func updateScanStatus(tx *gorm.DB, objectKey string, status string) error {
+	if err := tx.Model(&domain.StoredObject{}).Where("object_key = ?", objectKey).Update("scan_status", status).Error; err != nil {
+		return err
+	}
+	for _, tables := range taskFileTables {
+		if err := tx.Table(tables.table).Where("file_key = ?", objectKey).Update("scan_status", status).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Model(&domain.FileVersion{}).Where("file_key = ?", objectKey).Update("scan_status", status).Error; err != nil {
+		return err
+	}
+	return tx.Model(&domain.UploadSession{}).Where("object_key = ?", objectKey).Update("scan_status", status).Error
+}
+
+// FindObjectTaskFiles mengembalikan file pada task yang memakai objek tersebut
+func (f *fileRepository) FindObjectTaskFiles(objectKey string) ([]domain.TaskFile, error) {
+	var files []domain.TaskFile
+	for fileType, tables := range taskFileTables {
+		var rows []domain.TaskFile
+		err := f.db.Table(tables.table).
+			Select(tables.joinTable+".task_id, ? AS file_type, "+tables.table+".file_name, "+tables.table+".file_key, "+tables.table+".created_at", fileType).
+			Joins("JOIN "+tables.joinTable+" ON "+tables.joinTable+"."+tables.joinKey+" = "+tables.table+".id").
+			Where(tables.table+".file_key = ?", objectKey).
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, rows...)
+	}
+	return files, nil
+}
+
+func (f *fileRepository) CreateQuarantinedFile(file *domain.QuarantinedFile) error {
+	if err := f.db.Create(file).Error; err != nil {
+		return fmt.Errorf("Failed to save quarantined file: %v", err)
+	}
+	return nil
+}
+
+func (f *fileRepository) UpdateObjectRefCount(objectKey string, refCount int64) error {
+	return f.db.Model(&domain.StoredObject{}).Where("object_key = ?", objectKey).Update("ref_count", refCount).Error
+}
+
+func (f *fileRepository) DeleteStoredObject(objectKey string) error {
+	return f.db.Where("object_key = ?", objectKey).Delete(&domain.StoredObject{}).Error
+}
+
+// setTaskFileDetails mengisi file url dengan endpoint download, karena objek pada storage tidak lagi publik,
+// serta email user yang meng-upload setiap file
+func setTaskFileDetails(db *gorm.DB, task *domain.Task) {
+	var uploaderIDs []uint64
+	for i := range task.PlanningDescriptionFile {
+		task.PlanningDescriptionFile[i].FileUrl = helper.FileDownloadURL(task.ID, task.PlanningDescriptionFile[i].ID, domain.FileTypePlanningDescription)
+		uploaderIDs = append(uploaderIDs, task.PlanningDescriptionFile[i].UploadedBy)
+	}
+	for i := range task.PlanningFile {
+		task.PlanningFile[i].FileUrl = helper.FileDownloadURL(task.ID, task.PlanningFile[i].ID, domain.FileTypePlanning)
+		uploaderIDs = append(uploaderIDs, task.PlanningFile[i].UploadedBy)
+	}
+	for i := range task.ProjectFile {
+		task.ProjectFile[i].FileUrl = helper.FileDownloadURL(task.ID, task.ProjectFile[i].ID, domain.FileTypeProject)
+		uploaderIDs = append(uploaderIDs, task.ProjectFile[i].UploadedBy)
+	}
+
+	setThumbnailUrls(db, task)
+
+	emails := findUserEmails(db, uploaderIDs)
+	if len(emails) == 0 {
+		return
+	}
+	for i := range task.PlanningDescriptionFile {
+		task.PlanningDescriptionFile[i].UploaderEmail = emails[task.PlanningDescriptionFile[i].UploadedBy]
+	}
+	for i := range task.PlanningFile {
+		task.PlanningFile[i].UploaderEmail = emails[task.PlanningFile[i].UploadedBy]
+	}
+	for i := range task.ProjectFile {
+		task.ProjectFile[i].UploaderEmail = emails[task.ProjectFile[i].UploadedBy]
+	}
+}
+
+// setThumbnailUrls mengisi thumbnail url planning file dan project file yang thumbnail-nya sudah selesai dibuat
+func setThumbnailUrls(db *gorm.DB, task *domain.Task) {
+	var keys []string
+	for _, file := range task.PlanningFile {
+		keys = append(keys, file.FileKey)
+	}
+	for _, file := range task.ProjectFile {
+		keys = append(keys, file.FileKey)
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	var ready []string
+	if err := db.Model(&domain.StoredObject{}).
+		Where("object_key IN ? AND thumbnail_status = ?", keys, domain.ThumbnailStatusReady).
+		Pluck("object_key", &ready).Error; err != nil {
+		log.Printf("Failed to find file thumbnails: %v", err)
+		return
+	}
+	hasThumbnail := make(map[string]bool, len(ready))
+	for _, key := range ready {
+		hasThumbnail[key] = true
+	}
+
+	for i := range task.PlanningFile {
+		if hasThumbnail[task.PlanningFile[i].FileKey] {
+			task.PlanningFile[i].ThumbnailUrl = helper.FileThumbnailURL(task.ID, task.PlanningFile[i].ID, domain.FileTypePlanning)
+		}
+	}
+	for i := range task.ProjectFile {
+		if hasThumbnail[task.ProjectFile[i].FileKey] {
+			task.ProjectFile[i].ThumbnailUrl = helper.FileThumbnailURL(task.ID, task.ProjectFile[i].ID, domain.FileTypeProject)
+		}
+	}
+}
+
+// findUserEmails mengembalikan email per user id, file lama tanpa uploader (id 0) diabaikan
+func findUserEmails(db *gorm.DB, userIDs []uint64) map[uint64]string {
+	var ids []uint64
+	seen := make(map[uint64]bool)
+	for _, id := range userIDs {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var users []domain.User
+	if err := db.Select("id, email").Where("id IN ?", ids).Find(&users).Error; err != nil {
+		log.Printf("Failed to find file uploaders: %v", err)
+		return nil
+	}
+
+	emails := make(map[uint64]string, len(users))
+	for _, user := range users {
+		emails[user.ID] = user.Email
+	}
+	return emails
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"manajemen_tugas_master/model/domain"
+	"time"
+)
+
+type NotificationRepository interface {
+	Create(messages []*domain.OutboxMessage) error
+	ClaimDue(limit int, lease time.Duration) ([]domain.OutboxMessage, error)
+	MarkSent(id uint64) error
+	MarkFailed(id uint64, status string, nextAttemptAt time.Time, lastError string, recipients []string) error
+	FindAll(status string, limit int, offset int) ([]domain.OutboxMessage, int64, error)
+	FindByID(id uint64) (*domain.OutboxMessage, error)
+	Retry(id uint64) (bool, error)
+	FindByUser(userID uint64, unreadOnly bool, limit int, offset int) ([]domain.Notification, int64, error)
+	CountUnread(userID uint64) (int64, error)
+	MarkRead(userID uint64, id uint64) (*domain.Notification, error)
+	MarkAllRead(userID uint64) (int64, error)
+	FindPreferences(userID uint64) (map[string]string, error)
+	SavePreferences(userID uint64, modes map[string]string) error
+	FindPendingDigests(before time.Time) ([]domain.DigestNotification, error)
+	CreateDigest(message *domain.OutboxMessage, notificationIDs []uint64) error
+}
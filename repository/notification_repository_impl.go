@@ -0,0 +1,360 @@
+package repository
+
+import (
+	"fmt"
+	"manajemen_tugas_master/model/domain"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db}
+}
+
+func (n *notificationRepository) Create(messages []*domain.OutboxMessage) error {
+	return n.db.Transaction(func(tx *gorm.DB) error {
+		return createOutboxMessages(tx, messages)
+	})
+}
+
+// createOutboxMessages mencatat notifikasi pada outbox beserta notifikasi in-app untuk penerima yang terdaftar,
+// dipanggil dengan transaksi perubahan yang memicunya agar notifikasi hanya tercatat jika perubahan tersimpan.
+// Penerima hanya dikirimi email atau notifikasi in-app sesuai preferensinya untuk jenis event pesan tersebut
+func createOutboxMessages(tx *gorm.DB, messages []*domain.OutboxMessage) error {
+	notifications, err := applyNotificationPreferences(tx, messages)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var pending []*domain.OutboxMessage
+	for _, message := range messages {
+		if message == nil || len(message.Recipients) == 0 {
+			continue
+		}
+		if message.Channel == "" {
+			message.Channel = domain.OutboxChannelEmail
+		}
+		message.Status = domain.OutboxStatusPending
+		message.NextAttemptAt = now
+		pending = append(pending, message)
+	}
+	if len(pending) > 0 {
+		if err := tx.Create(&pending).Error; err != nil {
+			return fmt.Errorf("Failed to create notifications: %v", err)
+		}
+	}
+	if len(notifications) > 0 {
+		if err := tx.Create(&notifications).Error; err != nil {
+			return fmt.Errorf("Failed to create notifications: %v", err)
+		}
+	}
+	return nil
+}
+
+// applyNotificationPreferences menyusun notifikasi in-app untuk penerima yang memiliki akun dan menyisakan penerima
+// email yang memilih instant. Email yang tidak terdaftar sebagai user selalu dikirimi email. Pesan tanpa template
+// atau tanpa jenis event (misalnya email digest) dikirim apa adanya tanpa notifikasi in-app
+func applyNotificationPreferences(tx *gorm.DB, messages []*domain.OutboxMessage) ([]*domain.Notification, error) {
+	var emails []string
+	for _, message := range messages {
+		if message != nil && message.Template != "" && domain.NotificationEventType(message.Event) != "" {
+			emails = append(emails, message.Recipients...)
+		}
+	}
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	var users []domain.User
+	if err := tx.Select("id", "email").Where("email IN ?", emails).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	userIDs := make(map[string]uint64, len(users))
+	ids := make([]uint64, 0, len(users))
+	for _, user := range users {
+		userIDs[strings.ToLower(user.Email)] = user.ID
+		ids = append(ids, user.ID)
+	}
+	modes, err := findNotificationModes(tx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var notifications []*domain.Notification
+	for _, message := range messages {
+		eventType := ""
+		if message != nil && message.Template != "" {
+			eventType = domain.NotificationEventType(message.Event)
+		}
+		if eventType == "" {
+			continue
+		}
+
+		emailRecipients := make([]string, 0, len(message.Recipients))
+		notified := make(map[uint64]bool, len(message.Recipients))
+		for _, recipient := range message.Recipients {
+			userID, ok := userIDs[strings.ToLower(recipient)]
+			if !ok {
+				emailRecipients = append(emailRecipients, recipient)
+				continue
+			}
+			if notified[userID] {
+				continue
+			}
+			notified[userID] = true
+
+			mode := domain.DefaultNotificationMode
+			if userModes, ok := modes[userID]; ok && userModes[eventType] != "" {
+				mode = userModes[eventType]
+			}
+			if mode == domain.NotificationModeNone {
+				continue
+			}
+			if mode == domain.NotificationModeInstant {
+				emailRecipients = append(emailRecipients, recipient)
+			}
+			notifications = append(notifications, &domain.Notification{
+				UserID:   userID,
+				TaskID:   message.TaskID,
+				Event:    message.Event,
+				Template: message.Template,
+				Data:     message.Data,
+				Digest:   mode == domain.NotificationModeDigest,
+			})
+		}
+		message.Recipients = emailRecipients
+	}
+	return notifications, nil
+}
+
+// findNotificationModes mengembalikan preferensi notifikasi per user dan jenis event
+func findNotificationModes(db *gorm.DB, userIDs []uint64) (map[uint64]map[string]string, error) {
+	modes := make(map[uint64]map[string]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return modes, nil
+	}
+	var preferences []domain.NotificationPreference
+	if err := db.Where("user_id IN ?", userIDs).Find(&preferences).Error; err != nil {
+		return nil, err
+	}
+	for _, preference := range preferences {
+		if modes[preference.UserID] == nil {
+			modes[preference.UserID] = make(map[string]string)
+		}
+		modes[preference.UserID][preference.EventType] = preference.Mode
+	}
+	return modes, nil
+}
+
+// ClaimDue mengambil pesan yang sudah waktunya dikirim dan menandainya sending sampai lease habis. Pesan sending yang
+// lease-nya habis (worker berhenti di tengah pengiriman) ikut diambil lagi. SKIP LOCKED membuat beberapa instance
+// tidak mengambil pesan yang sama
+func (n *notificationRepository) ClaimDue(limit int, lease time.Duration) ([]domain.OutboxMessage, error) {
+	var messages []domain.OutboxMessage
+	err := n.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ? AND next_attempt_at <= ?", []string{domain.OutboxStatusPending, domain.OutboxStatusSending}, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&messages).Error
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+
+		ids := make([]uint64, 0, len(messages))
+		for i := range messages {
+			ids = append(ids, messages[i].ID)
+			messages[i].Status = domain.OutboxStatusSending
+			messages[i].Attempts++
+		}
+		return tx.Model(&domain.OutboxMessage{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":          domain.OutboxStatusSending,
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": now.Add(lease),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (n *notificationRepository) MarkSent(id uint64) error {
+	now := time.Now()
+	return n.db.Model(&domain.OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     domain.OutboxStatusSent,
+		"sent_at":    &now,
+		"last_error": "",
+	}).Error
+}
+
+func (n *notificationRepository) MarkFailed(id uint64, status string, nextAttemptAt time.Time, lastError string, recipients []string) error {
+	return n.db.Model(&domain.OutboxMessage{ID: id}).Select("status", "next_attempt_at", "last_error", "recipients", "updated_at").Updates(&domain.OutboxMessage{
+		Status:        status,
+		NextAttemptAt: nextAttemptAt,
+		LastError:     lastError,
+		Recipients:    recipients,
+	}).Error
+}
+
+func (n *notificationRepository) FindAll(status string, limit int, offset int) ([]domain.OutboxMessage, int64, error) {
+	query := n.db.Model(&domain.OutboxMessage{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var messages []domain.OutboxMessage
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&messages).Error; err != nil {
+		return nil, 0, err
+	}
+	return messages, total, nil
+}
+
+func (n *notificationRepository) FindByID(id uint64) (*domain.OutboxMessage, error) {
+	var message domain.OutboxMessage
+	if err := n.db.First(&message, id).Error; err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// Retry menjadwalkan ulang pesan dead atau pending saat itu juga dengan jumlah percobaan dari nol.
+// Mengembalikan false jika pesan sudah terkirim atau sedang dikirim
+func (n *notificationRepository) Retry(id uint64) (bool, error) {
+	result := n.db.Model(&domain.OutboxMessage{}).
+		Where("id = ? AND status IN ?", id, []string{domain.OutboxStatusDead, domain.OutboxStatusPending}).
+		Updates(map[string]interface{}{
+			"status":          domain.OutboxStatusPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (n *notificationRepository) FindByUser(userID uint64, unreadOnly bool, limit int, offset int) ([]domain.Notification, int64, error) {
+	query := n.db.Model(&domain.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notifications []domain.Notification
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&notifications).Error; err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+func (n *notificationRepository) CountUnread(userID uint64) (int64, error) {
+	var count int64
+	err := n.db.Model(&domain.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+// MarkRead menandai notifikasi milik user sebagai sudah dibaca, notifikasi yang sudah dibaca tidak berubah waktunya
+func (n *notificationRepository) MarkRead(userID uint64, id uint64) (*domain.Notification, error) {
+	var notification domain.Notification
+	if err := n.db.Where("id = ? AND user_id = ?", id, userID).First(&notification).Error; err != nil {
+		return nil, err
+	}
+	if notification.ReadAt != nil {
+		return &notification, nil
+	}
+
+	now := time.Now()
+	if err := n.db.Model(&notification).Update("read_at", &now).Error; err != nil {
+		return nil, err
+	}
+	notification.ReadAt = &now
+	return &notification, nil
+}
+
+func (n *notificationRepository) MarkAllRead(userID uint64) (int64, error) {
+	result := n.db.Model(&domain.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Update("read_at", time.Now())
+	return result.RowsAffected, result.Error
+}
+
+func (n *notificationRepository) FindPreferences(userID uint64) (map[string]string, error) {
+	modes, err := findNotificationModes(n.db, []uint64{userID})
+	if err != nil {
+		return nil, err
+	}
+	if modes[userID] == nil {
+		return map[string]string{}, nil
+	}
+	return modes[userID], nil
+}
+
+// SavePreferences menyimpan preferensi user per jenis event dalam satu transaksi. Jenis event yang dikembalikan ke
+// default dihapus sehingga hanya preferensi yang berbeda dari default yang tersimpan
+func (n *notificationRepository) SavePreferences(userID uint64, modes map[string]string) error {
+	return n.db.Transaction(func(tx *gorm.DB) error {
+		for eventType, mode := range modes {
+			if mode == domain.DefaultNotificationMode {
+				if err := tx.Where("user_id = ? AND event_type = ?", userID, eventType).Delete(&domain.NotificationPreference{}).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			preference := &domain.NotificationPreference{UserID: userID, EventType: eventType, Mode: mode}
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}, {Name: "event_type"}},
+				DoUpdates: clause.AssignmentColumns([]string{"mode", "updated_at"}),
+			}).Create(preference).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindPendingDigests mengambil notifikasi digest yang belum dikirim dan dibuat sebelum batas waktu, diurutkan per user
+func (n *notificationRepository) FindPendingDigests(before time.Time) ([]domain.DigestNotification, error) {
+	var notifications []domain.DigestNotification
+	err := n.db.Table("notifications").
+		Select("notifications.*, users.email AS email, users.locale AS locale, boards.name_board AS board_name").
+		Joins("JOIN users ON users.id = notifications.user_id").
+		Joins("LEFT JOIN tasks ON tasks.id = notifications.task_id").
+		Joins("LEFT JOIN boards ON boards.id = tasks.board_id").
+		Where("notifications.digest = ? AND notifications.digested_at IS NULL AND notifications.created_at < ?", true, before).
+		Order("notifications.user_id, notifications.id").
+		Scan(&notifications).Error
+	return notifications, err
+}
+
+// CreateDigest mencatat email digest pada outbox dan menandai notifikasi yang dirangkumnya dalam satu transaksi
+// sehingga setiap notifikasi hanya masuk ke satu email digest
+func (n *notificationRepository) CreateDigest(message *domain.OutboxMessage, notificationIDs []uint64) error {
+	return n.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Notification{}).
+			Where("id IN ? AND digested_at IS NULL", notificationIDs).
+			Update("digested_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		// notifikasi sudah dirangkum oleh instance lain
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		return createOutboxMessages(tx, []*domain.OutboxMessage{message})
+	})
+}
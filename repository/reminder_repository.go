@@ -0,0 +1,9 @@
+package repository
+
+import "manajemen_tugas_master/model/domain"
+
+type ReminderRepository interface {
+	FindOpenDueTasks() ([]domain.Task, error)
+	FindReminders(taskIDs []uint64) ([]domain.DueDateReminder, error)
+	CreateReminder(reminder *domain.DueDateReminder, skipped []*domain.DueDateReminder, message *domain.OutboxMessage) (bool, error)
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"manajemen_tugas_master/model/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository(db *gorm.DB) ReminderRepository {
+	return &reminderRepository{db}
+}
+
+// FindOpenDueTasks mengambil task yang masih memiliki tenggat terbuka: planning yang belum Approved
+// atau project yang belum Done
+func (r *reminderRepository) FindOpenDueTasks() ([]domain.Task, error) {
+	var tasks []domain.Task
+	err := r.db.Select("id", "board_id", "name_task", "planning_due_date", "project_due_date", "planning_status", "project_status").
+		Where("(planning_due_date <> '' AND planning_status <> ?) OR (project_due_date <> '' AND project_status <> ?)", "Approved", "Done").
+		Order("id").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *reminderRepository) FindReminders(taskIDs []uint64) ([]domain.DueDateReminder, error) {
+	var reminders []domain.DueDateReminder
+	if len(taskIDs) == 0 {
+		return reminders, nil
+	}
+	err := r.db.Where("task_id IN ?", taskIDs).Find(&reminders).Error
+	return reminders, err
+}
+
+// CreateReminder mencatat pengingat beserta emailnya pada outbox dalam satu transaksi. Jika pengingat yang sama sudah
+// dicatat (oleh instance lain atau sebelum restart) tidak ada yang dicatat dan hasilnya false
+func (r *reminderRepository) CreateReminder(reminder *domain.DueDateReminder, skipped []*domain.DueDateReminder, message *domain.OutboxMessage) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(reminder)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if len(skipped) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&skipped).Error; err != nil {
+				return err
+			}
+		}
+		created = true
+		return createOutboxMessages(tx, []*domain.OutboxMessage{message})
+	})
+	return created && err == nil, err
+}
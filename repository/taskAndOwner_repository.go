@@ -6,6 +6,10 @@ import (
 	"gorm.io/gorm"
 )
 
+// NotifyFunc menyusun notifikasi dari nama dan anggota task setelah semua perubahan tersimpan, dipanggil di dalam transaksi update.
+// Error dari NotifyFunc membatalkan transaksi
+type NotifyFunc func(recipients domain.TaskRecipients) ([]*domain.OutboxMessage, error)
+
 type TaskAndOwnerRepository interface {
 	Create(user *domain.User, task *domain.Task, board *domain.Board) (*domain.Task, *domain.Owner, error)
 	FindById(id uint) (*domain.TaskWithInvitation, error)
@@ -16,15 +20,17 @@ type TaskAndOwnerRepository interface {
 	FindAllPlanningFiles() ([]*domain.Task, error)
 	FindAllProjectFiles() ([]*domain.Task, error)
 	GetNameEmailsDescription(taskID uint64) (ownerEmail string, managerEmails []string, employeeEmails []string, nametask string, description string, err error)
-	Update(task *domain.Task, manager *domain.Manager, employee *domain.Employee, PlanningDescriptionFile *domain.PlanningDescriptionFile, planningFile *domain.PlanningFile, projectFile *domain.ProjectFile) (*domain.Task, *domain.Manager, *domain.Employee, *domain.PlanningDescriptionFile, *domain.PlanningFile, *domain.ProjectFile, *domain.Invitation, *domain.Invitation, error)
+	Update(task *domain.Task, manager *domain.Manager, employee *domain.Employee, planningDescriptionFiles []*domain.PlanningDescriptionFile, planningFiles []*domain.PlanningFile, projectFiles []*domain.ProjectFile, uploadedBy uint64, notify NotifyFunc) (*domain.Task, *domain.Manager, *domain.Employee, []*domain.PlanningDescriptionFile, []*domain.PlanningFile, []*domain.ProjectFile, *domain.Invitation, *domain.Invitation, error)
 	UpdateValidationOwner(taskID uint, userID uint) error
 	UpdateValidationManager(taskID uint, userID uint) error
 	UpdateValidationEmployee(taskID uint, userID uint) error
+	ValidationTaskMember(taskID uint, userID uint) error
+	AppendComment(taskID uint64, comment string, notify NotifyFunc) error
 	DeleteManager(taskId uint, managerId uint) (*gorm.DB, int64, int64, int64, error)
 	DeleteEmployee(taskId uint, employeeId uint) (*gorm.DB, int64, error)
-	DeletePlanningDescriptionFile(fileId uint) (*gorm.DB, string, error)
-	DeletePlanningFile(fileId uint) (*gorm.DB, string, error)
-	DeleteProjectFile(fileId uint) (*gorm.DB, string, error)
+	DeletePlanningDescriptionFile(fileId uint) (*gorm.DB, []string, error)
+	DeletePlanningFile(fileId uint) (*gorm.DB, []string, error)
+	DeleteProjectFile(fileId uint) (*gorm.DB, []string, error)
 	Delete(taskID uint) (*gorm.DB, int64, int64, int64, int64, int64, int64, error)
 
 	CreateInvitation(invitation *domain.Invitation) (*domain.Invitation, error)
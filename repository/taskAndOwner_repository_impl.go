@@ -39,9 +39,10 @@ func (t *taskAndOwnerRepository) Create(user *domain.User, task *domain.Task, bo
 
 func (t *taskAndOwnerRepository) FindById(id uint) (*domain.TaskWithInvitation, error) {
 	var task domain.Task
-	if err := t.db.Preload("Owner").Preload("Manager").Preload("Employee").Preload("PlanningFile").Preload("ProjectFile").Preload("Board").First(&task, id).Error; err != nil {
+	if err := t.db.Preload("Owner").Preload("Manager").Preload("Employee").Preload("PlanningDescriptionFile").Preload("PlanningFile").Preload("ProjectFile").Preload("Board").Preload("Escalations").First(&task, id).Error; err != nil {
 		return nil, err
 	}
+	setTaskFileDetails(t.db, &task)
 
 	taskWithInvitation := &domain.TaskWithInvitation{Task: task}
 
@@ -73,11 +74,12 @@ func (t *taskAndOwnerRepository) FindAll() ([]*domain.TaskWithInvitation, error)
 	var tasksWithInvitation []*domain.TaskWithInvitation
 
 	// Fetch all tasks with their relations, including Board
-	if err := t.db.Preload("Owner").Preload("Manager").Preload("Employee").Preload("PlanningFile").Preload("ProjectFile").Preload("Board").Find(&tasks).Error; err != nil {
+	if err := t.db.Preload("Owner").Preload("Manager").Preload("Employee").Preload("PlanningDescriptionFile").Preload("PlanningFile").Preload("ProjectFile").Preload("Board").Preload("Escalations").Find(&tasks).Error; err != nil {
 		return nil, errors.New("Task not found")
 	}
 
 	for _, task := range tasks {
+		setTaskFileDetails(t.db, task)
 		taskWithInvitation := &domain.TaskWithInvitation{Task: *task}
 
 		// Fetch and set invitation status for managers
@@ -158,6 +160,9 @@ func (t *taskAndOwnerRepository) FindAllPlanningFiles() ([]*domain.Task, error)
 	if err := t.db.Preload("PlanningFile").Find(&tasks).Error; err != nil {
 		return nil, errors.New("Failed to find tasks")
 	}
+	for _, task := range tasks {
+		setTaskFileDetails(t.db, task)
+	}
 
 	return tasks, nil
 }
@@ -169,6 +174,9 @@ func (t *taskAndOwnerRepository) FindAllProjectFiles() ([]*domain.Task, error) {
 	if err := t.db.Preload("ProjectFile").Find(&tasks).Error; err != nil {
 		return nil, errors.New("Failed to find tasks")
 	}
+	for _, task := range tasks {
+		setTaskFileDetails(t.db, task)
+	}
 
 	return tasks, nil
 }
@@ -198,6 +206,23 @@ func (t *taskAndOwnerRepository) GetNameEmailsDescription(taskID uint64) (ownerE
 	return ownerEmail, managerEmails, employeeEmails, description, nametask, nil
 }
 
+// findTaskRecipients membaca nama task dan email anggotanya dengan koneksi atau transaksi yang diberikan
+func findTaskRecipients(db *gorm.DB, taskID uint64) (domain.TaskRecipients, error) {
+	var task domain.Task
+	if err := db.Preload("Owner").Preload("Manager").Preload("Employee").First(&task, taskID).Error; err != nil {
+		return domain.TaskRecipients{}, err
+	}
+
+	recipients := domain.TaskRecipients{TaskName: task.NameTask, OwnerEmail: task.Owner.Email}
+	for _, manager := range task.Manager {
+		recipients.ManagerEmails = append(recipients.ManagerEmails, manager.Email)
+	}
+	for _, employee := range task.Employee {
+		recipients.EmployeeEmails = append(recipients.EmployeeEmails, employee.Email)
+	}
+	return recipients, nil
+}
+
 func (t *taskAndOwnerRepository) CreateInvitation(invitation *domain.Invitation) (*domain.Invitation, error) {
 	if err := t.db.Create(invitation).Error; err != nil {
 		return nil, err
@@ -233,254 +258,307 @@ func (t *taskAndOwnerRepository) GetAllInvitations() ([]domain.Invitation, error
 	return invitations, nil
 }
 
-func (t *taskAndOwnerRepository) Update(task *domain.Task, manager *domain.Manager, employee *domain.Employee, PlanningDescriptionFile *domain.PlanningDescriptionFile, planningFile *domain.PlanningFile, projectFile *domain.ProjectFile) (*domain.Task, *domain.Manager, *domain.Employee, *domain.PlanningDescriptionFile, *domain.PlanningFile, *domain.ProjectFile, *domain.Invitation, *domain.Invitation, error) {
-	// Ambil task yang ada dari database
-	existingTask := &domain.Task{}
-	if err := t.db.First(existingTask, task.ID).Error; err != nil {
-		return nil, nil, nil, nil, nil, nil, nil, nil, err
-	}
-
-	// Update hanya field yang tidak kosong
-	updates := make(map[string]interface{})
-	if task.NameTask != "" {
-		updates["name_task"] = task.NameTask
-	}
-	if task.PlanningStatus != "" {
-		updates["planning_status"] = task.PlanningStatus
-	}
-	if task.ProjectStatus != "" {
-		updates["project_status"] = task.ProjectStatus
-	}
-	if task.PlanningDueDate != "" {
-		updates["planning_due_date"] = task.PlanningDueDate
-	}
-	if task.ProjectDueDate != "" {
-		updates["project_due_date"] = task.ProjectDueDate
-	}
-	if task.Priority != "" {
-		updates["priority"] = task.Priority
-	}
-	if task.ProjectComment != "" {
-		updates["project_comment"] = task.ProjectComment
-	}
-	if task.PlanningDescriptionPersen != "" {
-		updates["planning_description_persen"] = task.PlanningDescriptionPersen
-	}
-
-	// Jika ada update, lakukan update
-	if len(updates) > 0 {
-		if err := t.db.Model(existingTask).Updates(updates).Error; err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, nil, err
-		}
-	}
-
+func (t *taskAndOwnerRepository) Update(task *domain.Task, manager *domain.Manager, employee *domain.Employee, planningDescriptionFiles []*domain.PlanningDescriptionFile, planningFiles []*domain.PlanningFile, projectFiles []*domain.ProjectFile, uploadedBy uint64, notify NotifyFunc) (*domain.Task, *domain.Manager, *domain.Employee, []*domain.PlanningDescriptionFile, []*domain.PlanningFile, []*domain.ProjectFile, *domain.Invitation, *domain.Invitation, error) {
 	var managerInvitation, employeeInvitation *domain.Invitation
 
-	// Simpan manager
-	if manager != nil && (manager.Email != "") {
-		var user domain.User
-		if err := t.db.First(&user, "email = ?", manager.Email).Error; err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, nil, errors.New("User not found")
+	// semua perubahan pada satu request disimpan dalam satu transaksi, sehingga tidak ada perubahan atau file yang tersimpan sebagian,
+	// bersama notifikasinya sehingga notifikasi hanya tercatat pada outbox jika perubahannya tersimpan
+	err := t.db.Transaction(func(tx *gorm.DB) error {
+		// Ambil task yang ada dari database
+		existingTask := &domain.Task{}
+		if err := tx.First(existingTask, task.ID).Error; err != nil {
+			return err
 		}
 
-		// Cek apakah sudah ada undangan yang pending untuk user ini
-		var countPendingInvitation int64
-		err := t.db.Model(&domain.Invitation{}).
-			Where("user_id = ? AND task_id = ? AND role = ? AND status = ?", user.ID, task.ID, "manager", "pending").
-			Count(&countPendingInvitation).Error
-		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, nil, err
+		// Update hanya field yang tidak kosong
+		updates := make(map[string]interface{})
+		if task.NameTask != "" {
+			updates["name_task"] = task.NameTask
 		}
-		if countPendingInvitation > 0 {
-			return nil, nil, nil, nil, nil, nil, nil, nil, errors.New("Invitation already sent to this user")
+		if task.PlanningStatus != "" {
+			updates["planning_status"] = task.PlanningStatus
 		}
-
-		// Buat undangan baru
-		invitation := &domain.Invitation{
-			TaskID: task.ID,
-			UserID: user.ID,
-			Role:   "manager",
-			Status: "pending",
+		if task.ProjectStatus != "" {
+			updates["project_status"] = task.ProjectStatus
 		}
-		createdInvitation, err := t.CreateInvitation(invitation)
-		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, nil, errors.New("Failed to create invitation")
+		if task.PlanningDueDate != "" {
+			updates["planning_due_date"] = task.PlanningDueDate
 		}
-		managerInvitation = createdInvitation
-
-		// validasi agar ada tidak ada user yang sama pada manager
-		var countManager int64
-		err = t.db.Model(&domain.Manager{}).
-			Where("user_id = ?", user.ID).                                         // Filter by user_id
-			Joins("JOIN task_managers ON task_managers.manager_id = managers.id"). // Join with task_managers
-			Where("task_managers.task_id = ?", task.ID).                           // Filter by task_id
-			Count(&countManager).Error
-		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, nil, err
+		if task.ProjectDueDate != "" {
+			updates["project_due_date"] = task.ProjectDueDate
 		}
-		if countManager > 0 {
-			return nil, nil, nil, nil, nil, nil, nil, nil, errors.New("User is already assigned as manager to a task")
+		if task.Priority != "" {
+			updates["priority"] = task.Priority
+		}
+		if task.ProjectComment != "" {
+			updates["project_comment"] = task.ProjectComment
+		}
+		if task.PlanningDescriptionPersen != "" {
+			updates["planning_description_persen"] = task.PlanningDescriptionPersen
 		}
 
-		// validasi agar user yang telah menjadi employee tidak bisa menjadi manager lagi pada task yang sama.
-		var countEmployee int64
-		err = t.db.Model(&domain.Employee{}).
-			Where("user_id = ?", user.ID).                                             // Filter by user_id
-			Joins("JOIN task_employees ON task_employees.employee_id = employees.id"). // Join with task_employees
-			Where("task_employees.task_id = ?", task.ID).                              // Filter by task_id
-			Count(&countEmployee).Error
-		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, nil, err
+		// Jika ada update, lakukan update
+		if len(updates) > 0 {
+			if err := tx.Model(existingTask).Updates(updates).Error; err != nil {
+				return err
+			}
 		}
-		if countEmployee > 0 {
-			return nil, nil, nil, nil, nil, nil, nil, nil, errors.New("User is already assigned as employee to a task")
-		} else {
-			// jika kedua validasi tersebut berhasil masukkan data ke table penghubung
-			manager.UserID = user.ID
-			if err := t.db.Save(manager).Error; err != nil {
-				return nil, nil, nil, nil, nil, nil, nil, nil, errors.New("Failed to save manager data")
+
+		// Simpan manager
+		if manager != nil && (manager.Email != "") {
+			var user domain.User
+			if err := tx.First(&user, "email = ?", manager.Email).Error; err != nil {
+				return errors.New("User not found")
+			}
+
+			// Cek apakah sudah ada undangan yang pending untuk user ini
+			var countPendingInvitation int64
+			err := tx.Model(&domain.Invitation{}).
+				Where("user_id = ? AND task_id = ? AND role = ? AND status = ?", user.ID, task.ID, "manager", "pending").
+				Count(&countPendingInvitation).Error
+			if err != nil {
+				return err
 			}
-			sqlQuery := "INSERT INTO task_managers (task_id, manager_id) VALUES (?, ?)"
-			if err := t.db.Exec(sqlQuery, task.ID, manager.ID).Error; err != nil {
-				return nil, nil, nil, nil, nil, nil, nil, nil, err
+			if countPendingInvitation > 0 {
+				return errors.New("Invitation already sent to this user")
 			}
-		}
-	}
 
-	// Simpan employee
-	if employee != nil && (employee.Email != "") {
-		var user domain.User
-		if err := t.db.First(&user, "email = ?", employee.Email).Error; err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, nil, errors.New("User not found")
-		}
+			// Buat undangan baru
+			invitation := &domain.Invitation{
+				TaskID: task.ID,
+				UserID: user.ID,
+				Role:   "manager",
+				Status: "pending",
+			}
+			if err := tx.Create(invitation).Error; err != nil {
+				return errors.New("Failed to create invitation")
+			}
+			managerInvitation = invitation
+
+			// validasi agar ada tidak ada user yang sama pada manager
+			var countManager int64
+			err = tx.Model(&domain.Manager{}).
+				Where("user_id = ?", user.ID).                                         // Filter by user_id
+				Joins("JOIN task_managers ON task_managers.manager_id = managers.id"). // Join with task_managers
+				Where("task_managers.task_id = ?", task.ID).                           // Filter by task_id
+				Count(&countManager).Error
+			if err != nil {
+				return err
+			}
+			if countManager > 0 {
+				return errors.New("User is already assigned as manager to a task")
+			}
 
-		// Cek apakah sudah ada undangan yang pending untuk user ini
-		var countPendingInvitation int64
-		err := t.db.Model(&domain.Invitation{}).
-			Where("user_id = ? AND task_id = ? AND role = ? AND status = ?", user.ID, task.ID, "employee", "pending").
-			Count(&countPendingInvitation).Error
-		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, nil, err
-		}
-		if countPendingInvitation > 0 {
-			return nil, nil, nil, nil, nil, nil, nil, nil, errors.New("Invitation already sent to this user")
+			// validasi agar user yang telah menjadi employee tidak bisa menjadi manager lagi pada task yang sama.
+			var countEmployee int64
+			err = tx.Model(&domain.Employee{}).
+				Where("user_id = ?", user.ID).                                             // Filter by user_id
+				Joins("JOIN task_employees ON task_employees.employee_id = employees.id"). // Join with task_employees
+				Where("task_employees.task_id = ?", task.ID).                              // Filter by task_id
+				Count(&countEmployee).Error
+			if err != nil {
+				return err
+			}
+			if countEmployee > 0 {
+				return errors.New("User is already assigned as employee to a task")
+			} else {
+				// jika kedua validasi tersebut berhasil masukkan data ke table penghubung
+				manager.UserID = user.ID
+				if err := tx.Save(manager).Error; err != nil {
+					return errors.New("Failed to save manager data")
+				}
+				sqlQuery := "INSERT INTO task_managers (task_id, manager_id) VALUES (?, ?)"
+				if err := tx.Exec(sqlQuery, task.ID, manager.ID).Error; err != nil {
+					return err
+				}
+			}
 		}
 
-		// Buat undangan baru
-		invitation := &domain.Invitation{
-			TaskID: task.ID,
-			UserID: user.ID,
-			Role:   "employee",
-			Status: "pending",
-		}
-		createdInvitation, err := t.CreateInvitation(invitation)
-		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, nil, errors.New("Failed to create invitation")
-		}
-		employeeInvitation = createdInvitation
+		// Simpan employee
+		if employee != nil && (employee.Email != "") {
+			var user domain.User
+			if err := tx.First(&user, "email = ?", employee.Email).Error; err != nil {
+				return errors.New("User not found")
+			}
 
-		// validasi agar ada tidak ada user yang sama pada employee
-		var countEmployee int64
-		err = t.db.Model(&domain.Employee{}).
-			Where("user_id = ?", user.ID).                                             // Filter by user_id
-			Joins("JOIN task_employees ON task_employees.employee_id = employees.id"). // Join with task_employees
-			Where("task_employees.task_id = ?", task.ID).                              // Filter by task_id
-			Count(&countEmployee).Error
-		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, nil, err
-		}
-		if countEmployee > 0 {
-			return nil, nil, nil, nil, nil, nil, nil, nil, errors.New("User is already assigned as employee to a task")
-		}
+			// Cek apakah sudah ada undangan yang pending untuk user ini
+			var countPendingInvitation int64
+			err := tx.Model(&domain.Invitation{}).
+				Where("user_id = ? AND task_id = ? AND role = ? AND status = ?", user.ID, task.ID, "employee", "pending").
+				Count(&countPendingInvitation).Error
+			if err != nil {
+				return err
+			}
+			if countPendingInvitation > 0 {
+				return errors.New("Invitation already sent to this user")
+			}
 
-		// validasi agar user yang telah menjadi manager tidak bisa menjadi employee lagi pada task yang sama.
-		var countManager int64
-		err = t.db.Model(&domain.Manager{}).
-			Where("user_id = ?", user.ID).                                         // Filter by user_id
-			Joins("JOIN task_managers ON task_managers.manager_id = managers.id"). // Join with task_managers
-			Where("task_managers.task_id = ?", task.ID).                           // Filter by task_id
-			Count(&countManager).Error
-		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, nil, err
-		}
-		if countManager > 0 {
-			return nil, nil, nil, nil, nil, nil, nil, nil, errors.New("User is already assigned as manager to a task")
-		} else {
-			// jika kedua validasi tersebut berhasil masukkan data ke table penghubung
-			employee.UserID = user.ID
-			if err := t.db.Save(employee).Error; err != nil {
-				return nil, nil, nil, nil, nil, nil, nil, nil, errors.New("Failed to save manager data")
+			// Buat undangan baru
+			invitation := &domain.Invitation{
+				TaskID: task.ID,
+				UserID: user.ID,
+				Role:   "employee",
+				Status: "pending",
 			}
-			sqlQuery := "INSERT INTO task_employees (task_id, employee_id) VALUES (?, ?)"
-			if err := t.db.Exec(sqlQuery, task.ID, employee.ID).Error; err != nil {
-				return nil, nil, nil, nil, nil, nil, nil, nil, err
+			if err := tx.Create(invitation).Error; err != nil {
+				return errors.New("Failed to create invitation")
+			}
+			employeeInvitation = invitation
+
+			// validasi agar ada tidak ada user yang sama pada employee
+			var countEmployee int64
+			err = tx.Model(&domain.Employee{}).
+				Where("user_id = ?", user.ID).                                             // Filter by user_id
+				Joins("JOIN task_employees ON task_employees.employee_id = employees.id"). // Join with task_employees
+				Where("task_employees.task_id = ?", task.ID).                              // Filter by task_id
+				Count(&countEmployee).Error
+			if err != nil {
+				return err
+			}
+			if countEmployee > 0 {
+				return errors.New("User is already assigned as employee to a task")
 			}
-		}
-	}
 
-	if PlanningDescriptionFile != nil && (PlanningDescriptionFile.FileUrl != "" || PlanningDescriptionFile.FileName != "") {
-		var count int64
-		if err := t.db.Model(&domain.PlanningDescriptionFile{}).Where("file_url", PlanningDescriptionFile.FileUrl).Count(&count).Error; err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, nil, err
-		}
-		if count > 0 {
-			return nil, nil, nil, nil, nil, nil, nil, nil, errors.New("Planning Description File already exists")
-		}
-		if count == 0 {
-			if err := t.db.Save(PlanningDescriptionFile).Error; err != nil {
-				return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("Failed to upload planning description file: %v", err)
+			// validasi agar user yang telah menjadi manager tidak bisa menjadi employee lagi pada task yang sama.
+			var countManager int64
+			err = tx.Model(&domain.Manager{}).
+				Where("user_id = ?", user.ID).                                         // Filter by user_id
+				Joins("JOIN task_managers ON task_managers.manager_id = managers.id"). // Join with task_managers
+				Where("task_managers.task_id = ?", task.ID).                           // Filter by task_id
+				Count(&countManager).Error
+			if err != nil {
+				return err
 			}
-			// Execute SQL query to add relation in task_planning_description_files
-			sqlQuery := "INSERT INTO task_planning_description_files (task_id, planning_description_file_id) VALUES (?, ?)"
-			if err := t.db.Exec(sqlQuery, task.ID, PlanningDescriptionFile.ID).Error; err != nil {
-				return nil, nil, nil, nil, nil, nil, nil, nil, err
+			if countManager > 0 {
+				return errors.New("User is already assigned as manager to a task")
+			} else {
+				// jika kedua validasi tersebut berhasil masukkan data ke table penghubung
+				employee.UserID = user.ID
+				if err := tx.Save(employee).Error; err != nil {
+					return errors.New("Failed to save manager data")
+				}
+				sqlQuery := "INSERT INTO task_employees (task_id, employee_id) VALUES (?, ?)"
+				if err := tx.Exec(sqlQuery, task.ID, employee.ID).Error; err != nil {
+					return err
+				}
 			}
 		}
-	}
 
-	// Simpan planningFile
-	if planningFile != nil && (planningFile.FileUrl != "" || planningFile.FileName != "") {
-		var count int64
-		if err := t.db.Model(&domain.PlanningFile{}).Where("file_url", planningFile.FileUrl).Count(&count).Error; err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, nil, err
-		}
-		if count > 0 {
-			return nil, nil, nil, nil, nil, nil, nil, nil, errors.New("File already exist")
+		for _, planningDescriptionFile := range planningDescriptionFiles {
+			planningDescriptionFile.TaskID = task.ID
+			planningDescriptionFile.UploadedBy = uploadedBy
+			if err := savePlanningDescriptionFile(tx, task.ID, planningDescriptionFile); err != nil {
+				return err
+			}
 		}
-		if count == 0 {
-			if err := t.db.Save(planningFile).Error; err != nil {
-				return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("Failed to upload file: %v", err)
+
+		for _, planningFile := range planningFiles {
+			fileID, version, err := saveTaskDocument(tx, task.ID, domain.FileTypePlanning, planningFile.FileKey, planningFile.FileName, uploadedBy, func() (uint64, error) {
+				planningFile.TaskID = task.ID
+				planningFile.UploadedBy = uploadedBy
+				planningFile.Version = 1
+				err := tx.Save(planningFile).Error
+				return planningFile.ID, err
+			})
+			if err != nil {
+				return err
 			}
-			// Eksekusi query SQL untuk menambahkan relasi task_project_files
-			sqlQuery := "INSERT INTO task_planning_files (task_id, planning_file_id) VALUES (?, ?)"
-			if err := t.db.Exec(sqlQuery, task.ID, planningFile.ID).Error; err != nil {
-				return nil, nil, nil, nil, nil, nil, nil, nil, err
+			planningFile.ID = fileID
+			planningFile.Version = version
+		}
+
+		for _, projectFile := range projectFiles {
+			fileID, version, err := saveTaskDocument(tx, task.ID, domain.FileTypeProject, projectFile.FileKey, projectFile.FileName, uploadedBy, func() (uint64, error) {
+				projectFile.TaskID = task.ID
+				projectFile.UploadedBy = uploadedBy
+				projectFile.Version = 1
+				err := tx.Save(projectFile).Error
+				return projectFile.ID, err
+			})
+			if err != nil {
+				return err
 			}
+			projectFile.ID = fileID
+			projectFile.Version = version
 		}
-	}
 
-	// Simpan projectFile
-	if projectFile != nil && (projectFile.FileUrl != "" || projectFile.FileName != "") {
-		var count int64
-		if err := t.db.Model(&domain.ProjectFile{}).Where("file_url", projectFile.FileUrl).Count(&count).Error; err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, nil, err
-		}
-		if count > 0 {
-			return nil, nil, nil, nil, nil, nil, nil, nil, errors.New("File already exist")
-		}
-		if count == 0 {
-			if err := t.db.Save(projectFile).Error; err != nil {
-				return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("Failed to upload file: %v", err)
+		if notify != nil {
+			recipients, err := findTaskRecipients(tx, task.ID)
+			if err != nil {
+				return err
 			}
-			// Eksekusi query SQL untuk menambahkan relasi task_project_files
-			sqlQuery := "INSERT INTO task_project_files (task_id, project_file_id) VALUES (?, ?)"
-			if err := t.db.Exec(sqlQuery, task.ID, projectFile.ID).Error; err != nil {
-				return nil, nil, nil, nil, nil, nil, nil, nil, err
+			messages, err := notify(recipients)
+			if err != nil {
+				return err
 			}
+			if err := createOutboxMessages(tx, messages); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, err
+	}
+
+	return task, manager, employee, planningDescriptionFiles, planningFiles, projectFiles, managerInvitation, employeeInvitation, nil
+}
+
+func savePlanningDescriptionFile(tx *gorm.DB, taskID uint64, planningDescriptionFile *domain.PlanningDescriptionFile) error {
+	var count int64
+	// file dengan isi yang sama boleh dipakai task lain, tetapi tidak boleh dilampirkan dua kali pada task yang sama
+	if err := tx.Model(&domain.PlanningDescriptionFile{}).
+		Joins("JOIN task_planning_description_files ON task_planning_description_files.planning_description_file_id = planning_description_files.id").
+		Where("task_planning_description_files.task_id = ? AND planning_description_files.file_key = ?", taskID, planningDescriptionFile.FileKey).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("Planning Description File already exists: %s", planningDescriptionFile.FileName)
+	}
+
+	if err := tx.Save(planningDescriptionFile).Error; err != nil {
+		return fmt.Errorf("Failed to upload planning description file: %v", err)
+	}
+	// Execute SQL query to add relation in task_planning_description_files
+	sqlQuery := "INSERT INTO task_planning_description_files (task_id, planning_description_file_id) VALUES (?, ?)"
+	return tx.Exec(sqlQuery, taskID, planningDescriptionFile.ID).Error
+}
+
+// saveTaskDocument menyimpan planning file atau project file pada task dan mengembalikan id serta nomor versinya.
+// Upload ulang dengan nama file yang sama pada task menjadi versi baru dari file tersebut, selain itu record baru dibuat oleh create
+func saveTaskDocument(tx *gorm.DB, taskID uint64, fileType string, fileKey string, fileName string, uploadedBy uint64, create func() (uint64, error)) (uint64, int, error) {
+	existingID, existingKey, err := findTaskDocument(tx, taskID, fileType, fileName)
+	if err != nil {
+		return 0, 0, err
+	}
+	if existingID != 0 && existingKey == fileKey {
+		return 0, 0, fmt.Errorf("File already exist: %s", fileName)
+	}
+	if existingID != 0 {
+		version, err := addFileVersion(tx, fileType, existingID, fileKey, fileName, uploadedBy)
+		if err != nil {
+			return 0, 0, fmt.Errorf("Failed to upload file: %v", err)
 		}
+		return existingID, version.Version, nil
 	}
 
-	return task, manager, employee, PlanningDescriptionFile, planningFile, projectFile, managerInvitation, employeeInvitation, nil
+	fileID, err := create()
+	if err != nil {
+		return 0, 0, fmt.Errorf("Failed to upload file: %v", err)
+	}
+	// Eksekusi query SQL untuk menambahkan relasi task dengan file
+	tables := taskFileTables[fileType]
+	sqlQuery := "INSERT INTO " + tables.joinTable + " (task_id, " + tables.joinKey + ") VALUES (?, ?)"
+	if err := tx.Exec(sqlQuery, taskID, fileID).Error; err != nil {
+		return 0, 0, err
+	}
+	if err := createFileVersion(tx, &domain.FileVersion{FileType: fileType, FileID: fileID, Version: 1, FileKey: fileKey, FileName: fileName, UploadedBy: uploadedBy}); err != nil {
+		return 0, 0, err
+	}
+
+	return fileID, 1, nil
 }
 
 func (t *taskAndOwnerRepository) UpdateValidationOwner(taskID uint, userID uint) error {
@@ -535,6 +613,50 @@ func (t *taskAndOwnerRepository) UpdateValidationEmployee(taskID uint, userID ui
 	return nil
 }
 
+func (t *taskAndOwnerRepository) ValidationTaskMember(taskID uint, userID uint) error {
+	if err := t.UpdateValidationOwner(taskID, userID); err == nil {
+		return nil
+	}
+	if err := t.UpdateValidationManager(taskID, userID); err == nil {
+		return nil
+	}
+	if err := t.UpdateValidationEmployee(taskID, userID); err == nil {
+		return nil
+	}
+
+	return errors.New("Only for task members")
+}
+
+// AppendComment menambahkan komentar di bawah komentar project yang sudah ada, dipisahkan baris kosong, dan mencatat
+// notifikasinya pada outbox dalam transaksi yang sama
+func (t *taskAndOwnerRepository) AppendComment(taskID uint64, comment string, notify NotifyFunc) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Task{}).Where("id = ?", taskID).Update("project_comment",
+			gorm.Expr("CASE WHEN project_comment IS NULL OR project_comment = '' THEN ? ELSE CONCAT(project_comment, ?, ?) END", comment, "\n\n", comment))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if notify != nil {
+			recipients, err := findTaskRecipients(tx, taskID)
+			if err != nil {
+				return err
+			}
+			messages, err := notify(recipients)
+			if err != nil {
+				return err
+			}
+			if err := createOutboxMessages(tx, messages); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (t *taskAndOwnerRepository) DeleteManager(taskId uint, managerId uint) (*gorm.DB, int64, int64, int64, error) {
 	var manager domain.Manager
 	if err := t.db.First(&manager, managerId).Error; err != nil {
@@ -622,6 +744,9 @@ func (t *taskAndOwnerRepository) DeleteManager(taskId uint, managerId uint) (*go
 				return nil, 0, 0, 0, err
 			}
 
+			if _, err := deleteFileVersions(t.db, domain.FileTypePlanning, taskPlanningFileIDs); err != nil {
+				return nil, 0, 0, 0, err
+			}
 			planningFileDelete := "DELETE FROM planning_files WHERE id IN (?)"
 			if err := t.db.Exec(planningFileDelete, taskPlanningFileIDs).Error; err != nil {
 				return nil, 0, 0, 0, err
@@ -655,6 +780,9 @@ func (t *taskAndOwnerRepository) DeleteManager(taskId uint, managerId uint) (*go
 			}
 
 			// Hapus project_files menggunakan klausa IN
+			if _, err := deleteFileVersions(t.db, domain.FileTypeProject, taskProjectFileIDs); err != nil {
+				return nil, 0, 0, 0, err
+			}
 			projectFileDelete := "DELETE FROM project_files WHERE id IN (?)"
 			if err := t.db.Exec(projectFileDelete, taskProjectFileIDs).Error; err != nil {
 				return nil, 0, 0, 0, err
@@ -725,6 +853,9 @@ func (t *taskAndOwnerRepository) DeleteEmployee(taskId uint, employeeId uint) (*
 			}
 
 			// Hapus project_files menggunakan klausa IN
+			if _, err := deleteFileVersions(t.db, domain.FileTypeProject, taskProjectFileIDs); err != nil {
+				return nil, 0, err
+			}
 			projectFileDelete := "DELETE FROM project_files WHERE id IN (?)"
 			if err := t.db.Exec(projectFileDelete, taskProjectFileIDs).Error; err != nil {
 				return nil, 0, err
@@ -736,78 +867,88 @@ func (t *taskAndOwnerRepository) DeleteEmployee(taskId uint, employeeId uint) (*
 	return t.db, countProjectFile, nil
 }
 
-func (t *taskAndOwnerRepository) DeletePlanningDescriptionFile(fileId uint) (*gorm.DB, string, error) {
+func (t *taskAndOwnerRepository) DeletePlanningDescriptionFile(fileId uint) (*gorm.DB, []string, error) {
 	var planningDescriptionFile domain.PlanningDescriptionFile
 	if err := t.db.First(&planningDescriptionFile, fileId).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, "", errors.New("file not found")
+			return nil, nil, errors.New("file not found")
 		}
-		return nil, "", fmt.Errorf("failed to find file: %v", err)
+		return nil, nil, fmt.Errorf("failed to find file: %v", err)
 	}
 
-	// mengambil file name
-	var fileName string
-	fileName = planningDescriptionFile.FileName
-
 	sqlQuery := "DELETE FROM task_planning_description_files WHERE planning_description_file_id = ?"
 	if err := t.db.Exec(sqlQuery, planningDescriptionFile.ID).Error; err != nil {
-		return nil, "", err
+		return nil, nil, err
+	}
+
+	// key objek dari file dan seluruh versinya, referensinya dilepas setelah record dihapus
+	fileKeys, err := deleteFileVersions(t.db, domain.FileTypePlanningDescription, []uint64{planningDescriptionFile.ID})
+	if err != nil {
+		return nil, nil, err
 	}
+	fileKeys = append(fileKeys, planningDescriptionFile.FileKey)
 
 	if err := t.db.Delete(&planningDescriptionFile).Error; err != nil {
-		return nil, "", fmt.Errorf("failed to delete file: %v", err)
+		return nil, nil, fmt.Errorf("failed to delete file: %v", err)
 	}
 
-	return t.db, fileName, nil
+	return t.db, fileKeys, nil
 }
 
-func (t *taskAndOwnerRepository) DeletePlanningFile(fileId uint) (*gorm.DB, string, error) {
+func (t *taskAndOwnerRepository) DeletePlanningFile(fileId uint) (*gorm.DB, []string, error) {
 	var planningFile domain.PlanningFile
 	if err := t.db.First(&planningFile, fileId).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, "", errors.New("file not found")
+			return nil, nil, errors.New("file not found")
 		}
-		return nil, "", fmt.Errorf("failed to find file: %v", err)
+		return nil, nil, fmt.Errorf("failed to find file: %v", err)
 	}
 
-	// mengambil file name
-	var fileName string
-	fileName = planningFile.FileName
-
 	sqlQuery := "DELETE FROM task_planning_files WHERE planning_file_id = ?"
 	if err := t.db.Exec(sqlQuery, planningFile.ID).Error; err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
+	// key objek dari file dan seluruh versinya, referensinya dilepas setelah record dihapus
+	fileKeys, err := deleteFileVersions(t.db, domain.FileTypePlanning, []uint64{planningFile.ID})
+	if err != nil {
+		return nil, nil, err
+	}
+	fileKeys = append(fileKeys, planningFile.FileKey)
+
 	if err := t.db.Delete(&planningFile).Error; err != nil {
-		return nil, "", fmt.Errorf("failed to delete file: %v", err)
+		return nil, nil, fmt.Errorf("failed to delete file: %v", err)
 	}
 
-	return t.db, fileName, nil
+	return t.db, fileKeys, nil
 }
 
-func (t *taskAndOwnerRepository) DeleteProjectFile(fileId uint) (*gorm.DB, string, error) {
+func (t *taskAndOwnerRepository) DeleteProjectFile(fileId uint) (*gorm.DB, []string, error) {
 	var projectFile domain.ProjectFile
 	if err := t.db.First(&projectFile, fileId).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, "", errors.New("file not found")
+			return nil, nil, errors.New("file not found")
 		}
-		return nil, "", fmt.Errorf("failed to find file: %v", err)
+		return nil, nil, fmt.Errorf("failed to find file: %v", err)
 	}
 
-	var fileName string
-	fileName = projectFile.FileName
-
 	sqlQuery := "DELETE FROM task_project_files WHERE project_file_id = ?"
 	if err := t.db.Exec(sqlQuery, projectFile.ID).Error; err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
+	// key objek dari file dan seluruh versinya, referensinya dilepas setelah record dihapus
+	fileKeys, err := deleteFileVersions(t.db, domain.FileTypeProject, []uint64{projectFile.ID})
+	if err != nil {
+		return nil, nil, err
+	}
+	fileKeys = append(fileKeys, projectFile.FileKey)
+
 	if err := t.db.Delete(&projectFile).Error; err != nil {
-		return nil, "", fmt.Errorf("failed to delete file: %v", err)
+		return nil, nil, fmt.Errorf("failed to delete file: %v", err)
 	}
 
-	return t.db, fileName, nil
+	return t.db, fileKeys, nil
 }
 
 func (t *taskAndOwnerRepository) Delete(taskID uint) (*gorm.DB, int64, int64, int64, int64, int64, int64, error) {
@@ -829,6 +970,24 @@ func (t *taskAndOwnerRepository) Delete(taskID uint) (*gorm.DB, int64, int64, in
 		countPlanningDescriptionFile int64
 	)
 
+	// Ambil id relasi sebelum referensi di tabel penghubung dihapus
+	var taskManagerIDs, taskEmployeeIDs, taskPlanningFileIDs, taskProjectFileIDs, taskPlanningDescriptionFileIDs []uint
+	if err := t.db.Table("task_managers").Where("task_id = ?", taskID).Pluck("manager_id", &taskManagerIDs).Error; err != nil {
+		return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to retrieve task manager IDs: %v", err)
+	}
+	if err := t.db.Table("task_employees").Where("task_id = ?", taskID).Pluck("employee_id", &taskEmployeeIDs).Error; err != nil {
+		return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to retrieve task employee IDs: %v", err)
+	}
+	if err := t.db.Table("task_planning_files").Where("task_id = ?", taskID).Pluck("planning_file_id", &taskPlanningFileIDs).Error; err != nil {
+		return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to retrieve task planning files IDs: %v", err)
+	}
+	if err := t.db.Table("task_project_files").Where("task_id = ?", taskID).Pluck("project_file_id", &taskProjectFileIDs).Error; err != nil {
+		return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to retrieve task project files IDs: %v", err)
+	}
+	if err := t.db.Table("task_planning_description_files").Where("task_id = ?", taskID).Pluck("planning_description_file_id", &taskPlanningDescriptionFileIDs).Error; err != nil {
+		return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to retrieve task planning description files IDs: %v", err)
+	}
+
 	// Hapus referensi di tabel task_employees terlebih dahulu
 	if err := t.db.Exec("DELETE FROM task_employees WHERE task_id = ?", taskID).Error; err != nil {
 		return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("gagal menghapus referensi di task_employees: %v", err)
@@ -854,6 +1013,21 @@ func (t *taskAndOwnerRepository) Delete(taskID uint) (*gorm.DB, int64, int64, in
 		return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("gagal menghapus referensi di task_planning_description_files: %v", err)
 	}
 
+	// Hapus pengingat tenggat agar task baru dengan id yang sama tetap mendapat pengingat
+	if err := t.db.Where("task_id = ?", taskID).Delete(&domain.DueDateReminder{}).Error; err != nil {
+		return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("gagal menghapus pengingat tenggat: %v", err)
+	}
+
+	// Hapus riwayat eskalasi task
+	if err := t.db.Where("task_id = ?", taskID).Delete(&domain.TaskEscalation{}).Error; err != nil {
+		return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("gagal menghapus eskalasi task: %v", err)
+	}
+
+	// Hapus catatan event calendar task, event-nya dibatalkan oleh service
+	if err := t.db.Where("task_id = ?", taskID).Delete(&domain.TaskCalendarEvent{}).Error; err != nil {
+		return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("gagal menghapus event calendar task: %v", err)
+	}
+
 	// Validasi owners
 	var ownerIDs []uint64
 	rows, err := t.db.Table("tasks").Select("tasks.owner_id").Joins("INNER JOIN owners ON owners.id = tasks.owner_id").Where("tasks.id = ?", taskID).Rows()
@@ -883,21 +1057,6 @@ func (t *taskAndOwnerRepository) Delete(taskID uint) (*gorm.DB, int64, int64, in
 	}
 
 	// Validasi manager
-	var taskManagerIDs []uint
-	rows, err = t.db.Raw("SELECT manager_id FROM task_managers WHERE task_id = ?", taskID).Rows()
-	if err != nil {
-		return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to retrieve task manager IDs: %v", err)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var managerID uint64
-		if err := rows.Scan(&managerID); err != nil {
-			return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to read manager ID: %v", err)
-		}
-		taskManagerIDs = append(taskManagerIDs, uint(managerID))
-	}
-
 	if len(taskManagerIDs) > 0 {
 		managersDelete := "DELETE FROM managers WHERE id IN (?)"
 		if err := t.db.Exec(managersDelete, taskManagerIDs).Error; err != nil {
@@ -907,21 +1066,6 @@ func (t *taskAndOwnerRepository) Delete(taskID uint) (*gorm.DB, int64, int64, in
 	}
 
 	// Validasi employee
-	var taskEmployeeIDs []uint
-	rows, err = t.db.Raw("SELECT employee_id FROM task_employees WHERE task_id = ?", taskID).Rows()
-	if err != nil {
-		return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to retrieve task employee IDs: %v", err)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var employeeID uint64
-		if err := rows.Scan(&employeeID); err != nil {
-			return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to read employee ID: %v", err)
-		}
-		taskEmployeeIDs = append(taskEmployeeIDs, uint(employeeID))
-	}
-
 	if len(taskEmployeeIDs) > 0 {
 		employeesDelete := "DELETE FROM employees WHERE id IN (?)"
 		if err := t.db.Exec(employeesDelete, taskEmployeeIDs).Error; err != nil {
@@ -931,22 +1075,10 @@ func (t *taskAndOwnerRepository) Delete(taskID uint) (*gorm.DB, int64, int64, in
 	}
 
 	// validasi PlanningFile
-	var taskPlanningFileIDs []uint
-	rows, err = t.db.Raw("SELECT planning_file_id FROM task_planning_files WHERE task_id = ?", taskID).Rows()
-	if err != nil {
-		return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to retrieve task planning files IDs: %v", err)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var planningFileID uint64
-		if err := rows.Scan(&planningFileID); err != nil {
-			return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to read planning file ID: %v", err)
-		}
-		taskPlanningFileIDs = append(taskPlanningFileIDs, uint(planningFileID))
-	}
-
 	if len(taskPlanningFileIDs) > 0 {
+		if _, err := deleteFileVersions(t.db, domain.FileTypePlanning, taskPlanningFileIDs); err != nil {
+			return nil, 0, 0, 0, 0, 0, 0, err
+		}
 		planningFileDelete := "DELETE FROM planning_files WHERE id IN (?)"
 		if err := t.db.Exec(planningFileDelete, taskPlanningFileIDs).Error; err != nil {
 			return nil, 0, 0, 0, 0, 0, 0, err
@@ -955,22 +1087,10 @@ func (t *taskAndOwnerRepository) Delete(taskID uint) (*gorm.DB, int64, int64, in
 	}
 
 	// validasi ProjectFile
-	var taskProjectFileIDs []uint
-	rows, err = t.db.Raw("SELECT project_file_id FROM task_project_files WHERE task_id = ?", taskID).Rows()
-	if err != nil {
-		return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to retrieve task project files IDs: %v", err)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var projectFileID uint64
-		if err := rows.Scan(&projectFileID); err != nil {
-			return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to read project file ID: %v", err)
-		}
-		taskProjectFileIDs = append(taskProjectFileIDs, uint(projectFileID))
-	}
-
 	if len(taskProjectFileIDs) > 0 {
+		if _, err := deleteFileVersions(t.db, domain.FileTypeProject, taskProjectFileIDs); err != nil {
+			return nil, 0, 0, 0, 0, 0, 0, err
+		}
 		projectFileDelete := "DELETE FROM project_files WHERE id IN (?)"
 		if err := t.db.Exec(projectFileDelete, taskProjectFileIDs).Error; err != nil {
 			return nil, 0, 0, 0, 0, 0, 0, err
@@ -979,21 +1099,6 @@ func (t *taskAndOwnerRepository) Delete(taskID uint) (*gorm.DB, int64, int64, in
 	}
 
 	// validasi PlanningDescriptionFile
-	var taskPlanningDescriptionFileIDs []uint
-	rows, err = t.db.Raw("SELECT planning_description_file_id FROM task_planning_description_files WHERE task_id = ?", taskID).Rows()
-	if err != nil {
-		return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to retrieve task planning description files IDs: %v", err)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var planningDescriptionFileID uint64
-		if err := rows.Scan(&planningDescriptionFileID); err != nil {
-			return nil, 0, 0, 0, 0, 0, 0, fmt.Errorf("failed to read planning description file ID: %v", err)
-		}
-		taskPlanningDescriptionFileIDs = append(taskPlanningDescriptionFileIDs, uint(planningDescriptionFileID))
-	}
-
 	if len(taskPlanningDescriptionFileIDs) > 0 {
 		planningDescriptionFileDelete := "DELETE FROM planning_description_files WHERE id IN (?)"
 		if err := t.db.Exec(planningDescriptionFileDelete, taskPlanningDescriptionFileIDs).Error; err != nil {
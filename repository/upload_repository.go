@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"manajemen_tugas_master/model/domain"
+	"time"
+)
+
+type UploadRepository interface {
+	FindTaskBoardID(taskID uint64) (uint64, error)
+	Create(session *domain.UploadSession) error
+	FindByID(id string) (*domain.UploadSession, error)
+	Update(session *domain.UploadSession) error
+	FindParts(sessionID string) ([]domain.UploadSessionPart, error)
+	AppendPart(session *domain.UploadSession, part *domain.UploadSessionPart) error
+	Claim(ids []string, userID uint64, taskID uint64, fileType string) ([]domain.UploadSession, error)
+	Unclaim(ids []string) error
+	FindExpired(before time.Time) ([]domain.UploadSession, error)
+	Delete(id string, status string) (bool, error)
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"manajemen_tugas_master/model/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrUploadOffsetMismatch = errors.New("Upload offset mismatch")
+	ErrUploadNotAttachable  = errors.New("Upload cannot be attached")
+)
+
+type uploadRepository struct {
+	db *gorm.DB
+}
+
+func NewUploadRepository(db *gorm.DB) UploadRepository {
+	return &uploadRepository{db}
+}
+
+func (u *uploadRepository) FindTaskBoardID(taskID uint64) (uint64, error) {
+	var task domain.Task
+	if err := u.db.Select("id", "board_id").First(&task, taskID).Error; err != nil {
+		return 0, err
+	}
+	return task.BoardID, nil
+}
+
+func (u *uploadRepository) Create(session *domain.UploadSession) error {
+	if err := u.db.Create(session).Error; err != nil {
+		return fmt.Errorf("Failed to create upload: %v", err)
+	}
+	return nil
+}
+
+func (u *uploadRepository) FindByID(id string) (*domain.UploadSession, error) {
+	var session domain.UploadSession
+	if err := u.db.Where("id = ?", id).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (u *uploadRepository) Update(session *domain.UploadSession) error {
+	if err := u.db.Save(session).Error; err != nil {
+		return fmt.Errorf("Failed to update upload: %v", err)
+	}
+	return nil
+}
+
+func (u *uploadRepository) FindParts(sessionID string) ([]domain.UploadSessionPart, error) {
+	var parts []domain.UploadSessionPart
+	if err := u.db.Where("session_id = ?", sessionID).Order("part_number").Find(&parts).Error; err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
+
+// AppendPart mencatat part baru dan memajukan offset upload, hanya berhasil jika offset di database masih sama
+// dengan offset saat part dikirim sehingga dua request untuk offset yang sama tidak tercatat dua kali
+func (u *uploadRepository) AppendPart(session *domain.UploadSession, part *domain.UploadSessionPart) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.UploadSession{}).
+			Where("id = ? AND status = ? AND upload_offset = ?", session.ID, domain.UploadStatusUploading, session.Offset).
+			Updates(map[string]interface{}{"upload_offset": session.Offset + part.Size, "updated_at": time.Now()})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrUploadOffsetMismatch
+		}
+
+		if err := tx.Create(part).Error; err != nil {
+			return err
+		}
+		session.Offset += part.Size
+		return nil
+	})
+}
+
+// Claim menandai upload yang sudah selesai sebagai attached agar referensi objeknya berpindah ke file pada task.
+// Semua upload harus milik user, task dan jenis file yang sama, jika salah satu tidak memenuhi tidak ada yang diubah
+func (u *uploadRepository) Claim(ids []string, userID uint64, taskID uint64, fileType string) ([]domain.UploadSession, error) {
+	var sessions []domain.UploadSession
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", ids).Find(&sessions).Error; err != nil {
+			return err
+		}
+
+		found := make(map[string]domain.UploadSession)
+		for _, session := range sessions {
+			found[session.ID] = session
+		}
+		now := time.Now()
+		for _, id := range ids {
+			session, ok := found[id]
+			if !ok || session.UserID != userID || session.TaskID != taskID || session.FileType != fileType {
+				return fmt.Errorf("%w: upload %s not found for this task and file type", ErrUploadNotAttachable, id)
+			}
+			if session.Status != domain.UploadStatusCompleted || session.ExpiresAt.Before(now) {
+				return fmt.Errorf("%w: upload %s is %s", ErrUploadNotAttachable, id, session.Status)
+			}
+		}
+
+		return tx.Model(&domain.UploadSession{}).Where("id IN ?", ids).Update("status", domain.UploadStatusAttached).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// urutan mengikuti urutan id pada request
+	ordered := make([]domain.UploadSession, 0, len(ids))
+	for _, id := range ids {
+		for _, session := range sessions {
+			if session.ID == id {
+				ordered = append(ordered, session)
+				break
+			}
+		}
+	}
+	return ordered, nil
+}
+
+// Unclaim mengembalikan upload yang gagal dilampirkan menjadi completed sehingga bisa dilampirkan ulang
+func (u *uploadRepository) Unclaim(ids []string) error {
+	return u.db.Model(&domain.UploadSession{}).
+		Where("id IN ? AND status = ?", ids, domain.UploadStatusAttached).
+		Update("status", domain.UploadStatusCompleted).Error
+}
+
+func (u *uploadRepository) FindExpired(before time.Time) ([]domain.UploadSession, error) {
+	var sessions []domain.UploadSession
+	if err := u.db.Where("expires_at < ?", before).Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// Delete menghapus upload beserta part-nya jika statusnya masih sama, false berarti upload sudah berubah atau sudah dihapus
+func (u *uploadRepository) Delete(id string, status string) (bool, error) {
+	deleted := false
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND status = ?", id, status).Delete(&domain.UploadSession{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		deleted = true
+		return tx.Where("session_id = ?", id).Delete(&domain.UploadSessionPart{}).Error
+	})
+	if err != nil {
+		return false, fmt.Errorf("Failed to delete upload: %v", err)
+	}
+	return deleted, nil
+}
@@ -13,6 +13,8 @@ type UserRepository interface {
 	GoogleOauth(email string) error
 	RequireOauth(email string) (*domain.User, error)
 	GetUserByEmail(email string) (*domain.User, error)
+	FindLocalesByEmails(emails []string) (map[string]string, error)
+	FindIDsByEmails(emails []string) (map[string]uint64, error)
 	UpdatePassword(userID uint64, newPassword string) error
 	FindById(id interface{}) (*domain.User, error)
 	FindAll() ([]*domain.User, error)
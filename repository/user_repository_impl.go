@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"manajemen_tugas_master/model/domain"
+	"strings"
 
 	"gorm.io/gorm"
 )
@@ -82,6 +83,38 @@ func (r *userRepository) GetUserByEmail(email string) (*domain.User, error) {
 	return &user, nil
 }
 
+// FindIDsByEmails mengembalikan id user per alamat email dalam huruf kecil, email yang tidak terdaftar tidak ada di hasil
+func (r *userRepository) FindIDsByEmails(emails []string) (map[string]uint64, error) {
+	ids := make(map[string]uint64, len(emails))
+	if len(emails) == 0 {
+		return ids, nil
+	}
+	var users []domain.User
+	if err := r.db.Select("id", "email").Where("email IN ?", emails).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		ids[strings.ToLower(user.Email)] = user.ID
+	}
+	return ids, nil
+}
+
+// FindLocalesByEmails mengembalikan bahasa email tiap user per alamat email dalam huruf kecil, email yang tidak terdaftar tidak ada di hasil
+func (r *userRepository) FindLocalesByEmails(emails []string) (map[string]string, error) {
+	locales := make(map[string]string, len(emails))
+	if len(emails) == 0 {
+		return locales, nil
+	}
+	var users []domain.User
+	if err := r.db.Select("email", "locale").Where("email IN ?", emails).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		locales[strings.ToLower(user.Email)] = user.Locale
+	}
+	return locales, nil
+}
+
 func (r *userRepository) UpdatePassword(userID uint64, newPassword string) error {
 	result := r.db.Model(&domain.User{}).Where("id = ?", userID).Update("password", newPassword)
 	return result.Error
@@ -123,5 +156,13 @@ func (r *userRepository) Delete(id uint) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to delete related users, because they are associated with tasks")
 	}
 
+	// koneksi Google Calendar dan feed calendar user tidak dipakai lagi
+	if err := r.db.Where("user_id = ?", id).Delete(&domain.CalendarConnection{}).Error; err != nil {
+		return nil, fmt.Errorf("failed to delete calendar connection: %v", err)
+	}
+	if err := r.db.Where("user_id = ?", id).Delete(&domain.CalendarFeed{}).Error; err != nil {
+		return nil, fmt.Errorf("failed to delete calendar feeds: %v", err)
+	}
+
 	return r.db, nil
 }
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"manajemen_tugas_master/model/domain"
+	"time"
+)
+
+type WebhookRepository interface {
+	FindBoardOwner(boardID uint64) (uint64, error)
+	Create(webhook *domain.Webhook) error
+	FindByBoard(boardID uint64) ([]domain.Webhook, error)
+	FindByID(boardID uint64, id uint64) (*domain.Webhook, error)
+	Delete(id uint64) error
+	DeleteByBoard(boardID uint64) error
+	FindSubscribed(boardID uint64, event string) ([]domain.Webhook, error)
+	CreateDeliveries(deliveries []*domain.WebhookDelivery) error
+	ClaimDue(limit int, lease time.Duration) ([]domain.WebhookDelivery, error)
+	RecordAttempt(delivery *domain.WebhookDelivery, attempt *domain.WebhookDeliveryAttempt) error
+	FindDeliveries(webhookID uint64, limit int, offset int) ([]domain.WebhookDelivery, int64, error)
+	FindDelivery(webhookID uint64, id uint64) (*domain.WebhookDelivery, error)
+}
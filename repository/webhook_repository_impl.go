@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"manajemen_tugas_master/model/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db}
+}
+
+func (w *webhookRepository) FindBoardOwner(boardID uint64) (uint64, error) {
+	var board domain.Board
+	if err := w.db.Select("id", "user_id").First(&board, boardID).Error; err != nil {
+		return 0, err
+	}
+	return board.UserID, nil
+}
+
+func (w *webhookRepository) Create(webhook *domain.Webhook) error {
+	return w.db.Create(webhook).Error
+}
+
+func (w *webhookRepository) FindByBoard(boardID uint64) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+	err := w.db.Where("board_id = ?", boardID).Order("id").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (w *webhookRepository) FindByID(boardID uint64, id uint64) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	if err := w.db.Where("board_id = ?", boardID).First(&webhook, id).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// Delete menghapus webhook beserta riwayat pengirimannya sehingga tidak ada lagi event yang dikirim ke url tersebut
+func (w *webhookRepository) Delete(id uint64) error {
+	return w.db.Transaction(func(tx *gorm.DB) error {
+		deliveries := tx.Model(&domain.WebhookDelivery{}).Select("id").Where("webhook_id = ?", id)
+		if err := tx.Where("delivery_id IN (?)", deliveries).Delete(&domain.WebhookDeliveryAttempt{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("webhook_id = ?", id).Delete(&domain.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Webhook{}, id).Error
+	})
+}
+
+// DeleteByBoard menghapus webhook milik board yang dihapus. Pengiriman yang sudah dicatat, termasuk event
+// board.deleted, tetap dikirim karena url dan payload-nya tersimpan pada pengiriman
+func (w *webhookRepository) DeleteByBoard(boardID uint64) error {
+	return w.db.Where("board_id = ?", boardID).Delete(&domain.Webhook{}).Error
+}
+
+func (w *webhookRepository) FindSubscribed(boardID uint64, event string) ([]domain.Webhook, error) {
+	webhooks, err := w.FindByBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	subscribed := webhooks[:0]
+	for _, webhook := range webhooks {
+		if webhook.Active && webhook.Subscribed(event) {
+			subscribed = append(subscribed, webhook)
+		}
+	}
+	return subscribed, nil
+}
+
+func (w *webhookRepository) CreateDeliveries(deliveries []*domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	now := time.Now()
+	for _, delivery := range deliveries {
+		delivery.Status = domain.WebhookDeliveryPending
+		delivery.NextAttemptAt = now
+	}
+	return w.db.Create(&deliveries).Error
+}
+
+// ClaimDue mengambil pengiriman yang sudah waktunya dan menandainya sending sampai lease habis, sama seperti outbox notifikasi
+func (w *webhookRepository) ClaimDue(limit int, lease time.Duration) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	err := w.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ? AND next_attempt_at <= ?", []string{domain.WebhookDeliveryPending, domain.WebhookDeliverySending}, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uint64, 0, len(deliveries))
+		for i := range deliveries {
+			ids = append(ids, deliveries[i].ID)
+			deliveries[i].Status = domain.WebhookDeliverySending
+			deliveries[i].Attempts++
+		}
+		return tx.Model(&domain.WebhookDelivery{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":          domain.WebhookDeliverySending,
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": now.Add(lease),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// RecordAttempt mencatat hasil satu percobaan dan status pengiriman setelahnya dalam satu transaksi
+func (w *webhookRepository) RecordAttempt(delivery *domain.WebhookDelivery, attempt *domain.WebhookDeliveryAttempt) error {
+	return w.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(attempt).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.WebhookDelivery{ID: delivery.ID}).
+			Select("status", "next_attempt_at", "response_status", "last_error", "delivered_at", "updated_at").
+			Updates(delivery).Error
+	})
+}
+
+func (w *webhookRepository) FindDeliveries(webhookID uint64, limit int, offset int) ([]domain.WebhookDelivery, int64, error) {
+	query := w.db.Model(&domain.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var deliveries []domain.WebhookDelivery
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&deliveries).Error; err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+func (w *webhookRepository) FindDelivery(webhookID uint64, id uint64) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	err := w.db.Preload("AttemptLog", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Where("webhook_id = ?", webhookID).
+		First(&delivery, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
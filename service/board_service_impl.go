@@ -9,10 +9,13 @@ import (
 
 type boardService struct {
 	boardRepository repository.BoardRepository
+	fileService     FileService
+	webhookService  WebhookService
+	calendarService CalendarService
 }
 
-func NewBoardService(boardRepository repository.BoardRepository) BoardService {
-	return &boardService{boardRepository}
+func NewBoardService(boardRepository repository.BoardRepository, fileService FileService, webhookService WebhookService, calendarService CalendarService) BoardService {
+	return &boardService{boardRepository, fileService, webhookService, calendarService}
 }
 
 func (s *boardService) CreateBoard(board *domain.Board) (*domain.Board, error) {
@@ -45,10 +48,36 @@ func (s *boardService) GetAllBoards() ([]*domain.Board, error) {
 }
 
 func (s *boardService) DeleteBoardById(id uint64) error {
+	// ambil key file dari semua task pada board sebelum record-nya dihapus
+	fileKeys, err := s.fileService.BoardFileKeys(id)
+	if err != nil {
+		return err
+	}
+
+	// data board untuk webhook board.deleted diambil sebelum board dihapus
+	deletedBoard, err := s.boardRepository.FindById(id)
+	if err != nil {
+		return err
+	}
+
+	// event calendar task pada board dibatalkan setelah board terhapus
+	calendarEvents, err := s.calendarService.BoardEvents(id)
+	if err != nil {
+		return err
+	}
+
 	db, countTasks, countManagers, countEmployees, countPlanningFiles, countProjectFiles, err := s.boardRepository.DeleteById(id)
 	if err != nil {
 		return err
 	}
+	s.fileService.ReleaseFiles(fileKeys)
+	s.calendarService.CancelEvents(calendarEvents)
+
+	// event board.deleted dicatat sebelum webhook board ikut dihapus, pengirimannya tetap berjalan setelahnya
+	s.webhookService.Dispatch(id, domain.WebhookEventBoardDeleted, map[string]interface{}{
+		"board": map[string]interface{}{"id": deletedBoard.ID, "name_board": deletedBoard.NameBoard, "user_id": deletedBoard.UserID},
+	})
+	s.webhookService.DeleteBoardWebhooks(id)
 
 	// Reset auto increment
 	if countTasks > 0 {
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"errors"
+	"manajemen_tugas_master/model/web"
+)
+
+var ErrCalendarFeedNotFound = errors.New("Calendar feed not found")
+
+type CalendarFeedService interface {
+	GetFeed(userID uint64, boardID uint64) (*web.CalendarFeedResponse, error)
+	RotateFeed(userID uint64, boardID uint64) (*web.CalendarFeedResponse, error)
+	RenderFeed(token string) ([]byte, error)
+}
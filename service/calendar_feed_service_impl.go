@@ -0,0 +1,190 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/model/web"
+	"manajemen_tugas_master/repository"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// calendarFeedLabels adalah teks event feed iCalendar sesuai bahasa pemilik feed
+var calendarFeedLabels = map[string]map[string]string{
+	"en": {
+		"name":            "Task due dates",
+		"planning":        "Planning due: %s",
+		"project":         "Project due: %s",
+		"board":           "Board",
+		"planning_status": "Planning status",
+		"project_status":  "Project status",
+		"priority":        "Priority",
+	},
+	"id": {
+		"name":            "Tenggat task",
+		"planning":        "Tenggat planning: %s",
+		"project":         "Tenggat project: %s",
+		"board":           "Board",
+		"planning_status": "Status planning",
+		"project_status":  "Status project",
+		"priority":        "Prioritas",
+	},
+}
+
+type calendarFeedService struct {
+	calendarFeedRepository repository.CalendarFeedRepository
+	userRepository         repository.UserRepository
+}
+
+func NewCalendarFeedService(calendarFeedRepository repository.CalendarFeedRepository, userRepository repository.UserRepository) CalendarFeedService {
+	return &calendarFeedService{calendarFeedRepository, userRepository}
+}
+
+// authorizeBoard memastikan board ada dan user adalah owner-nya, feed pribadi (boardID 0) tidak diperiksa
+func (c *calendarFeedService) authorizeBoard(userID uint64, boardID uint64) error {
+	if boardID == 0 {
+		return nil
+	}
+	board, err := c.calendarFeedRepository.FindBoard(boardID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrBoardNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if board.UserID != userID {
+		return ErrNotBoardOwner
+	}
+	return nil
+}
+
+// GetFeed mengembalikan url feed user atau board, feed dibuat saat pertama kali diminta
+func (c *calendarFeedService) GetFeed(userID uint64, boardID uint64) (*web.CalendarFeedResponse, error) {
+	if err := c.authorizeBoard(userID, boardID); err != nil {
+		return nil, err
+	}
+
+	feed, err := c.calendarFeedRepository.FindFeed(userID, boardID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		token, err := helper.GenerateFeedToken()
+		if err != nil {
+			return nil, err
+		}
+		if err := c.calendarFeedRepository.CreateFeed(&domain.CalendarFeed{UserID: userID, BoardID: boardID, Token: token}); err != nil {
+			return nil, err
+		}
+		feed, err = c.calendarFeedRepository.FindFeed(userID, boardID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return calendarFeedResponse(feed), nil
+}
+
+// RotateFeed mengganti token feed, url lama langsung tidak berlaku
+func (c *calendarFeedService) RotateFeed(userID uint64, boardID uint64) (*web.CalendarFeedResponse, error) {
+	if err := c.authorizeBoard(userID, boardID); err != nil {
+		return nil, err
+	}
+
+	token, err := helper.GenerateFeedToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.calendarFeedRepository.SaveFeed(&domain.CalendarFeed{UserID: userID, BoardID: boardID, Token: token}); err != nil {
+		return nil, err
+	}
+	feed, err := c.calendarFeedRepository.FindFeed(userID, boardID)
+	if err != nil {
+		return nil, err
+	}
+	return calendarFeedResponse(feed), nil
+}
+
+// RenderFeed menyusun isi feed iCalendar untuk token. Setiap tenggat planning dan project menjadi event sepanjang hari
+// dengan UID tetap per task dan jenis tenggat
+func (c *calendarFeedService) RenderFeed(token string) ([]byte, error) {
+	feed, err := c.calendarFeedRepository.FindFeedByToken(token)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCalendarFeedNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	locale := helper.DefaultLocale
+	if user, err := c.userRepository.FindById(feed.UserID); err == nil {
+		locale = helper.NormalizeLocale(user.Locale)
+	}
+	labels := calendarFeedLabels[locale]
+
+	name := labels["name"]
+	var tasks []domain.Task
+	if feed.BoardID != 0 {
+		// feed board hanya berlaku selama pembuatnya masih owner board
+		board, err := c.calendarFeedRepository.FindBoard(feed.BoardID)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && board.UserID != feed.UserID) {
+			return nil, ErrCalendarFeedNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		name = board.NameBoard
+		tasks, err = c.calendarFeedRepository.FindBoardDueTasks(feed.BoardID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		tasks, err = c.calendarFeedRepository.FindUserDueTasks(feed.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	events := make([]helper.ICSEvent, 0, 2*len(tasks))
+	for _, task := range tasks {
+		if event, ok := dueDateICSEvent(&task, domain.CalendarDueTypePlanning, task.PlanningDueDate, labels); ok {
+			events = append(events, event)
+		}
+		if event, ok := dueDateICSEvent(&task, domain.CalendarDueTypeProject, task.ProjectDueDate, labels); ok {
+			events = append(events, event)
+		}
+	}
+	return helper.RenderICS(name, events, time.Now()), nil
+}
+
+// dueDateICSEvent menyusun event tenggat task, tanggal yang kosong atau tidak valid dilewati
+func dueDateICSEvent(task *domain.Task, dueType string, dueDate string, labels map[string]string) (helper.ICSEvent, bool) {
+	date, err := time.Parse("02-01-2006", dueDate)
+	if err != nil {
+		return helper.ICSEvent{}, false
+	}
+
+	lines := []string{fmt.Sprintf("%s: %s", labels["board"], task.Board.NameBoard)}
+	if dueType == domain.CalendarDueTypePlanning {
+		lines = append(lines, fmt.Sprintf("%s: %s", labels["planning_status"], task.PlanningStatus))
+	} else {
+		lines = append(lines, fmt.Sprintf("%s: %s", labels["project_status"], task.ProjectStatus))
+	}
+	lines = append(lines, fmt.Sprintf("%s: %s", labels["priority"], task.Priority))
+
+	return helper.ICSEvent{
+		UID:         helper.TaskEventUID(task.ID, dueType),
+		Date:        date,
+		Summary:     fmt.Sprintf(labels[dueType], task.NameTask),
+		Description: strings.Join(lines, "\n"),
+		URL:         helper.TaskURL(task.ID),
+	}, true
+}
+
+func calendarFeedResponse(feed *domain.CalendarFeed) *web.CalendarFeedResponse {
+	return &web.CalendarFeedResponse{
+		URL:       helper.CalendarFeedURL(feed.Token),
+		BoardID:   feed.BoardID,
+		RotatedAt: feed.UpdatedAt,
+	}
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"errors"
+	"manajemen_tugas_master/model/domain"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+var (
+	ErrCalendarNotConfigured  = errors.New("Google Calendar is not configured")
+	ErrCalendarNotConnected   = errors.New("Google Calendar is not connected")
+	ErrCalendarAuthorization  = errors.New("Google Calendar authorization failed")
+	ErrCalendarNoRefreshToken = errors.New("Google did not return a refresh token, remove the app access from your Google account and connect again")
+)
+
+// CalendarEvent adalah isi event tenggat task. Start dan End kosong tidak mengubah waktu event yang sudah ada
+type CalendarEvent struct {
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+	TimeZone    string
+	Attendees   []string
+}
+
+type CalendarService interface {
+	AuthURL(state string) (string, error)
+	Connect(userID uint64, code string) (*domain.CalendarConnection, error)
+	GetConnection(userID uint64) (*domain.CalendarConnection, error)
+	Disconnect(userID uint64) error
+	SyncTaskEvent(userID uint64, taskID uint64, dueType string, event *CalendarEvent) (*calendar.Event, error)
+	TaskEvents(taskID uint64) ([]domain.TaskCalendarEvent, error)
+	BoardEvents(boardID uint64) ([]domain.TaskCalendarEvent, error)
+	CancelEvents(events []domain.TaskCalendarEvent)
+}
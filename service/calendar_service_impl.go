@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/repository"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/calendar/v3"
+	"gorm.io/gorm"
+)
+
+// batas waktu satu permintaan ke Google, termasuk refresh token
+const calendarTimeout = 30 * time.Second
+
+type calendarService struct {
+	calendarRepository repository.CalendarRepository
+	config             *oauth2.Config
+}
+
+// NewCalendarService membuat service Google Calendar. Tanpa GOOGLE_CALENDAR_CREDENTIALS yang valid user tidak bisa
+// menghubungkan calendar dan pembuatan event dilewati
+func NewCalendarService(calendarRepository repository.CalendarRepository) CalendarService {
+	config, err := helper.GoogleCalendarConfig()
+	if err != nil {
+		if !errors.Is(err, helper.ErrGoogleCalendarNotConfigured) {
+			log.Printf("Google Calendar disabled: %v", err)
+		}
+		config = nil
+	}
+	return &calendarService{calendarRepository, config}
+}
+
+// AuthURL adalah halaman persetujuan Google. Persetujuan selalu diminta ulang agar Google mengirim refresh token
+// walaupun user pernah menghubungkan calendar sebelumnya
+func (c *calendarService) AuthURL(state string) (string, error) {
+	if c.config == nil {
+		return "", ErrCalendarNotConfigured
+	}
+	return c.config.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.ApprovalForce), nil
+}
+
+func (c *calendarService) Connect(userID uint64, code string) (*domain.CalendarConnection, error) {
+	if c.config == nil {
+		return nil, ErrCalendarNotConfigured
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), calendarTimeout)
+	defer cancel()
+
+	token, err := c.config.Exchange(ctx, code)
+	if err != nil {
+		log.Printf("Failed to exchange Google Calendar code for user %d: %v", userID, err)
+		return nil, ErrCalendarAuthorization
+	}
+	if token.RefreshToken == "" {
+		return nil, ErrCalendarNoRefreshToken
+	}
+
+	email, err := helper.GoogleAccountEmail(ctx, c.config.Client(ctx, token))
+	if err != nil {
+		log.Printf("Failed to read Google account of user %d: %v", userID, err)
+		return nil, ErrCalendarAuthorization
+	}
+
+	refreshToken, err := helper.EncryptSecret(token.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := helper.EncryptSecret(token.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	connection := &domain.CalendarConnection{
+		UserID:       userID,
+		Email:        email,
+		RefreshToken: refreshToken,
+		AccessToken:  accessToken,
+		TokenExpiry:  tokenExpiry(token),
+	}
+	if err := c.calendarRepository.SaveConnection(connection); err != nil {
+		return nil, err
+	}
+	return connection, nil
+}
+
+func (c *calendarService) GetConnection(userID uint64) (*domain.CalendarConnection, error) {
+	connection, err := c.calendarRepository.FindConnection(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCalendarNotConnected
+	}
+	return connection, err
+}
+
+// Disconnect menghapus koneksi calendar user. Token juga dicabut di Google, kegagalan pencabutan hanya dicatat
+// karena token yang tersimpan tetap dihapus
+func (c *calendarService) Disconnect(userID uint64) error {
+	connection, err := c.GetConnection(userID)
+	if err != nil {
+		return err
+	}
+
+	if refreshToken, err := helper.DecryptSecret(connection.RefreshToken); err != nil {
+		log.Printf("Failed to decrypt Google Calendar token of user %d: %v", userID, err)
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), calendarTimeout)
+		if err := helper.RevokeGoogleToken(ctx, refreshToken); err != nil {
+			log.Printf("Failed to revoke Google Calendar token of user %d: %v", userID, err)
+		}
+		cancel()
+	}
+
+	return c.calendarRepository.DeleteConnection(userID)
+}
+
+// SyncTaskEvent membuat event tenggat task pada calendar utama user atau memperbarui event yang sudah tercatat,
+// termasuk mengganti daftar attendee. Event baru hanya dibuat jika event punya waktu selesai dan attendee; event yang
+// sudah dihapus di Google dibuat ulang. User yang belum menghubungkan calendar mengembalikan ErrCalendarNotConnected
+func (c *calendarService) SyncTaskEvent(userID uint64, taskID uint64, dueType string, event *CalendarEvent) (*calendar.Event, error) {
+	if c.config == nil {
+		return nil, ErrCalendarNotConfigured
+	}
+	stored, err := c.calendarRepository.FindTaskEvent(taskID, dueType)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	// event di calendar user lain tidak bisa diubah dengan koneksi user ini
+	if stored != nil && stored.UserID != userID {
+		stored = nil
+	}
+	canCreate := !event.End.IsZero() && len(event.Attendees) > 0
+	if stored == nil && !canCreate {
+		return nil, nil
+	}
+
+	connection, err := c.GetConnection(userID)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), calendarTimeout)
+	defer cancel()
+	srv, err := c.calendarClient(ctx, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := formatEventTime(event.Start), formatEventTime(event.End)
+	if stored != nil {
+		updated, err := helper.UpdateGoogleCalendarEvent(srv, stored.EventID, connection.Email, event.Summary, event.Description, start, end, event.TimeZone, event.Attendees)
+		if err == nil {
+			return updated, nil
+		}
+		if !helper.GoogleCalendarEventGone(err) {
+			return nil, err
+		}
+		// event sudah dihapus di Google
+		if err := c.calendarRepository.DeleteTaskEvent(taskID, dueType); err != nil {
+			return nil, err
+		}
+		if !canCreate {
+			return nil, nil
+		}
+	}
+
+	// event baru dimulai saat dibuat jika waktu mulai tidak diisi
+	if start == "" {
+		start = time.Now().Format(time.RFC3339)
+	}
+	created, err := helper.CreateGoogleCalendarEvent(srv, connection.Email, event.Summary, event.Description, start, end, event.TimeZone, event.Attendees)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.calendarRepository.SaveTaskEvent(&domain.TaskCalendarEvent{TaskID: taskID, DueType: dueType, UserID: userID, EventID: created.Id}); err != nil {
+		return nil, fmt.Errorf("failed to save calendar event of task %d: %w", taskID, err)
+	}
+	return created, nil
+}
+
+// TaskEvents mengambil event calendar task, dipanggil sebelum task dihapus agar event-nya bisa dibatalkan
+func (c *calendarService) TaskEvents(taskID uint64) ([]domain.TaskCalendarEvent, error) {
+	return c.calendarRepository.FindTaskEvents(taskID)
+}
+
+// BoardEvents mengambil event calendar semua task pada board, dipanggil sebelum board dihapus
+func (c *calendarService) BoardEvents(boardID uint64) ([]domain.TaskCalendarEvent, error) {
+	return c.calendarRepository.FindBoardEvents(boardID)
+}
+
+// CancelEvents membatalkan event calendar task yang sudah dihapus. Kegagalan hanya dicatat karena task sudah terhapus,
+// event di calendar user yang sudah memutus koneksi tidak bisa dibatalkan lagi
+func (c *calendarService) CancelEvents(events []domain.TaskCalendarEvent) {
+	if c.config == nil {
+		return
+	}
+	for _, event := range events {
+		if err := c.cancelEvent(event); err != nil {
+			log.Printf("Failed to cancel Google Calendar event of task %d (%s): %v", event.TaskID, event.DueType, err)
+		}
+	}
+}
+
+func (c *calendarService) cancelEvent(event domain.TaskCalendarEvent) error {
+	connection, err := c.GetConnection(event.UserID)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), calendarTimeout)
+	defer cancel()
+	srv, err := c.calendarClient(ctx, connection)
+	if err != nil {
+		return err
+	}
+	return helper.DeleteGoogleCalendarEvent(srv, event.EventID)
+}
+
+// calendarClient membuat client Google Calendar dengan token koneksi user
+func (c *calendarService) calendarClient(ctx context.Context, connection *domain.CalendarConnection) (*calendar.Service, error) {
+	tokenSource, err := c.tokenSource(ctx, connection)
+	if err != nil {
+		return nil, err
+	}
+	return helper.NewGoogleCalendarService(ctx, tokenSource)
+}
+
+// tokenSource membuat token source dari token tersimpan yang diperbarui otomatis saat kedaluwarsa
+func (c *calendarService) tokenSource(ctx context.Context, connection *domain.CalendarConnection) (oauth2.TokenSource, error) {
+	refreshToken, err := helper.DecryptSecret(connection.RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt Google Calendar token of user %d: %w", connection.UserID, err)
+	}
+	// access token yang tidak bisa dibuka cukup diganti dengan hasil refresh
+	accessToken, _ := helper.DecryptSecret(connection.AccessToken)
+
+	token := &oauth2.Token{AccessToken: accessToken, RefreshToken: refreshToken, TokenType: "Bearer"}
+	if connection.TokenExpiry != nil {
+		token.Expiry = *connection.TokenExpiry
+	}
+	return &connectionTokenSource{
+		service:      c,
+		userID:       connection.UserID,
+		base:         c.config.TokenSource(ctx, token),
+		accessToken:  accessToken,
+		refreshToken: refreshToken,
+	}, nil
+}
+
+// connectionTokenSource menyimpan token hasil refresh ke database. Refresh token yang dicabut user di Google
+// menghapus koneksi calendar sehingga event berikutnya dilewati
+type connectionTokenSource struct {
+	service      *calendarService
+	userID       uint64
+	base         oauth2.TokenSource
+	mu           sync.Mutex
+	accessToken  string
+	refreshToken string
+}
+
+func (s *connectionTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, err := s.base.Token()
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) && retrieveErr.ErrorCode == "invalid_grant" {
+			log.Printf("Google Calendar access of user %d was revoked, removing connection", s.userID)
+			if err := s.service.calendarRepository.DeleteConnection(s.userID); err != nil {
+				log.Printf("Failed to remove Google Calendar connection of user %d: %v", s.userID, err)
+			}
+			return nil, ErrCalendarNotConnected
+		}
+		return nil, err
+	}
+	if token.AccessToken == s.accessToken {
+		return token, nil
+	}
+
+	accessToken, err := helper.EncryptSecret(token.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	// Google tidak selalu mengirim refresh token baru saat refresh
+	var refreshToken string
+	if token.RefreshToken != "" && token.RefreshToken != s.refreshToken {
+		if refreshToken, err = helper.EncryptSecret(token.RefreshToken); err != nil {
+			return nil, err
+		}
+		s.refreshToken = token.RefreshToken
+	}
+	if err := s.service.calendarRepository.UpdateTokens(s.userID, accessToken, refreshToken, tokenExpiry(token)); err != nil {
+		log.Printf("Failed to save refreshed Google Calendar token of user %d: %v", s.userID, err)
+	}
+	s.accessToken = token.AccessToken
+	return token, nil
+}
+
+func tokenExpiry(token *oauth2.Token) *time.Time {
+	if token.Expiry.IsZero() {
+		return nil
+	}
+	expiry := token.Expiry
+	return &expiry
+}
+
+func formatEventTime(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.Format(time.RFC3339)
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/model/web"
+)
+
+var (
+	ErrChatIntegrationNotFound = errors.New("Chat integration not found")
+	ErrInvalidChatProvider     = errors.New("Chat provider must be slack or discord")
+	ErrInvalidChatURL          = errors.New("Chat webhook url must be an incoming webhook url on an allowed chat service host")
+	ErrChatDeliveryFailed      = errors.New("Chat service did not accept the message")
+)
+
+type ChatService interface {
+	CreateIntegration(userID uint64, boardID uint64, request *web.ChatIntegrationRequest) (*web.ChatIntegrationResponse, error)
+	GetIntegrations(userID uint64, boardID uint64) ([]web.ChatIntegrationResponse, error)
+	UpdateIntegration(userID uint64, boardID uint64, integrationID uint64, request *web.UpdateChatIntegrationRequest) (*web.ChatIntegrationResponse, error)
+	DeleteIntegration(userID uint64, boardID uint64, integrationID uint64) error
+	TestIntegration(userID uint64, boardID uint64, integrationID uint64) error
+	Messages(boardID uint64, actorID uint64, messages []*domain.OutboxMessage) []*domain.OutboxMessage
+	Send(ctx context.Context, message domain.OutboxMessage) error
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/model/web"
+	"manajemen_tugas_master/repository"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const defaultChatTimeout = 10 * time.Second
+
+type chatService struct {
+	chatRepository repository.ChatRepository
+	userRepository repository.UserRepository
+	client         *http.Client
+}
+
+func NewChatService(chatRepository repository.ChatRepository, userRepository repository.UserRepository) ChatService {
+	timeout := helper.DurationFromEnv("CHAT_TIMEOUT", defaultChatTimeout)
+	if timeout == 0 {
+		timeout = defaultChatTimeout
+	}
+	return &chatService{
+		chatRepository: chatRepository,
+		userRepository: userRepository,
+		client:         helper.NewPublicHTTPClient(timeout),
+	}
+}
+
+// authorizeBoard memastikan board ada dan user adalah owner-nya
+func (c *chatService) authorizeBoard(userID uint64, boardID uint64) (*domain.Board, error) {
+	board, err := c.chatRepository.FindBoard(boardID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrBoardNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if board.UserID != userID {
+		return nil, ErrNotBoardOwner
+	}
+	return board, nil
+}
+
+func (c *chatService) findIntegration(userID uint64, boardID uint64, integrationID uint64) (*domain.Board, *domain.ChatIntegration, error) {
+	board, err := c.authorizeBoard(userID, boardID)
+	if err != nil {
+		return nil, nil, err
+	}
+	integration, err := c.chatRepository.FindByID(boardID, integrationID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrChatIntegrationNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return board, integration, nil
+}
+
+// chatEventTypes memeriksa jenis event yang dipilih dan mengembalikannya dalam urutan tetap, kosong berarti semua jenis event
+func chatEventTypes(events []string) ([]string, error) {
+	if len(events) == 0 {
+		return slices.Clone(domain.NotificationEventTypes), nil
+	}
+	for _, event := range events {
+		if !slices.Contains(domain.NotificationEventTypes, event) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidNotificationEvent, event)
+		}
+	}
+	var selected []string
+	for _, event := range domain.NotificationEventTypes {
+		if slices.Contains(events, event) {
+			selected = append(selected, event)
+		}
+	}
+	return selected, nil
+}
+
+func chatLocale(locale string) (string, error) {
+	if locale == "" {
+		return helper.DefaultLocale, nil
+	}
+	if !slices.Contains(helper.SupportedLocales, locale) {
+		return "", ErrUnsupportedLocale
+	}
+	return locale, nil
+}
+
+// chatIntegrationResponse menyamarkan url webhook, yang tampil hanya host layanan chat-nya
+func chatIntegrationResponse(integration *domain.ChatIntegration) web.ChatIntegrationResponse {
+	masked := "…"
+	if parsed, err := url.Parse(integration.URL); err == nil {
+		masked = parsed.Scheme + "://" + parsed.Host + "/…"
+	}
+	return web.ChatIntegrationResponse{ChatIntegration: *integration, URL: masked}
+}
+
+func (c *chatService) CreateIntegration(userID uint64, boardID uint64, request *web.ChatIntegrationRequest) (*web.ChatIntegrationResponse, error) {
+	if _, err := c.authorizeBoard(userID, boardID); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(domain.ChatProviders, request.Provider) {
+		return nil, ErrInvalidChatProvider
+	}
+	if !helper.ValidChatWebhookURL(request.Provider, request.URL) {
+		return nil, ErrInvalidChatURL
+	}
+	locale, err := chatLocale(request.Locale)
+	if err != nil {
+		return nil, err
+	}
+	events, err := chatEventTypes(request.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	integration := &domain.ChatIntegration{
+		BoardID:  boardID,
+		UserID:   userID,
+		Provider: request.Provider,
+		URL:      request.URL,
+		Locale:   locale,
+		Events:   events,
+		Active:   true,
+	}
+	if err := c.chatRepository.Create(integration); err != nil {
+		return nil, err
+	}
+	response := chatIntegrationResponse(integration)
+	return &response, nil
+}
+
+func (c *chatService) GetIntegrations(userID uint64, boardID uint64) ([]web.ChatIntegrationResponse, error) {
+	if _, err := c.authorizeBoard(userID, boardID); err != nil {
+		return nil, err
+	}
+	integrations, err := c.chatRepository.FindByBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]web.ChatIntegrationResponse, 0, len(integrations))
+	for i := range integrations {
+		responses = append(responses, chatIntegrationResponse(&integrations[i]))
+	}
+	return responses, nil
+}
+
+func (c *chatService) UpdateIntegration(userID uint64, boardID uint64, integrationID uint64, request *web.UpdateChatIntegrationRequest) (*web.ChatIntegrationResponse, error) {
+	_, integration, err := c.findIntegration(userID, boardID, integrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Locale != "" {
+		if integration.Locale, err = chatLocale(request.Locale); err != nil {
+			return nil, err
+		}
+	}
+	if request.Events != nil {
+		if integration.Events, err = chatEventTypes(request.Events); err != nil {
+			return nil, err
+		}
+	}
+	if request.Active != nil {
+		integration.Active = *request.Active
+	}
+	if err := c.chatRepository.Update(integration); err != nil {
+		return nil, err
+	}
+	response := chatIntegrationResponse(integration)
+	return &response, nil
+}
+
+func (c *chatService) DeleteIntegration(userID uint64, boardID uint64, integrationID uint64) error {
+	if _, _, err := c.findIntegration(userID, boardID, integrationID); err != nil {
+		return err
+	}
+	return c.chatRepository.Delete(integrationID)
+}
+
+// TestIntegration langsung mengirim pesan percobaan tanpa lewat outbox agar owner board tahu url webhook-nya benar
+func (c *chatService) TestIntegration(userID uint64, boardID uint64, integrationID uint64) error {
+	board, integration, err := c.findIntegration(userID, boardID, integrationID)
+	if err != nil {
+		return err
+	}
+
+	text, err := helper.RenderChatMessage(integration.Provider, integration.Locale, helper.ChatEventTest, "", nil)
+	if err != nil {
+		return err
+	}
+	payload, err := helper.ChatPayload(integration.Provider, helper.ChatMessage{Title: board.NameBoard, Text: text, Timestamp: time.Now()})
+	if err != nil {
+		return err
+	}
+	if err := helper.SendChatMessage(context.Background(), c.client, integration.URL, payload); err != nil {
+		// isi respons dan detail koneksi hanya dicatat ke log, client hanya mendapat status dari layanan chat
+		log.Printf("Test message of chat integration %d failed: %v", integration.ID, err)
+		var responseErr *helper.ChatResponseError
+		if errors.As(err, &responseErr) {
+			return fmt.Errorf("%w: status %d", ErrChatDeliveryFailed, responseErr.Status)
+		}
+		return ErrChatDeliveryFailed
+	}
+	return nil
+}
+
+// Messages menyusun pesan chat untuk integrasi aktif pada board dari notifikasi email sebuah perubahan task, satu pesan
+// per integrasi yang melanggan jenis event-nya. Pesan dicatat pada outbox bersama notifikasi email sehingga ikut dicoba
+// ulang jika layanan chat gagal. Kegagalan menyusun pesan chat hanya dicatat ke log agar perubahan task tetap tersimpan
+func (c *chatService) Messages(boardID uint64, actorID uint64, messages []*domain.OutboxMessage) []*domain.OutboxMessage {
+	integrations, err := c.chatRepository.FindByBoard(boardID)
+	if err != nil {
+		log.Printf("Failed to find chat integrations of board %d: %v", boardID, err)
+		return nil
+	}
+	integrations = slices.DeleteFunc(integrations, func(integration domain.ChatIntegration) bool { return !integration.Active })
+	if len(integrations) == 0 {
+		return nil
+	}
+
+	board, err := c.chatRepository.FindBoard(boardID)
+	if err != nil {
+		log.Printf("Failed to find board %d for chat messages: %v", boardID, err)
+		return nil
+	}
+	actor := ""
+	if user, err := c.userRepository.FindById(actorID); err == nil {
+		actor = user.Email
+	}
+
+	now := time.Now()
+	var chatMessages []*domain.OutboxMessage
+	for _, message := range messages {
+		if message == nil || (message.Channel != "" && message.Channel != domain.OutboxChannelEmail) {
+			continue
+		}
+		eventType := domain.NotificationEventType(message.Event)
+		if eventType == "" {
+			continue
+		}
+
+		for i := range integrations {
+			integration := &integrations[i]
+			if !integration.Subscribed(eventType) {
+				continue
+			}
+			text, err := helper.RenderChatMessage(integration.Provider, integration.Locale, message.Event, actor, &message.Data)
+			if errors.Is(err, helper.ErrChatTemplateNotFound) {
+				continue
+			}
+			if err != nil {
+				log.Printf("Failed to render %s chat message for task %d: %v", message.Event, message.TaskID, err)
+				continue
+			}
+			payload, err := helper.ChatPayload(integration.Provider, helper.ChatMessage{
+				Title:     message.Data.TaskName,
+				TitleURL:  helper.TaskURL(message.TaskID),
+				Text:      text,
+				Footer:    board.NameBoard,
+				Timestamp: now,
+			})
+			if err != nil {
+				log.Printf("Failed to encode %s chat message for task %d: %v", message.Event, message.TaskID, err)
+				continue
+			}
+
+			chatMessages = append(chatMessages, &domain.OutboxMessage{
+				Event:      message.Event,
+				TaskID:     message.TaskID,
+				Channel:    domain.OutboxChannelChat,
+				Recipients: []string{strconv.FormatUint(integration.ID, 10)},
+				Subject:    message.Subject,
+				Body:       string(payload),
+			})
+		}
+	}
+	return chatMessages
+}
+
+// Send mengirim pesan chat pada outbox ke integrasi penerimanya. Integrasi yang sudah dihapus atau dinonaktifkan
+// dilewati, integrasi yang gagal dikembalikan sebagai RecipientsError agar hanya integrasi tersebut yang dicoba ulang
+func (c *chatService) Send(ctx context.Context, message domain.OutboxMessage) error {
+	ids := make([]uint64, 0, len(message.Recipients))
+	for _, recipient := range message.Recipients {
+		id, err := strconv.ParseUint(recipient, 10, 64)
+		if err != nil {
+			log.Printf("Invalid chat integration %q on notification %d", recipient, message.ID)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	integrations, err := c.chatRepository.FindByIDs(ids)
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	var lastErr error
+	for _, integration := range integrations {
+		if !integration.Active {
+			continue
+		}
+		if err := helper.SendChatMessage(ctx, c.client, integration.URL, []byte(message.Body)); err != nil {
+			failed = append(failed, strconv.FormatUint(integration.ID, 10))
+			lastErr = err
+		}
+	}
+	if len(failed) > 0 {
+		return &helper.RecipientsError{Failed: failed, Err: lastErr}
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"errors"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/model/web"
+)
+
+var ErrInvalidEscalationPolicy = errors.New("Escalation hours must not be negative and owner_after_hours must not be less than manager_after_hours")
+
+type EscalationService interface {
+	GetPolicy(userID uint64, boardID uint64) (*domain.EscalationPolicy, error)
+	UpdatePolicy(userID uint64, boardID uint64, request *web.EscalationPolicyRequest) (*domain.EscalationPolicy, error)
+	EscalateOverdueTasks() (int, error)
+}
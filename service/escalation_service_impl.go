@@ -0,0 +1,218 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/model/web"
+	"manajemen_tugas_master/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type escalationService struct {
+	escalationRepository   repository.EscalationRepository
+	taskAndOwnerRepository repository.TaskAndOwnerRepository
+	notificationService    NotificationService
+	location               *time.Location
+}
+
+func NewEscalationService(escalationRepository repository.EscalationRepository, taskAndOwnerRepository repository.TaskAndOwnerRepository, notificationService NotificationService) EscalationService {
+	return &escalationService{
+		escalationRepository:   escalationRepository,
+		taskAndOwnerRepository: taskAndOwnerRepository,
+		notificationService:    notificationService,
+		location:               reminderLocation(),
+	}
+}
+
+// authorizeBoard memastikan board ada dan user adalah owner-nya
+func (e *escalationService) authorizeBoard(userID uint64, boardID uint64) error {
+	board, err := e.escalationRepository.FindBoard(boardID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrBoardNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if board.UserID != userID {
+		return ErrNotBoardOwner
+	}
+	return nil
+}
+
+func (e *escalationService) findPolicy(boardID uint64) (*domain.EscalationPolicy, error) {
+	policy, err := e.escalationRepository.FindPolicy(boardID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		defaultPolicy := domain.DefaultEscalationPolicy(boardID)
+		return &defaultPolicy, nil
+	}
+	return policy, err
+}
+
+func (e *escalationService) GetPolicy(userID uint64, boardID uint64) (*domain.EscalationPolicy, error) {
+	if err := e.authorizeBoard(userID, boardID); err != nil {
+		return nil, err
+	}
+	return e.findPolicy(boardID)
+}
+
+func (e *escalationService) UpdatePolicy(userID uint64, boardID uint64, request *web.EscalationPolicyRequest) (*domain.EscalationPolicy, error) {
+	if err := e.authorizeBoard(userID, boardID); err != nil {
+		return nil, err
+	}
+	policy, err := e.findPolicy(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Enabled != nil {
+		policy.Enabled = *request.Enabled
+	}
+	if request.ManagerAfterHours != nil {
+		policy.ManagerAfterHours = *request.ManagerAfterHours
+	}
+	if request.OwnerAfterHours != nil {
+		policy.OwnerAfterHours = *request.OwnerAfterHours
+	}
+	if policy.ManagerAfterHours < 0 || policy.OwnerAfterHours < policy.ManagerAfterHours {
+		return nil, ErrInvalidEscalationPolicy
+	}
+
+	if err := e.escalationRepository.SavePolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// escalationLevels mengembalikan tahap eskalasi yang waktunya sudah tiba untuk tenggat yang sudah lewat, berurutan
+// dari employee ke owner. Tahap yang terlewat (misalnya aplikasi mati) tetap dikirim karena penerimanya berbeda
+func escalationLevels(policy *domain.EscalationPolicy, deadline time.Time, now time.Time) []string {
+	if !policy.Enabled || now.Before(deadline) {
+		return nil
+	}
+	levels := []string{domain.EscalationLevelEmployee}
+	if !now.Before(deadline.Add(time.Duration(policy.ManagerAfterHours) * time.Hour)) {
+		levels = append(levels, domain.EscalationLevelManager)
+	}
+	if !now.Before(deadline.Add(time.Duration(policy.OwnerAfterHours) * time.Hour)) {
+		levels = append(levels, domain.EscalationLevelOwner)
+	}
+	return levels
+}
+
+func escalationKey(taskID uint64, dueDate string, level string) string {
+	return fmt.Sprintf("%d/%s/%s", taskID, dueDate, level)
+}
+
+// EscalateOverdueTasks menjalankan aturan eskalasi board untuk task yang melewati tenggat project sementara
+// project-nya masih Working atau Undone: employee diberi tahu setelah tenggat, lalu manager dan owner setelah jarak
+// waktu aturan. Eskalasi task yang sudah Done atau tenggatnya diubah diselesaikan lebih dulu.
+// Mengembalikan jumlah tahap eskalasi yang dicatat
+func (e *escalationService) EscalateOverdueTasks() (int, error) {
+	now := time.Now()
+	if _, err := e.escalationRepository.ResolveEscalations(now); err != nil {
+		return 0, err
+	}
+
+	tasks, err := e.escalationRepository.FindOverdueTasks()
+	if err != nil {
+		return 0, err
+	}
+	deadlines := make(map[uint64]time.Time, len(tasks))
+	var taskIDs, boardIDs []uint64
+	for _, task := range tasks {
+		deadline, ok := dueDeadline(task.ProjectDueDate, e.location)
+		if !ok || now.Before(deadline) {
+			continue
+		}
+		deadlines[task.ID] = deadline
+		taskIDs = append(taskIDs, task.ID)
+		boardIDs = append(boardIDs, task.BoardID)
+	}
+	if len(taskIDs) == 0 {
+		return 0, nil
+	}
+
+	policies, err := e.escalationRepository.FindPolicies(boardIDs)
+	if err != nil {
+		return 0, err
+	}
+	boardPolicies := make(map[uint64]*domain.EscalationPolicy, len(policies))
+	for i := range policies {
+		boardPolicies[policies[i].BoardID] = &policies[i]
+	}
+	escalations, err := e.escalationRepository.FindEscalations(taskIDs)
+	if err != nil {
+		return 0, err
+	}
+	escalated := make(map[string]bool, len(escalations))
+	for _, escalation := range escalations {
+		escalated[escalationKey(escalation.TaskID, escalation.DueDate, escalation.Level)] = true
+	}
+
+	created := 0
+	for i := range tasks {
+		task := &tasks[i]
+		deadline, ok := deadlines[task.ID]
+		if !ok {
+			continue
+		}
+		policy, ok := boardPolicies[task.BoardID]
+		if !ok {
+			defaultPolicy := domain.DefaultEscalationPolicy(task.BoardID)
+			policy = &defaultPolicy
+		}
+
+		for _, level := range escalationLevels(policy, deadline, now) {
+			if escalated[escalationKey(task.ID, task.ProjectDueDate, level)] {
+				continue
+			}
+			ok, err := e.createEscalation(task, level)
+			if err != nil {
+				log.Printf("Failed to escalate overdue task %d to %s: %v", task.ID, level, err)
+				break
+			}
+			if ok {
+				created++
+			}
+		}
+	}
+	if created > 0 {
+		e.notificationService.Schedule()
+	}
+	return created, nil
+}
+
+func (e *escalationService) createEscalation(task *domain.Task, level string) (bool, error) {
+	ownerEmail, managerEmails, employeeEmails, _, nameTask, err := e.taskAndOwnerRepository.GetNameEmailsDescription(task.ID)
+	if err != nil {
+		return false, err
+	}
+	var recipients []string
+	switch level {
+	case domain.EscalationLevelEmployee:
+		recipients = employeeEmails
+	case domain.EscalationLevelManager:
+		recipients = managerEmails
+	case domain.EscalationLevelOwner:
+		if ownerEmail != "" {
+			recipients = []string{ownerEmail}
+		}
+	}
+
+	data := domain.EmailData{TaskName: nameTask, Value: task.ProjectDueDate, Status: level, Detail: task.ProjectStatus}
+	message, err := taskNotification("task.overdue_escalation", task.ID, recipients, "overdue_escalation", data)
+	if err != nil {
+		return false, err
+	}
+	escalation := &domain.TaskEscalation{
+		TaskID:     task.ID,
+		DueDate:    task.ProjectDueDate,
+		Level:      level,
+		Recipients: recipients,
+	}
+	return e.escalationRepository.CreateEscalation(escalation, message)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/model/web"
+	"mime/multipart"
+	"time"
+)
+
+var (
+	ErrFileNotFound        = errors.New("File not found")
+	ErrFileVersionNotFound = errors.New("File version not found")
+	ErrFileTooLarge        = errors.New("File too large")
+	ErrFileTypeNotAllowed  = errors.New("File type not allowed")
+	ErrTooManyFiles        = errors.New("Too many files")
+	ErrBoardNotFound       = errors.New("Board not found")
+	ErrBoardAccessDenied   = errors.New("Only for board owner or task members")
+	ErrNoFilesToArchive    = errors.New("No files to archive")
+	ErrThumbnailNotFound   = errors.New("Thumbnail not available")
+	ErrFileInfected        = errors.New("File is infected")
+	ErrFileAwaitingScan    = errors.New("File is awaiting virus scan")
+)
+
+// UploadedFile adalah file yang sudah tersimpan pada storage dan siap dicatat pada task
+type UploadedFile struct {
+	Key         string
+	FileName    string
+	Size        int64
+	ContentType string
+	Checksum    string
+	ScanStatus  string
+}
+
+// FileDownload berisi signed url, atau Body jika storage tidak mendukung signed url sehingga file harus di-stream
+type FileDownload struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	URL         string
+	ExpiresAt   time.Time
+	Body        io.ReadCloser
+}
+
+// ArchiveEntry adalah satu file di dalam arsip zip beserta path-nya di dalam arsip
+type ArchiveEntry struct {
+	Path     string
+	FileKey  string
+	Modified time.Time
+}
+
+// FileArchive adalah daftar file yang akan di-stream sebagai satu arsip zip, disusun sebelum response dikirim
+// agar kesalahan seperti task tanpa file masih bisa dikembalikan sebagai status http
+type FileArchive struct {
+	FileName string
+	Entries  []ArchiveEntry
+}
+
+// GarbageCollectReport adalah hasil satu kali pembersihan objek yang tidak lagi dipakai file manapun
+type GarbageCollectReport struct {
+	DryRun     bool
+	Scanned    int
+	Orphaned   []string
+	Removed    int
+	Reconciled int
+}
+
+type FileService interface {
+	UploadFiles(files []*multipart.FileHeader, boardID uint64, taskID uint64, fileType string) ([]UploadedFile, error)
+	DeleteFile(key string) error
+	TaskFileKeys(taskID uint64) ([]string, error)
+	BoardFileKeys(boardID uint64) ([]string, error)
+	ReleaseFiles(keys []string)
+	CollectGarbage(dryRun bool) (*GarbageCollectReport, error)
+	ValidateTaskMember(taskID uint, userID uint) error
+	DownloadFile(taskID uint64, fileID uint64, fileType string) (*FileDownload, error)
+	DownloadThumbnail(taskID uint64, fileID uint64, fileType string) (*FileDownload, error)
+	TaskArchive(taskID uint64) (*FileArchive, error)
+	BoardArchive(boardID uint64, userID uint) (*FileArchive, error)
+	WriteArchive(archive *FileArchive, w io.Writer) error
+	ValidateFileUploader(taskID uint, userID uint, fileType string) error
+	CheckUploadPolicy(fileName string, size int64, file io.ReaderAt, fileType string) (string, error)
+	CheckUploadSize(fileName string, size int64, fileType string) error
+	CheckFileCount(taskID uint64, fileType string, fileNames []string) error
+	ScanFile(taskID uint64, fileType string, fileName string, checksum string, content io.ReadSeeker) (string, error)
+	ScanPendingFiles() (int, error)
+	ListFileVersions(taskID uint64, fileID uint64, fileType string) ([]domain.FileVersion, error)
+	DownloadFileVersion(taskID uint64, fileID uint64, fileType string, version int) (*FileDownload, error)
+	RestoreFileVersion(taskID uint64, fileID uint64, fileType string, version int, userID uint64) (*domain.FileVersion, error)
+	DiffFileVersions(taskID uint64, fileID uint64, fileType string, from int, to int) (*web.FileVersionDiff, error)
+}
@@ -0,0 +1,963 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/model/web"
+	"manajemen_tugas_master/repository"
+	"mime/multipart"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultSignedURLExpiry = 15 * time.Minute
+	defaultGCGracePeriod   = 24 * time.Hour
+	defaultGCPrefix        = "boards/"
+
+	defaultUploadConcurrency = 4
+
+	scanBatchSize = 20
+)
+
+type fileService struct {
+	storage                helper.Storage
+	fileRepository         repository.FileRepository
+	taskAndOwnerRepository repository.TaskAndOwnerRepository
+	thumbnailService       ThumbnailService
+	notificationService    NotificationService
+	scanner                helper.Scanner
+	scanRequired           bool
+	urlExpiry              time.Duration
+	gcGracePeriod          time.Duration
+	gcPrefix               string
+	uploadPolicies         map[string]helper.UploadPolicy
+	uploadConcurrency      int
+}
+
+func NewFileService(storage helper.Storage, fileRepository repository.FileRepository, taskAndOwnerRepository repository.TaskAndOwnerRepository, thumbnailService ThumbnailService, notificationService NotificationService, scanner helper.Scanner) FileService {
+	urlExpiry := helper.DurationFromEnv("STORAGE_URL_EXPIRY", defaultSignedURLExpiry)
+	if urlExpiry == 0 {
+		urlExpiry = defaultSignedURLExpiry
+	}
+
+	gcPrefix := defaultGCPrefix
+	if value, ok := os.LookupEnv("STORAGE_GC_PREFIX"); ok {
+		gcPrefix = value
+	}
+
+	uploadPolicies := make(map[string]helper.UploadPolicy)
+	for fileType := range helper.DefaultUploadPolicies {
+		uploadPolicies[fileType] = helper.UploadPolicyFromEnv(fileType)
+	}
+
+	uploadConcurrency := helper.IntFromEnv("UPLOAD_CONCURRENCY", defaultUploadConcurrency)
+	if uploadConcurrency == 0 {
+		uploadConcurrency = defaultUploadConcurrency
+	}
+
+	return &fileService{
+		storage:                storage,
+		fileRepository:         fileRepository,
+		taskAndOwnerRepository: taskAndOwnerRepository,
+		thumbnailService:       thumbnailService,
+		notificationService:    notificationService,
+		scanner:                scanner,
+		scanRequired:           helper.BoolFromEnv("SCANNER_REQUIRED", false),
+		urlExpiry:              urlExpiry,
+		gcGracePeriod:          helper.DurationFromEnv("STORAGE_GC_GRACE_PERIOD", defaultGCGracePeriod),
+		gcPrefix:               gcPrefix,
+		uploadPolicies:         uploadPolicies,
+		uploadConcurrency:      uploadConcurrency,
+	}
+}
+
+// preparedUpload adalah file yang sudah lolos upload policy beserta hash isinya, belum menyentuh storage
+type preparedUpload struct {
+	file        *multipart.FileHeader
+	contentType string
+	checksum    string
+	size        int64
+	scanStatus  string
+}
+
+// UploadFiles memeriksa semua file terhadap upload policy sebelum ada yang disimpan, lalu meng-upload file secara bersamaan.
+// Jika salah satu gagal, file yang sudah ter-upload dilepas kembali sehingga tidak ada yang tersimpan sebagian.
+// File dengan isi yang sama tidak di-upload ulang, melainkan memakai objek yang sudah ada dengan menambah jumlah referensinya
+func (f *fileService) UploadFiles(files []*multipart.FileHeader, boardID uint64, taskID uint64, fileType string) ([]UploadedFile, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	fileNames := make([]string, 0, len(files))
+	for _, file := range files {
+		fileNames = append(fileNames, file.Filename)
+	}
+	if err := f.CheckFileCount(taskID, fileType, fileNames); err != nil {
+		return nil, err
+	}
+
+	prepared := make([]preparedUpload, len(files))
+	errs := make([]error, len(files))
+	f.runConcurrently(len(files), func(i int) {
+		prepared[i], errs[i] = f.prepareUpload(files[i], taskID, fileType)
+	})
+	if err := firstError(errs); err != nil {
+		return nil, err
+	}
+
+	// isi yang sama pada satu request cukup di-upload sekali, sisanya hanya menambah referensi setelah upload pertama selesai
+	var unique, duplicates []int
+	seen := make(map[string]bool)
+	for i, upload := range prepared {
+		if seen[upload.checksum] {
+			duplicates = append(duplicates, i)
+			continue
+		}
+		seen[upload.checksum] = true
+		unique = append(unique, i)
+	}
+
+	uploaded := make([]UploadedFile, len(files))
+	f.runConcurrently(len(unique), func(n int) {
+		i := unique[n]
+		uploaded[i].Key, uploaded[i].ScanStatus, errs[i] = f.storeUpload(prepared[i], boardID, taskID, fileType)
+	})
+	for _, i := range duplicates {
+		if firstError(errs) != nil {
+			break
+		}
+		var object *domain.StoredObject
+		object, errs[i] = f.fileRepository.AcquireObject(&domain.StoredObject{Checksum: prepared[i].checksum})
+		if errs[i] == nil {
+			uploaded[i].Key = object.ObjectKey
+			uploaded[i].ScanStatus = object.ScanStatus
+		}
+	}
+	for i, upload := range prepared {
+		uploaded[i].FileName = upload.file.Filename
+		uploaded[i].Size = upload.size
+		uploaded[i].ContentType = upload.contentType
+		uploaded[i].Checksum = upload.checksum
+	}
+
+	if err := firstError(errs); err != nil {
+		var keys []string
+		for i, file := range uploaded {
+			if file.Key != "" && errs[i] == nil {
+				keys = append(keys, file.Key)
+			}
+		}
+		f.ReleaseFiles(keys)
+		return nil, err
+	}
+
+	return uploaded, nil
+}
+
+func (f *fileService) prepareUpload(file *multipart.FileHeader, taskID uint64, fileType string) (preparedUpload, error) {
+	openFile, err := file.Open()
+	if err != nil {
+		return preparedUpload{}, err
+	}
+	defer openFile.Close()
+
+	contentType, err := f.CheckUploadPolicy(file.Filename, file.Size, openFile, fileType)
+	if err != nil {
+		return preparedUpload{}, err
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, openFile)
+	if err != nil {
+		return preparedUpload{}, err
+	}
+	checksum := hex.EncodeToString(hash.Sum(nil))
+
+	scanStatus, err := f.ScanFile(taskID, fileType, file.Filename, checksum, openFile)
+	if err != nil {
+		return preparedUpload{}, err
+	}
+
+	return preparedUpload{
+		file:        file,
+		contentType: contentType,
+		checksum:    checksum,
+		size:        size,
+		scanStatus:  scanStatus,
+	}, nil
+}
+
+// storeUpload mencatat stored object dan meng-upload isinya ke storage jika objek tersebut baru,
+// status pemindaian yang dikembalikan adalah status objek setelah digabung dengan hasil pemindaian upload ini
+func (f *fileService) storeUpload(upload preparedUpload, boardID uint64, taskID uint64, fileType string) (string, string, error) {
+	object, err := f.fileRepository.AcquireObject(&domain.StoredObject{
+		ObjectKey:       helper.ObjectKey(boardID, taskID, fileType, upload.checksum, upload.file.Filename),
+		Checksum:        upload.checksum,
+		Size:            upload.size,
+		ContentType:     upload.contentType,
+		ThumbnailStatus: thumbnailStatus(upload.contentType),
+		ScanStatus:      upload.scanStatus,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	// isi yang sama pernah terdeteksi terinfeksi saat scanner sedang tidak tersedia untuk upload ini
+	if object.ScanStatus == domain.ScanStatusInfected {
+		f.ReleaseFiles([]string{object.ObjectKey})
+		return "", "", fmt.Errorf("%w: %s was previously quarantined", ErrFileInfected, upload.file.Filename)
+	}
+
+	// objek baru, upload isi file ke storage
+	if object.RefCount == 1 {
+		err := func() error {
+			openFile, err := upload.file.Open()
+			if err != nil {
+				return err
+			}
+			defer openFile.Close()
+			return f.storage.Put(context.TODO(), object.ObjectKey, openFile, upload.contentType)
+		}()
+		if err != nil {
+			if _, releaseErr := f.fileRepository.ReleaseObject(object.ObjectKey); releaseErr != nil {
+				log.Printf("Failed to release object %s: %v", object.ObjectKey, releaseErr)
+			}
+			return "", "", err
+		}
+		if object.ThumbnailStatus == domain.ThumbnailStatusPending {
+			f.thumbnailService.Schedule()
+		}
+	}
+
+	return object.ObjectKey, object.ScanStatus, nil
+}
+
+// ScanFile memindai isi file sebelum file disimpan. File terinfeksi dikarantina, owner task diberi tahu dan ErrFileInfected dikembalikan.
+// Jika scanner tidak tersedia file tetap diterima dengan status pending dan dipindai ulang oleh worker, kecuali SCANNER_REQUIRED=true
+func (f *fileService) ScanFile(taskID uint64, fileType string, fileName string, checksum string, content io.ReadSeeker) (string, error) {
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	result, err := f.scanner.Scan(context.TODO(), content)
+	if err != nil {
+		if f.scanRequired {
+			return "", err
+		}
+		log.Printf("Failed to scan %s, the file will be scanned later: %v", fileName, err)
+		return domain.ScanStatusPending, nil
+	}
+	if result.Skipped {
+		return domain.ScanStatusSkipped, nil
+	}
+	if !result.Infected {
+		return domain.ScanStatusClean, nil
+	}
+
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	f.quarantineFile(content, checksum, result.Signature, []domain.TaskFile{{TaskID: taskID, FileType: fileType, FileName: fileName}})
+	return "", fmt.Errorf("%w: %s contains %s and has been quarantined", ErrFileInfected, fileName, result.Signature)
+}
+
+// quarantineFile menyimpan isi file terinfeksi di bawah prefix quarantine, mencatatnya per file dan memberi tahu owner task.
+// Kegagalan hanya dicatat karena file tetap ditolak
+func (f *fileService) quarantineFile(content io.Reader, checksum string, signature string, files []domain.TaskFile) {
+	key := helper.QuarantineKey(checksum, files[0].FileName)
+	if err := f.storage.Put(context.TODO(), key, content, "application/octet-stream"); err != nil {
+		log.Printf("Failed to quarantine %s: %v", key, err)
+	}
+
+	for _, file := range files {
+		if err := f.fileRepository.CreateQuarantinedFile(&domain.QuarantinedFile{
+			TaskID:    file.TaskID,
+			FileType:  file.FileType,
+			FileName:  file.FileName,
+			ObjectKey: key,
+			Checksum:  checksum,
+			Signature: signature,
+		}); err != nil {
+			log.Printf("Failed to record quarantined file %s: %v", file.FileName, err)
+		}
+		f.notifyInfectedFile(file.TaskID, file.FileName, signature)
+	}
+}
+
+func (f *fileService) notifyInfectedFile(taskID uint64, fileName string, signature string) {
+	ownerEmail, _, _, _, nameTask, err := f.taskAndOwnerRepository.GetNameEmailsDescription(taskID)
+	if err != nil {
+		log.Printf("Failed to find owner of task %d: %v", taskID, err)
+		return
+	}
+
+	data := domain.EmailData{TaskName: nameTask, Value: fileName, Detail: signature}
+	message, err := taskNotification("file.quarantined", taskID, []string{ownerEmail}, "file_quarantined", data)
+	if err != nil {
+		log.Printf("Failed to queue infected file notification: %v", err)
+		return
+	}
+	if err := f.notificationService.Enqueue(message); err != nil {
+		log.Printf("Failed to queue infected file notification: %v", err)
+	}
+}
+
+// ScanPendingFiles memindai ulang objek yang diterima saat scanner tidak tersedia. Objek terinfeksi dikarantina
+// dan dihapus dari storage, file yang memakainya tetap ada dengan status infected sehingga tidak bisa diunduh
+func (f *fileService) ScanPendingFiles() (int, error) {
+	scanned := 0
+	for {
+		objects, err := f.fileRepository.FindPendingScans(scanBatchSize)
+		if err != nil {
+			return scanned, err
+		}
+		if len(objects) == 0 {
+			return scanned, nil
+		}
+
+		for _, object := range objects {
+			if err := f.scanPendingObject(object); err != nil {
+				// scanner masih belum tersedia, sisanya dicoba lagi pada jadwal berikutnya
+				return scanned, err
+			}
+			scanned++
+		}
+	}
+}
+
+func (f *fileService) scanPendingObject(object domain.StoredObject) error {
+	body, err := f.storage.Get(context.TODO(), object.ObjectKey)
+	if errors.Is(err, helper.ErrObjectNotFound) {
+		return f.fileRepository.UpdateScanStatus(object.ObjectKey, domain.ScanStatusSkipped)
+	}
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	// isi objek disalin ke file sementara karena dibaca dua kali jika harus dikarantina
+	tmp, err := os.CreateTemp("", "scan-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	if _, err := io.Copy(tmp, body); err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	result, err := f.scanner.Scan(context.TODO(), tmp)
+	if err != nil {
+		return err
+	}
+	switch {
+	case result.Skipped:
+		return f.fileRepository.UpdateScanStatus(object.ObjectKey, domain.ScanStatusSkipped)
+	case !result.Infected:
+		return f.fileRepository.UpdateScanStatus(object.ObjectKey, domain.ScanStatusClean)
+	}
+
+	files, err := f.fileRepository.FindObjectTaskFiles(object.ObjectKey)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		files = []domain.TaskFile{{FileName: path.Base(object.ObjectKey)}}
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	f.quarantineFile(tmp, object.Checksum, result.Signature, files)
+
+	if err := f.fileRepository.UpdateScanStatus(object.ObjectKey, domain.ScanStatusInfected); err != nil {
+		return err
+	}
+	if err := f.storage.Delete(context.TODO(), helper.ThumbnailKey(object.ObjectKey)); err != nil {
+		log.Printf("Failed to delete thumbnail of %s: %v", object.ObjectKey, err)
+	}
+	return f.storage.Delete(context.TODO(), object.ObjectKey)
+}
+
+// runConcurrently menjalankan fn untuk setiap index dengan paling banyak UPLOAD_CONCURRENCY goroutine sekaligus
+func (f *fileService) runConcurrently(n int, fn func(i int)) {
+	semaphore := make(chan struct{}, f.uploadConcurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckUploadPolicy mengembalikan content type hasil deteksi isi file jika file lolos policy jenis file tersebut
+func (f *fileService) CheckUploadPolicy(fileName string, size int64, file io.ReaderAt, fileType string) (string, error) {
+	if err := f.CheckUploadSize(fileName, size, fileType); err != nil {
+		return "", err
+	}
+
+	policy := f.uploadPolicies[fileType]
+	contentType := helper.SniffContentType(file, size, fileName)
+	if !policy.Allows(contentType) {
+		return "", fmt.Errorf("%w: %s is %s, allowed types for %s are %s", ErrFileTypeNotAllowed, fileName, contentType, fileType, strings.Join(policy.AllowedTypes, ", "))
+	}
+
+	return contentType, nil
+}
+
+// CheckUploadSize memeriksa ukuran file terhadap policy, dipakai juga sebelum isi file tersedia seperti saat resumable upload dimulai
+func (f *fileService) CheckUploadSize(fileName string, size int64, fileType string) error {
+	policy, ok := f.uploadPolicies[fileType]
+	if !ok {
+		return fmt.Errorf("invalid file type %q", fileType)
+	}
+
+	if size > policy.MaxSize {
+		return fmt.Errorf("%w: %s exceeds the maximum size of %d bytes", ErrFileTooLarge, fileName, policy.MaxSize)
+	}
+
+	return nil
+}
+
+// CheckFileCount memastikan jumlah file pada task tidak melebihi batas setelah semua file pada request ditambahkan.
+// Upload ulang planning file atau project file dengan nama yang sama menjadi versi baru, bukan file tambahan
+func (f *fileService) CheckFileCount(taskID uint64, fileType string, fileNames []string) error {
+	policy, ok := f.uploadPolicies[fileType]
+	if !ok {
+		return fmt.Errorf("invalid file type %q", fileType)
+	}
+	if policy.MaxFilesPerTask == 0 {
+		return nil
+	}
+
+	versioned := fileType == domain.FileTypePlanning || fileType == domain.FileTypeProject
+	added := 0
+	names := make(map[string]bool)
+	for _, fileName := range fileNames {
+		if versioned {
+			if names[fileName] {
+				continue
+			}
+			names[fileName] = true
+
+			exists, err := f.fileRepository.TaskFileExists(taskID, fileType, fileName)
+			if err != nil {
+				return err
+			}
+			if exists {
+				continue
+			}
+		}
+		added++
+	}
+	if added == 0 {
+		return nil
+	}
+
+	count, err := f.fileRepository.CountTaskFiles(taskID, fileType)
+	if err != nil {
+		return err
+	}
+	if count+int64(added) > int64(policy.MaxFilesPerTask) {
+		return fmt.Errorf("%w: a task can have at most %d %s, it already has %d", ErrTooManyFiles, policy.MaxFilesPerTask, fileType, count)
+	}
+
+	return nil
+}
+
+// DeleteFile melepas satu referensi objek, blob pada storage hanya dihapus jika sudah tidak ada file yang memakainya
+func (f *fileService) DeleteFile(key string) error {
+	remove, err := f.fileRepository.ReleaseObject(key)
+	if err != nil {
+		return err
+	}
+	if !remove {
+		return nil
+	}
+
+	if err := f.storage.Delete(context.TODO(), helper.ThumbnailKey(key)); err != nil {
+		log.Printf("Failed to delete thumbnail of %s: %v", key, err)
+	}
+	return f.storage.Delete(context.TODO(), key)
+}
+
+func (f *fileService) TaskFileKeys(taskID uint64) ([]string, error) {
+	return f.fileRepository.FindTaskFileKeys(taskID)
+}
+
+func (f *fileService) BoardFileKeys(boardID uint64) ([]string, error) {
+	return f.fileRepository.FindBoardFileKeys(boardID)
+}
+
+// ReleaseFiles melepas referensi file yang record-nya sudah dihapus, kegagalan hanya dicatat karena sisa objek akan dibersihkan garbage collector
+func (f *fileService) ReleaseFiles(keys []string) {
+	for _, key := range keys {
+		if err := f.DeleteFile(key); err != nil {
+			log.Printf("Failed to delete object %s: %v", key, err)
+		}
+	}
+}
+
+// CollectGarbage mencocokkan isi storage dengan database, menghapus objek di bawah STORAGE_GC_PREFIX yang tidak dipakai file manapun
+// dan menyesuaikan jumlah referensi stored object. Pada mode dry-run tidak ada yang diubah, hanya dilaporkan
+func (f *fileService) CollectGarbage(dryRun bool) (*GarbageCollectReport, error) {
+	report := &GarbageCollectReport{DryRun: dryRun}
+	// objek yang lebih baru dari grace period bisa jadi sedang di-upload dan belum tercatat pada file
+	cutoff := time.Now().Add(-f.gcGracePeriod)
+
+	references, err := f.fileRepository.CountFileReferences()
+	if err != nil {
+		return nil, err
+	}
+
+	storedObjects, err := f.fileRepository.FindStoredObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	orphaned := make(map[string]bool)
+	for _, object := range storedObjects {
+		refCount := references[object.ObjectKey]
+		if refCount == object.RefCount || object.CreatedAt.After(cutoff) {
+			continue
+		}
+		if refCount == 0 {
+			orphaned[object.ObjectKey] = true
+			continue
+		}
+		report.Reconciled++
+		if !dryRun {
+			if err := f.fileRepository.UpdateObjectRefCount(object.ObjectKey, refCount); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// hanya objek di bawah prefix aplikasi yang diperiksa, bucket bisa dipakai bersama aplikasi lain
+	objects, err := f.storage.List(context.TODO(), f.gcPrefix)
+	if err != nil {
+		return nil, err
+	}
+	report.Scanned = len(objects)
+	for _, object := range objects {
+		// thumbnail dipakai selama objek aslinya dipakai
+		key := object.Key
+		if source, ok := helper.ThumbnailSourceKey(object.Key); ok {
+			key = source
+		}
+		if references[key] == 0 && object.LastModified.Before(cutoff) {
+			orphaned[object.Key] = true
+		}
+	}
+
+	for key := range orphaned {
+		report.Orphaned = append(report.Orphaned, key)
+		if dryRun {
+			continue
+		}
+		if err := f.storage.Delete(context.TODO(), key); err != nil {
+			log.Printf("Failed to delete orphaned object %s: %v", key, err)
+			continue
+		}
+		if err := f.fileRepository.DeleteStoredObject(key); err != nil {
+			log.Printf("Failed to delete stored object %s: %v", key, err)
+			continue
+		}
+		report.Removed++
+	}
+	sort.Strings(report.Orphaned)
+
+	return report, nil
+}
+
+func (f *fileService) ValidateTaskMember(taskID uint, userID uint) error {
+	return f.taskAndOwnerRepository.ValidationTaskMember(taskID, userID)
+}
+
+func (f *fileService) DownloadFile(taskID uint64, fileID uint64, fileType string) (*FileDownload, error) {
+	fileKey, fileName, err := f.findTaskFile(taskID, fileID, fileType)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.download(fileKey, fileName)
+}
+
+// DownloadThumbnail mengembalikan thumbnail file seperti DownloadFile, ErrThumbnailNotFound jika thumbnail belum atau tidak bisa dibuat
+func (f *fileService) DownloadThumbnail(taskID uint64, fileID uint64, fileType string) (*FileDownload, error) {
+	fileKey, fileName, err := f.findTaskFile(taskID, fileID, fileType)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnailKey := helper.ThumbnailKey(fileKey)
+	if _, err := f.storage.Stat(context.TODO(), thumbnailKey); err != nil {
+		if errors.Is(err, helper.ErrObjectNotFound) {
+			return nil, ErrThumbnailNotFound
+		}
+		return nil, err
+	}
+
+	return f.download(thumbnailKey, strings.TrimSuffix(fileName, path.Ext(fileName))+".png")
+}
+
+// archiveFolders adalah folder di dalam arsip zip untuk setiap jenis file
+var archiveFolders = map[string]string{
+	domain.FileTypePlanningDescription: "planning-description",
+	domain.FileTypePlanning:            "planning",
+	domain.FileTypeProject:             "project",
+}
+
+func (f *fileService) TaskArchive(taskID uint64) (*FileArchive, error) {
+	files, err := f.fileRepository.FindTaskFiles([]uint64{taskID})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, ErrNoFilesToArchive
+	}
+
+	return &FileArchive{
+		FileName: fmt.Sprintf("task-%d-files.zip", taskID),
+		Entries:  archiveEntries(files, nil),
+	}, nil
+}
+
+// BoardArchive berisi file dari task pada board yang boleh diakses user, pembuat board boleh mengakses semua task
+func (f *fileService) BoardArchive(boardID uint64, userID uint) (*FileArchive, error) {
+	board, err := f.fileRepository.FindBoardWithTasks(boardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+
+	taskFolders := make(map[uint64]string)
+	var taskIDs []uint64
+	for _, task := range board.Tasks {
+		if board.UserID != uint64(userID) {
+			if err := f.taskAndOwnerRepository.ValidationTaskMember(uint(task.ID), userID); err != nil {
+				continue
+			}
+		}
+		taskIDs = append(taskIDs, task.ID)
+		taskFolders[task.ID] = fmt.Sprintf("%d-%s", task.ID, archiveName(strings.NewReplacer("/", "-", "\\", "-").Replace(task.NameTask), "task"))
+	}
+	if len(taskIDs) == 0 {
+		if board.UserID != uint64(userID) {
+			return nil, ErrBoardAccessDenied
+		}
+		return nil, ErrNoFilesToArchive
+	}
+
+	files, err := f.fileRepository.FindTaskFiles(taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, ErrNoFilesToArchive
+	}
+
+	return &FileArchive{
+		FileName: fmt.Sprintf("board-%d-files.zip", boardID),
+		Entries:  archiveEntries(files, taskFolders),
+	}, nil
+}
+
+// archiveEntries menyusun path file di dalam arsip per jenis file, dan per task jika taskFolders diisi.
+// Nama file yang sama di dalam satu folder diberi nomor agar tidak saling menimpa
+func archiveEntries(files []domain.TaskFile, taskFolders map[uint64]string) []ArchiveEntry {
+	entries := make([]ArchiveEntry, 0, len(files))
+	used := make(map[string]bool)
+	for _, file := range files {
+		dir := archiveFolders[file.FileType]
+		if taskFolders != nil {
+			dir = taskFolders[file.TaskID] + "/" + dir
+		}
+
+		name := archiveName(file.FileName, "file")
+		entryPath := dir + "/" + name
+		ext := path.Ext(name)
+		for n := 2; used[entryPath]; n++ {
+			entryPath = fmt.Sprintf("%s/%s (%d)%s", dir, strings.TrimSuffix(name, ext), n, ext)
+		}
+		used[entryPath] = true
+
+		entries = append(entries, ArchiveEntry{Path: entryPath, FileKey: file.FileKey, Modified: file.CreatedAt})
+	}
+	return entries
+}
+
+// archiveName membuang path dari nama file agar entri arsip tidak bisa keluar dari foldernya
+func archiveName(name string, fallback string) string {
+	name = strings.TrimSpace(path.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == ".." || name == "/" {
+		return fallback
+	}
+	return name
+}
+
+// WriteArchive menulis arsip zip langsung ke w, file dibaca satu per satu dari storage sehingga arsip tidak pernah ditampung utuh di memori.
+// Response sudah terkirim sebagian saat menulis, sehingga objek yang hilang dari storage hanya dicatat dan dilewati
+func (f *fileService) WriteArchive(archive *FileArchive, w io.Writer) error {
+	zipWriter := zip.NewWriter(w)
+	for _, entry := range archive.Entries {
+		if err := f.writeArchiveEntry(zipWriter, entry); err != nil {
+			if errors.Is(err, helper.ErrObjectNotFound) {
+				log.Printf("Skipping missing object %s in archive %s", entry.FileKey, archive.FileName)
+				continue
+			}
+			return err
+		}
+
+		if flusher, ok := w.(interface{ Flush() error }); ok {
+			if err := flusher.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return zipWriter.Close()
+}
+
+func (f *fileService) writeArchiveEntry(zipWriter *zip.Writer, entry ArchiveEntry) error {
+	body, err := f.storage.Get(context.TODO(), entry.FileKey)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	header := &zip.FileHeader{Name: entry.Path, Method: zip.Deflate}
+	if !entry.Modified.IsZero() {
+		header.Modified = entry.Modified
+	}
+	writer, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(writer, body)
+	return err
+}
+
+// download membuat signed url untuk objek, atau membuka objek untuk di-stream jika storage tidak mendukung signed url
+func (f *fileService) download(fileKey string, fileName string) (*FileDownload, error) {
+	scanStatus, err := f.fileRepository.FindObjectScanStatus(fileKey)
+	if err != nil {
+		return nil, err
+	}
+	switch scanStatus {
+	case domain.ScanStatusPending:
+		return nil, ErrFileAwaitingScan
+	case domain.ScanStatusInfected:
+		return nil, ErrFileInfected
+	}
+
+	url, err := f.storage.SignedURL(context.TODO(), fileKey, fileName, f.urlExpiry)
+	if err == nil {
+		return &FileDownload{
+			FileName:  fileName,
+			URL:       url,
+			ExpiresAt: time.Now().Add(f.urlExpiry),
+		}, nil
+	}
+	if !errors.Is(err, helper.ErrSignedURLNotSupported) {
+		return nil, err
+	}
+
+	// storage tidak mendukung signed url, file di-stream langsung oleh server
+	info, err := f.storage.Stat(context.TODO(), fileKey)
+	if err != nil {
+		if errors.Is(err, helper.ErrObjectNotFound) {
+			return nil, ErrFileNotFound
+		}
+		return nil, err
+	}
+	body, err := f.storage.Get(context.TODO(), fileKey)
+	if err != nil {
+		if errors.Is(err, helper.ErrObjectNotFound) {
+			return nil, ErrFileNotFound
+		}
+		return nil, err
+	}
+
+	return &FileDownload{
+		FileName:    fileName,
+		ContentType: info.ContentType,
+		Size:        info.Size,
+		Body:        body,
+	}, nil
+}
+
+// ValidateFileUploader memastikan user boleh mengubah file dengan jenis tersebut, sama dengan aturan upload pada update task
+func (f *fileService) ValidateFileUploader(taskID uint, userID uint, fileType string) error {
+	switch fileType {
+	case domain.FileTypePlanning:
+		return f.taskAndOwnerRepository.UpdateValidationManager(taskID, userID)
+	case domain.FileTypeProject:
+		return f.taskAndOwnerRepository.UpdateValidationEmployee(taskID, userID)
+	default:
+		return f.taskAndOwnerRepository.UpdateValidationOwner(taskID, userID)
+	}
+}
+
+func (f *fileService) ListFileVersions(taskID uint64, fileID uint64, fileType string) ([]domain.FileVersion, error) {
+	fileKey, fileName, err := f.findTaskFile(taskID, fileID, fileType)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := f.fileRepository.FindFileVersions(fileType, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	// file yang di-upload sebelum ada riwayat versi hanya memiliki satu versi
+	if len(versions) == 0 {
+		versions = append(versions, domain.FileVersion{FileType: fileType, FileID: fileID, Version: 1, FileKey: fileKey, FileName: fileName})
+	}
+	for i := range versions {
+		versions[i].FileUrl = helper.FileVersionDownloadURL(taskID, fileID, versions[i].Version, fileType)
+	}
+
+	return versions, nil
+}
+
+func (f *fileService) DownloadFileVersion(taskID uint64, fileID uint64, fileType string, version int) (*FileDownload, error) {
+	fileVersion, err := f.findFileVersion(taskID, fileID, fileType, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.download(fileVersion.FileKey, fileVersion.FileName)
+}
+
+func (f *fileService) RestoreFileVersion(taskID uint64, fileID uint64, fileType string, version int, userID uint64) (*domain.FileVersion, error) {
+	fileVersion, err := f.findFileVersion(taskID, fileID, fileType, version)
+	if err != nil {
+		return nil, err
+	}
+
+	currentKey, _, err := f.findTaskFile(taskID, fileID, fileType)
+	if err != nil {
+		return nil, err
+	}
+	if currentKey == fileVersion.FileKey {
+		return nil, errors.New("Version is already the current version")
+	}
+
+	restored, err := f.fileRepository.RestoreFileVersion(fileType, fileID, fileVersion.Version, userID)
+	if err != nil {
+		return nil, err
+	}
+	restored.FileUrl = helper.FileVersionDownloadURL(taskID, fileID, restored.Version, fileType)
+
+	return restored, nil
+}
+
+func (f *fileService) DiffFileVersions(taskID uint64, fileID uint64, fileType string, from int, to int) (*web.FileVersionDiff, error) {
+	fromVersion, err := f.findFileVersion(taskID, fileID, fileType, from)
+	if err != nil {
+		return nil, err
+	}
+	toVersion, err := f.findFileVersion(taskID, fileID, fileType, to)
+	if err != nil {
+		return nil, err
+	}
+	fromVersion.FileUrl = helper.FileVersionDownloadURL(taskID, fileID, fromVersion.Version, fileType)
+	toVersion.FileUrl = helper.FileVersionDownloadURL(taskID, fileID, toVersion.Version, fileType)
+
+	return &web.FileVersionDiff{
+		From:               *fromVersion,
+		To:                 *toVersion,
+		SizeDelta:          toVersion.Size - fromVersion.Size,
+		ContentChanged:     fromVersion.Checksum != toVersion.Checksum || fromVersion.FileKey != toVersion.FileKey,
+		ContentTypeChanged: fromVersion.ContentType != toVersion.ContentType,
+		FileNameChanged:    fromVersion.FileName != toVersion.FileName,
+		UploaderChanged:    fromVersion.UploadedBy != toVersion.UploadedBy,
+		TimeBetween:        toVersion.CreatedAt.Sub(fromVersion.CreatedAt).String(),
+	}, nil
+}
+
+func (f *fileService) findFileVersion(taskID uint64, fileID uint64, fileType string, version int) (*domain.FileVersion, error) {
+	fileKey, fileName, err := f.findTaskFile(taskID, fileID, fileType)
+	if err != nil {
+		return nil, err
+	}
+
+	fileVersion, err := f.fileRepository.FindFileVersion(fileType, fileID, version)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// file lama tanpa riwayat versi, versi 1 adalah file itu sendiri
+		if version == 1 {
+			return &domain.FileVersion{FileType: fileType, FileID: fileID, Version: 1, FileKey: fileKey, FileName: fileName}, nil
+		}
+		return nil, ErrFileVersionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return fileVersion, nil
+}
+
+// findTaskFile mencari file pada task, jika jenis file tidak diisi maka semua jenis file dicoba
+func (f *fileService) findTaskFile(taskID uint64, fileID uint64, fileType string) (string, string, error) {
+	fileTypes := []string{fileType}
+	if fileType == "" {
+		fileTypes = []string{domain.FileTypePlanningDescription, domain.FileTypePlanning, domain.FileTypeProject}
+	}
+
+	var fileKey, fileName string
+	found := 0
+	for _, fileType := range fileTypes {
+		key, name, err := f.fileRepository.FindTaskFile(taskID, fileID, fileType)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", "", err
+		}
+		fileKey, fileName = key, name
+		found++
+	}
+
+	switch {
+	case found == 0:
+		return "", "", ErrFileNotFound
+	case found > 1:
+		return "", "", fmt.Errorf("File id %d is ambiguous, set the type query to one of %s, %s or %s", fileID, domain.FileTypePlanningDescription, domain.FileTypePlanning, domain.FileTypeProject)
+	}
+	if fileKey == "" {
+		return "", "", ErrFileNotFound
+	}
+
+	return fileKey, fileName, nil
+}
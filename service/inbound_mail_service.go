@@ -0,0 +1,24 @@
+package service
+
+import (
+	"errors"
+	"manajemen_tugas_master/model/web"
+)
+
+var (
+	ErrReplyDisabled       = errors.New("Reply by email is not enabled")
+	ErrInvalidInboundToken = errors.New("Invalid inbound mail token")
+	ErrInvalidReplyAddress = errors.New("No valid reply address found in the recipients")
+	ErrReplySenderMismatch = errors.New("The sender is not the user the reply address was sent to")
+	ErrEmptyReply          = errors.New("The reply is empty after removing the quoted email")
+	ErrReplyTooLong        = errors.New("The reply is too long")
+	ErrAutomaticReply      = errors.New("Automatic replies are ignored")
+
+	// INBOUND_MAIL_TOKEN belum diisi sehingga email masuk tidak bisa diautentikasi
+	ErrInboundMailNotConfigured = errors.New("Inbound mail is not configured")
+)
+
+type InboundMailService interface {
+	Authorize(token string) error
+	ReceiveEmail(raw []byte, recipients []string) (*web.InboundMailResponse, error)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"crypto/subtle"
+	"errors"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/web"
+	"manajemen_tugas_master/repository"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// batas panjang balasan email yang disimpan sebagai komentar
+const replyCommentLimit = 10000
+
+type inboundMailService struct {
+	userRepository      repository.UserRepository
+	taskAndOwnerService TaskAndOwnerService
+	token               string
+	// allowUnauthenticated hanya untuk development, endpoint menerima email tanpa token
+	allowUnauthenticated bool
+}
+
+func NewInboundMailService(userRepository repository.UserRepository, taskAndOwnerService TaskAndOwnerService) InboundMailService {
+	return &inboundMailService{
+		userRepository:       userRepository,
+		taskAndOwnerService:  taskAndOwnerService,
+		token:                os.Getenv("INBOUND_MAIL_TOKEN"),
+		allowUnauthenticated: helper.BoolFromEnv("INBOUND_MAIL_ALLOW_UNAUTHENTICATED", false),
+	}
+}
+
+// Authorize memeriksa token endpoint email masuk. Tanpa INBOUND_MAIL_TOKEN endpoint ditolak, kecuali
+// INBOUND_MAIL_ALLOW_UNAUTHENTICATED diaktifkan untuk development
+func (i *inboundMailService) Authorize(token string) error {
+	if i.token == "" {
+		if i.allowUnauthenticated {
+			return nil
+		}
+		return ErrInboundMailNotConfigured
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(i.token)) != 1 {
+		return ErrInvalidInboundToken
+	}
+	return nil
+}
+
+// ReceiveEmail menambahkan balasan email sebagai komentar task. Task dan user dibaca dari alamat balasan bertanda
+// tangan pada penerima envelope atau header email, pengirimnya harus user pemilik alamat tersebut dan masih menjadi
+// anggota task. Kutipan email sebelumnya dibuang dan balasan otomatis diabaikan
+func (i *inboundMailService) ReceiveEmail(raw []byte, recipients []string) (*web.InboundMailResponse, error) {
+	if !helper.ReplyEnabled() {
+		return nil, ErrReplyDisabled
+	}
+	mail, err := helper.ParseInboundMail(raw)
+	if err != nil {
+		return nil, err
+	}
+	if mail.AutoReply {
+		return nil, ErrAutomaticReply
+	}
+
+	var taskID, userID uint64
+	found := false
+	for _, recipient := range slices.Concat(recipients, mail.Recipients) {
+		if taskID, userID, found = helper.ParseReplyAddress(recipient); found {
+			break
+		}
+	}
+	if !found {
+		return nil, ErrInvalidReplyAddress
+	}
+
+	user, err := i.userRepository.FindById(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidReplyAddress
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(user.Email, mail.From) {
+		return nil, ErrReplySenderMismatch
+	}
+
+	comment := helper.StripQuotedReply(mail.Text)
+	if comment == "" {
+		return nil, ErrEmptyReply
+	}
+	if utf8.RuneCountInString(comment) > replyCommentLimit {
+		return nil, ErrReplyTooLong
+	}
+
+	if err := i.taskAndOwnerService.AddCommentReply(taskID, user, comment); err != nil {
+		return nil, err
+	}
+	return &web.InboundMailResponse{TaskID: taskID, UserID: userID, Comment: comment}, nil
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"errors"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/model/web"
+)
+
+var (
+	ErrNotificationNotFound      = errors.New("Notification not found")
+	ErrNotificationNotRetryable  = errors.New("Notification has already been sent or is being sent")
+	ErrInvalidNotificationStatus = errors.New("Invalid notification status")
+	ErrEmailTemplateNotFound     = errors.New("Email template not found")
+	ErrUnsupportedLocale         = errors.New("Unsupported locale")
+	ErrInvalidNotificationEvent  = errors.New("Invalid notification event type")
+	ErrInvalidNotificationMode   = errors.New("Invalid notification mode")
+)
+
+type NotificationService interface {
+	Enqueue(messages ...*domain.OutboxMessage) error
+	Schedule()
+	Scheduled() <-chan struct{}
+	DeliverPending() (int, error)
+	ListNotifications(status string, limit int, offset int) ([]domain.OutboxMessage, int64, error)
+	GetNotification(id uint64) (*domain.OutboxMessage, error)
+	RetryNotification(id uint64) (*domain.OutboxMessage, error)
+	EmailTemplates() []string
+	PreviewEmail(name string, locale string) (*helper.RenderedEmail, error)
+	ListUserNotifications(user *domain.User, unreadOnly bool, limit int, offset int) (*web.UserNotificationListResponse, error)
+	CountUnreadNotifications(userID uint64) (int64, error)
+	MarkNotificationRead(user *domain.User, id uint64) (*web.UserNotificationResponse, error)
+	MarkAllNotificationsRead(userID uint64) (int64, error)
+	GetNotificationPreferences(userID uint64) (map[string]string, error)
+	UpdateNotificationPreferences(userID uint64, modes map[string]string) (map[string]string, error)
+	SendDigests() (int, error)
+}
@@ -0,0 +1,457 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/model/web"
+	"manajemen_tugas_master/repository"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultNotificationWorkers     = 4
+	defaultNotificationMaxAttempts = 8
+	defaultNotificationRetryBase   = 30 * time.Second
+	defaultNotificationRetryMax    = time.Hour
+	defaultNotificationDigestHour  = 8
+	notificationBatchSize          = 50
+	notificationSendTimeout        = time.Minute
+	// pesan yang sedang dikirim diambil lagi setelah lease habis jika worker berhenti sebelum mencatat hasilnya
+	notificationLease = 5 * time.Minute
+)
+
+type notificationService struct {
+	notificationRepository repository.NotificationRepository
+	userRepository         repository.UserRepository
+	chatService            ChatService
+	mailer                 helper.Mailer
+	workers                int
+	maxAttempts            int
+	retryBase              time.Duration
+	retryMax               time.Duration
+	digestHour             int
+	scheduled              chan struct{}
+}
+
+func NewNotificationService(notificationRepository repository.NotificationRepository, userRepository repository.UserRepository, chatService ChatService, mailer helper.Mailer) NotificationService {
+	workers := helper.IntFromEnv("NOTIFICATION_WORKERS", defaultNotificationWorkers)
+	if workers <= 0 {
+		workers = defaultNotificationWorkers
+	}
+	maxAttempts := helper.IntFromEnv("NOTIFICATION_MAX_ATTEMPTS", defaultNotificationMaxAttempts)
+	if maxAttempts <= 0 {
+		maxAttempts = defaultNotificationMaxAttempts
+	}
+	retryBase := helper.DurationFromEnv("NOTIFICATION_RETRY_BASE", defaultNotificationRetryBase)
+	if retryBase == 0 {
+		retryBase = defaultNotificationRetryBase
+	}
+	digestHour := helper.IntFromEnv("NOTIFICATION_DIGEST_HOUR", defaultNotificationDigestHour)
+	if digestHour < 0 || digestHour > 23 {
+		digestHour = defaultNotificationDigestHour
+	}
+
+	return &notificationService{
+		notificationRepository: notificationRepository,
+		userRepository:         userRepository,
+		chatService:            chatService,
+		mailer:                 mailer,
+		workers:                workers,
+		maxAttempts:            maxAttempts,
+		retryBase:              retryBase,
+		retryMax:               max(helper.DurationFromEnv("NOTIFICATION_RETRY_MAX", defaultNotificationRetryMax), retryBase),
+		digestHour:             digestHour,
+		scheduled:              make(chan struct{}, 1),
+	}
+}
+
+// Enqueue mencatat notifikasi yang tidak terikat transaksi perubahan lain pada outbox lalu membangunkan worker
+func (n *notificationService) Enqueue(messages ...*domain.OutboxMessage) error {
+	if err := n.notificationRepository.Create(messages); err != nil {
+		return err
+	}
+	n.Schedule()
+	return nil
+}
+
+// Schedule membangunkan worker notifikasi setelah ada pesan baru, tidak menunggu jika worker sudah dijadwalkan
+func (n *notificationService) Schedule() {
+	select {
+	case n.scheduled <- struct{}{}:
+	default:
+	}
+}
+
+func (n *notificationService) Scheduled() <-chan struct{} {
+	return n.scheduled
+}
+
+// DeliverPending mengirim semua pesan yang sudah waktunya dengan NOTIFICATION_WORKERS pengiriman bersamaan
+// dan mengembalikan jumlah pesan yang berhasil dikirim. Kegagalan pengiriman dicatat per pesan
+func (n *notificationService) DeliverPending() (int, error) {
+	delivered := 0
+	for {
+		messages, err := n.notificationRepository.ClaimDue(notificationBatchSize, notificationLease)
+		if err != nil {
+			return delivered, err
+		}
+		if len(messages) == 0 {
+			return delivered, nil
+		}
+
+		var (
+			wg   sync.WaitGroup
+			mu   sync.Mutex
+			jobs = make(chan domain.OutboxMessage)
+		)
+		for i := 0; i < min(n.workers, len(messages)); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for message := range jobs {
+					if n.deliver(message) {
+						mu.Lock()
+						delivered++
+						mu.Unlock()
+					}
+				}
+			}()
+		}
+		for _, message := range messages {
+			jobs <- message
+		}
+		close(jobs)
+		wg.Wait()
+	}
+}
+
+// deliver mengirim satu pesan dan mencatat hasilnya. Pesan yang gagal dicoba lagi dengan jeda yang berlipat dua
+// setiap percobaan sampai NOTIFICATION_MAX_ATTEMPTS, setelah itu menjadi dead
+func (n *notificationService) deliver(message domain.OutboxMessage) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), notificationSendTimeout)
+	defer cancel()
+
+	sendErr := n.send(ctx, message)
+	if sendErr == nil {
+		if err := n.notificationRepository.MarkSent(message.ID); err != nil {
+			log.Printf("Failed to mark notification %d as sent: %v", message.ID, err)
+		}
+		return true
+	}
+
+	status := domain.OutboxStatusPending
+	nextAttemptAt := time.Now().Add(helper.RetryBackoff(n.retryBase, n.retryMax, message.Attempts))
+	if message.Attempts >= n.maxAttempts {
+		status = domain.OutboxStatusDead
+		log.Printf("Notification %d (%s) failed %d times and was moved to the dead letter: %v", message.ID, message.Event, message.Attempts, sendErr)
+	} else {
+		log.Printf("Failed to deliver notification %d (%s), retrying at %s: %v", message.ID, message.Event, nextAttemptAt.Format(time.RFC3339), sendErr)
+	}
+	// penerima yang sudah berhasil dikirimi tidak dikirimi lagi pada percobaan berikutnya
+	recipients := message.Recipients
+	var recipientsErr *helper.RecipientsError
+	if errors.As(sendErr, &recipientsErr) {
+		recipients = recipientsErr.Failed
+	}
+	if err := n.notificationRepository.MarkFailed(message.ID, status, nextAttemptAt, sendErr.Error(), recipients); err != nil {
+		log.Printf("Failed to record delivery failure of notification %d: %v", message.ID, err)
+	}
+	return false
+}
+
+// send mengirim pesan ke semua penerimanya. Pesan dengan template dirender per bahasa penerima, pesan lama
+// yang belum memakai template dikirim dengan subject dan body yang tersimpan
+func (n *notificationService) send(ctx context.Context, message domain.OutboxMessage) error {
+	if message.Channel == domain.OutboxChannelChat {
+		return n.chatService.Send(ctx, message)
+	}
+	if message.Template == "" {
+		return helper.SendToEach(ctx, n.mailer, message.Recipients, message.Subject, message.Body, "", nil)
+	}
+	replyTo, err := n.replyTo(message)
+	if err != nil {
+		return err
+	}
+
+	locales, err := n.userRepository.FindLocalesByEmails(message.Recipients)
+	if err != nil {
+		return err
+	}
+	groups := make(map[string][]string)
+	for _, recipient := range message.Recipients {
+		locale := helper.NormalizeLocale(locales[strings.ToLower(recipient)])
+		groups[locale] = append(groups[locale], recipient)
+	}
+
+	var failed []string
+	var lastErr error
+	for locale, recipients := range groups {
+		data := message.Data
+		data.Reply = replyTo != nil
+		rendered, err := helper.RenderEmail(message.Template, locale, &data)
+		if err != nil {
+			failed = append(failed, recipients...)
+			lastErr = err
+			continue
+		}
+		err = helper.SendToEach(ctx, n.mailer, recipients, rendered.Subject, rendered.HTMLBody, rendered.TextBody, replyTo)
+		var recipientsErr *helper.RecipientsError
+		if errors.As(err, &recipientsErr) {
+			failed = append(failed, recipientsErr.Failed...)
+			lastErr = recipientsErr.Err
+		} else if err != nil {
+			failed = append(failed, recipients...)
+			lastErr = err
+		}
+	}
+	if len(failed) > 0 {
+		return &helper.RecipientsError{Failed: failed, Err: lastErr}
+	}
+	return nil
+}
+
+// replyTo memberi email notifikasi task alamat balasan bertanda tangan untuk setiap penerima yang memiliki akun,
+// sehingga balasannya ditambahkan sebagai komentar task atas nama penerima tersebut
+func (n *notificationService) replyTo(message domain.OutboxMessage) (helper.ReplyToFunc, error) {
+	if message.TaskID == 0 || !helper.ReplyEnabled() {
+		return nil, nil
+	}
+	userIDs, err := n.userRepository.FindIDsByEmails(message.Recipients)
+	if err != nil {
+		return nil, err
+	}
+	return func(recipient string) string {
+		return helper.ReplyAddress(message.TaskID, userIDs[strings.ToLower(recipient)])
+	}, nil
+}
+
+func (n *notificationService) ListNotifications(status string, limit int, offset int) ([]domain.OutboxMessage, int64, error) {
+	switch status {
+	case "", domain.OutboxStatusPending, domain.OutboxStatusSending, domain.OutboxStatusSent, domain.OutboxStatusDead:
+	default:
+		return nil, 0, ErrInvalidNotificationStatus
+	}
+	return n.notificationRepository.FindAll(status, limit, offset)
+}
+
+func (n *notificationService) GetNotification(id uint64) (*domain.OutboxMessage, error) {
+	message, err := n.notificationRepository.FindByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotificationNotFound
+	}
+	return message, err
+}
+
+// RetryNotification mengirim ulang pesan dead, atau pesan pending yang masih menunggu jeda, secepatnya
+func (n *notificationService) RetryNotification(id uint64) (*domain.OutboxMessage, error) {
+	if _, err := n.GetNotification(id); err != nil {
+		return nil, err
+	}
+
+	retried, err := n.notificationRepository.Retry(id)
+	if err != nil {
+		return nil, err
+	}
+	if !retried {
+		return nil, ErrNotificationNotRetryable
+	}
+	n.Schedule()
+
+	return n.notificationRepository.FindByID(id)
+}
+
+func (n *notificationService) EmailTemplates() []string {
+	return helper.EmailTemplateNames()
+}
+
+// PreviewEmail merender template email dengan contoh data, tanpa locale memakai bahasa default
+func (n *notificationService) PreviewEmail(name string, locale string) (*helper.RenderedEmail, error) {
+	if locale != "" && !slices.Contains(helper.SupportedLocales, locale) {
+		return nil, ErrUnsupportedLocale
+	}
+	data, err := helper.EmailTemplateSample(name)
+	if errors.Is(err, helper.ErrEmailTemplateNotFound) {
+		return nil, ErrEmailTemplateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return helper.RenderEmail(name, locale, data)
+}
+
+func (n *notificationService) ListUserNotifications(user *domain.User, unreadOnly bool, limit int, offset int) (*web.UserNotificationListResponse, error) {
+	notifications, total, err := n.notificationRepository.FindByUser(user.ID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := n.notificationRepository.CountUnread(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &web.UserNotificationListResponse{
+		Total:         total,
+		Unread:        unread,
+		Limit:         limit,
+		Offset:        offset,
+		Notifications: make([]web.UserNotificationResponse, 0, len(notifications)),
+	}
+	for i := range notifications {
+		response.Notifications = append(response.Notifications, userNotificationResponse(&notifications[i], user.Locale))
+	}
+	return response, nil
+}
+
+func (n *notificationService) CountUnreadNotifications(userID uint64) (int64, error) {
+	return n.notificationRepository.CountUnread(userID)
+}
+
+// MarkNotificationRead menandai notifikasi sebagai sudah dibaca, notifikasi milik user lain dianggap tidak ada
+func (n *notificationService) MarkNotificationRead(user *domain.User, id uint64) (*web.UserNotificationResponse, error) {
+	notification, err := n.notificationRepository.MarkRead(user.ID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotificationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	response := userNotificationResponse(notification, user.Locale)
+	return &response, nil
+}
+
+func (n *notificationService) MarkAllNotificationsRead(userID uint64) (int64, error) {
+	return n.notificationRepository.MarkAllRead(userID)
+}
+
+// userNotificationResponse merender judul dan isi notifikasi dalam bahasa user. Jika template sudah tidak ada,
+// nama event dipakai sebagai judul agar notifikasi tetap tampil
+func userNotificationResponse(notification *domain.Notification, locale string) web.UserNotificationResponse {
+	title, message, err := helper.RenderNotification(notification.Template, locale, &notification.Data)
+	if err != nil {
+		log.Printf("Failed to render notification %d: %v", notification.ID, err)
+		title, message = notification.Event, ""
+	}
+	return web.UserNotificationResponse{
+		ID:        notification.ID,
+		TaskID:    notification.TaskID,
+		Event:     notification.Event,
+		Title:     title,
+		Message:   message,
+		Read:      notification.ReadAt != nil,
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+// GetNotificationPreferences mengembalikan cara notifikasi diterima untuk setiap jenis event, termasuk yang masih default
+func (n *notificationService) GetNotificationPreferences(userID uint64) (map[string]string, error) {
+	saved, err := n.notificationRepository.FindPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := make(map[string]string, len(domain.NotificationEventTypes))
+	for _, eventType := range domain.NotificationEventTypes {
+		preferences[eventType] = domain.DefaultNotificationMode
+		if mode, ok := saved[eventType]; ok {
+			preferences[eventType] = mode
+		}
+	}
+	return preferences, nil
+}
+
+// UpdateNotificationPreferences mengubah preferensi jenis event yang dikirim, jenis event lain tidak berubah
+func (n *notificationService) UpdateNotificationPreferences(userID uint64, modes map[string]string) (map[string]string, error) {
+	for eventType, mode := range modes {
+		if !slices.Contains(domain.NotificationEventTypes, eventType) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidNotificationEvent, eventType)
+		}
+		if !slices.Contains(domain.NotificationModes, mode) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidNotificationMode, mode)
+		}
+	}
+
+	if err := n.notificationRepository.SavePreferences(userID, modes); err != nil {
+		return nil, err
+	}
+	return n.GetNotificationPreferences(userID)
+}
+
+// SendDigests menyusun satu email digest per user berisi notifikasi digest yang dibuat sebelum jam
+// NOTIFICATION_DIGEST_HOUR terakhir, dikelompokkan per board. Email dikirim lewat outbox sehingga ikut dicoba ulang
+// jika gagal. Mengembalikan jumlah email digest yang dicatat
+func (n *notificationService) SendDigests() (int, error) {
+	cutoff := n.digestCutoff(time.Now())
+	notifications, err := n.notificationRepository.FindPendingDigests(cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for start := 0; start < len(notifications); {
+		end := start
+		for end < len(notifications) && notifications[end].UserID == notifications[start].UserID {
+			end++
+		}
+		if err := n.createDigest(notifications[start:end], cutoff); err != nil {
+			log.Printf("Failed to create notification digest for user %d: %v", notifications[start].UserID, err)
+		} else {
+			sent++
+		}
+		start = end
+	}
+	if sent > 0 {
+		n.Schedule()
+	}
+	return sent, nil
+}
+
+func (n *notificationService) createDigest(notifications []domain.DigestNotification, cutoff time.Time) error {
+	recipient := notifications[0]
+	data := domain.EmailData{Value: cutoff.Format("02-01-2006")}
+	boards := make(map[string]int)
+	ids := make([]uint64, 0, len(notifications))
+	for _, notification := range notifications {
+		title, _, err := helper.RenderNotification(notification.Template, recipient.Locale, &notification.Data)
+		if err != nil {
+			title = notification.Event
+		}
+
+		index, ok := boards[notification.BoardName]
+		if !ok {
+			index = len(data.Boards)
+			boards[notification.BoardName] = index
+			data.Boards = append(data.Boards, domain.EmailDigestBoard{Name: notification.BoardName})
+		}
+		data.Boards[index].Items = append(data.Boards[index].Items, domain.EmailDigestItem{
+			TaskName: notification.Data.TaskName,
+			Title:    title,
+			Time:     notification.CreatedAt.Format("02-01-2006 15:04"),
+		})
+		ids = append(ids, notification.ID)
+	}
+
+	message, err := taskNotification("notification.digest", 0, []string{recipient.Email}, "digest", data)
+	if err != nil {
+		return err
+	}
+	return n.notificationRepository.CreateDigest(message, ids)
+}
+
+// digestCutoff adalah jam NOTIFICATION_DIGEST_HOUR terakhir sebelum now
+func (n *notificationService) digestCutoff(now time.Time) time.Time {
+	cutoff := time.Date(now.Year(), now.Month(), now.Day(), n.digestHour, 0, 0, 0, now.Location())
+	if cutoff.After(now) {
+		cutoff = cutoff.AddDate(0, 0, -1)
+	}
+	return cutoff
+}
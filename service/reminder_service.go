@@ -0,0 +1,5 @@
+package service
+
+type ReminderService interface {
+	SendDueDateReminders() (int, error)
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/repository"
+	"math"
+	"os"
+	"slices"
+	"time"
+)
+
+const defaultReminderTimezone = "Asia/Jakarta"
+
+// pengingat dikirim 3 hari, 1 hari dan 2 jam sebelum tenggat
+var defaultReminderOffsets = []time.Duration{72 * time.Hour, 24 * time.Hour, 2 * time.Hour}
+
+type reminderService struct {
+	reminderRepository     repository.ReminderRepository
+	taskAndOwnerRepository repository.TaskAndOwnerRepository
+	notificationService    NotificationService
+	// offsets diurutkan dari yang paling dekat ke tenggat
+	offsets  []time.Duration
+	location *time.Location
+}
+
+func NewReminderService(reminderRepository repository.ReminderRepository, taskAndOwnerRepository repository.TaskAndOwnerRepository, notificationService NotificationService) ReminderService {
+	offsets := slices.Clone(helper.DurationsFromEnv("REMINDER_OFFSETS", defaultReminderOffsets))
+	slices.Sort(offsets)
+
+	return &reminderService{
+		reminderRepository:     reminderRepository,
+		taskAndOwnerRepository: taskAndOwnerRepository,
+		notificationService:    notificationService,
+		offsets:                slices.Compact(offsets),
+		location:               reminderLocation(),
+	}
+}
+
+// reminderLocation adalah zona waktu REMINDER_TIMEZONE tempat tanggal tenggat task dibaca
+func reminderLocation() *time.Location {
+	timezone := os.Getenv("REMINDER_TIMEZONE")
+	if timezone == "" {
+		timezone = defaultReminderTimezone
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		log.Printf("Invalid REMINDER_TIMEZONE %q, using local time: %v", timezone, err)
+		return time.Local
+	}
+	return location
+}
+
+// dueReminder adalah pengingat yang sudah waktunya dikirim untuk satu tenggat task
+type dueReminder struct {
+	task    *domain.Task
+	dueType string
+	dueDate string
+	offset  time.Duration
+	// skipped adalah pengingat dengan jarak lebih jauh yang waktunya sudah lewat tetapi belum dikirim
+	skipped []time.Duration
+}
+
+// dueDeadline adalah akhir hari tenggat (format 02-01-2006) pada zona waktu location
+func dueDeadline(dueDate string, location *time.Location) (time.Time, bool) {
+	date, err := time.ParseInLocation("02-01-2006", dueDate, location)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date.AddDate(0, 0, 1), true
+}
+
+// dueReminder mengembalikan pengingat yang waktunya sudah tiba untuk tenggat yang belum lewat. Jika beberapa waktu
+// pengingat sudah terlewat sekaligus hanya yang paling dekat ke tenggat yang dikirim
+func (r *reminderService) dueReminder(task *domain.Task, dueType string, dueDate string, now time.Time) *dueReminder {
+	deadline, ok := dueDeadline(dueDate, r.location)
+	if !ok || !now.Before(deadline) {
+		return nil
+	}
+	var opened []time.Duration
+	for _, offset := range r.offsets {
+		if !now.Before(deadline.Add(-offset)) {
+			opened = append(opened, offset)
+		}
+	}
+	if len(opened) == 0 {
+		return nil
+	}
+	return &dueReminder{task: task, dueType: dueType, dueDate: dueDate, offset: opened[0], skipped: opened[1:]}
+}
+
+func reminderKey(taskID uint64, dueType string, dueDate string, offsetMinutes int) string {
+	return fmt.Sprintf("%d/%s/%s/%d", taskID, dueType, dueDate, offsetMinutes)
+}
+
+// SendDueDateReminders mengirim pengingat tenggat planning ke manager dan tenggat project ke employee pada setiap
+// jarak waktu REMINDER_OFFSETS sebelum tenggat. Task yang planning-nya Approved atau project-nya Done dilewati.
+// Mengembalikan jumlah pengingat yang dicatat pada outbox
+func (r *reminderService) SendDueDateReminders() (int, error) {
+	if len(r.offsets) == 0 {
+		return 0, nil
+	}
+	tasks, err := r.reminderRepository.FindOpenDueTasks()
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var reminders []*dueReminder
+	var taskIDs []uint64
+	for i := range tasks {
+		task := &tasks[i]
+		if task.PlanningDueDate != "" && task.PlanningStatus != "Approved" {
+			if reminder := r.dueReminder(task, domain.DueTypePlanning, task.PlanningDueDate, now); reminder != nil {
+				reminders = append(reminders, reminder)
+			}
+		}
+		if task.ProjectDueDate != "" && task.ProjectStatus != "Done" {
+			if reminder := r.dueReminder(task, domain.DueTypeProject, task.ProjectDueDate, now); reminder != nil {
+				reminders = append(reminders, reminder)
+			}
+		}
+		if len(reminders) > 0 && reminders[len(reminders)-1].task == task {
+			taskIDs = append(taskIDs, task.ID)
+		}
+	}
+	if len(reminders) == 0 {
+		return 0, nil
+	}
+
+	sentReminders, err := r.reminderRepository.FindReminders(taskIDs)
+	if err != nil {
+		return 0, err
+	}
+	sent := make(map[string]bool, len(sentReminders))
+	for _, reminder := range sentReminders {
+		sent[reminderKey(reminder.TaskID, reminder.DueType, reminder.DueDate, reminder.OffsetMinutes)] = true
+	}
+
+	created := 0
+	for _, reminder := range reminders {
+		if sent[reminderKey(reminder.task.ID, reminder.dueType, reminder.dueDate, int(reminder.offset.Minutes()))] {
+			continue
+		}
+		ok, err := r.createReminder(reminder)
+		if err != nil {
+			log.Printf("Failed to create %s due date reminder for task %d: %v", reminder.dueType, reminder.task.ID, err)
+			continue
+		}
+		if ok {
+			created++
+		}
+	}
+	if created > 0 {
+		r.notificationService.Schedule()
+	}
+	return created, nil
+}
+
+func (r *reminderService) createReminder(reminder *dueReminder) (bool, error) {
+	_, managerEmails, employeeEmails, _, nameTask, err := r.taskAndOwnerRepository.GetNameEmailsDescription(reminder.task.ID)
+	if err != nil {
+		return false, err
+	}
+	recipients := employeeEmails
+	if reminder.dueType == domain.DueTypePlanning {
+		recipients = managerEmails
+	}
+
+	data := domain.EmailData{TaskName: nameTask, Value: reminder.dueDate, Status: reminder.dueType}
+	hours := int(math.Ceil(reminder.offset.Hours()))
+	if hours >= 24 && hours%24 == 0 {
+		data.Days = hours / 24
+	} else {
+		data.Hours = hours
+	}
+	event := fmt.Sprintf("task.%s_due_date_reminder", reminder.dueType)
+	message, err := taskNotification(event, reminder.task.ID, recipients, "due_date_reminder", data)
+	if err != nil {
+		return false, err
+	}
+
+	record := func(offset time.Duration, skipped bool) *domain.DueDateReminder {
+		return &domain.DueDateReminder{
+			TaskID:        reminder.task.ID,
+			DueType:       reminder.dueType,
+			DueDate:       reminder.dueDate,
+			OffsetMinutes: int(offset.Minutes()),
+			Skipped:       skipped,
+		}
+	}
+	skipped := make([]*domain.DueDateReminder, 0, len(reminder.skipped))
+	for _, offset := range reminder.skipped {
+		skipped = append(skipped, record(offset, true))
+	}
+	return r.reminderRepository.CreateReminder(record(reminder.offset, false), skipped, message)
+}
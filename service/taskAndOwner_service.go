@@ -1,10 +1,16 @@
 package service
 
 import (
+	"errors"
 	"manajemen_tugas_master/model/domain"
 	"manajemen_tugas_master/model/web"
 )
 
+var (
+	ErrTaskNotFound  = errors.New("Task not found")
+	ErrNotTaskMember = errors.New("Only for task members")
+)
+
 type TaskAndOwnerService interface {
 	CreateTaskAndOwner(user *domain.User, task *domain.Task, board *domain.Board) (*domain.Task, *domain.Owner, error)
 	GetTaskAndOwnerById(id uint) (*domain.TaskWithInvitation, error)
@@ -14,16 +20,17 @@ type TaskAndOwnerService interface {
 	FindAllEmployees() ([]*domain.Task, error)
 	FindAllPlanningFiles() ([]*domain.Task, error)
 	FindAllProjectFiles() ([]*domain.Task, error)
-	UpdateTaskAndOwner(task *domain.Task, manager *domain.Manager, employee *domain.Employee, PlanningDescriptionFile *domain.PlanningDescriptionFile, planningFile *domain.PlanningFile, projectFile *domain.ProjectFile, taskID uint, boardID uint) (*web.UpdateResponse, error)
+	UpdateTaskAndOwner(task *domain.Task, manager *domain.Manager, employee *domain.Employee, planningDescriptionFiles []*domain.PlanningDescriptionFile, planningFiles []*domain.PlanningFile, projectFiles []*domain.ProjectFile, taskID uint, boardID uint, userID uint) (*web.UpdateResponse, error)
 	UpdateValidationOwner(taskID uint, userID uint) error
 	UpdateValidationManager(taskID uint, userID uint) error
 	UpdateValidationEmployee(taskID uint, userID uint) error
 	DeleteManager(taskId uint, managerId uint) error
 	DeleteEmployee(taskId uint, employeeId uint) error
-	DeletePlanningDescriptionFile(fileId uint) (string, error)
-	DeletePlanningFile(fileId uint) (string, error)
-	DeleteProjectFile(fileId uint) (string, error)
+	DeletePlanningDescriptionFile(fileId uint) ([]string, error)
+	DeletePlanningFile(fileId uint) ([]string, error)
+	DeleteProjectFile(fileId uint) ([]string, error)
 	DeleteTaskAndOwner(taskID uint) error
+	AddCommentReply(taskID uint64, user *domain.User, comment string) error
 
 	RespondToInvitation(invitationID uint64, response string) (*domain.Invitation, error)
 	GetAllInvitations() ([]domain.Invitation, error)
@@ -8,19 +8,26 @@ import (
 	"manajemen_tugas_master/model/domain"
 	"manajemen_tugas_master/model/web"
 	"manajemen_tugas_master/repository"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
 type taskAndOwnerService struct {
 	taskAndOwnerRepository repository.TaskAndOwnerRepository
 	boardRepository        repository.BoardRepository
+	fileService            FileService
+	notificationService    NotificationService
+	webhookService         WebhookService
+	chatService            ChatService
+	calendarService        CalendarService
 	validator              *validator.Validate
 }
 
-func NewTaskAndOwnerService(taskAndOwnerRepository repository.TaskAndOwnerRepository, boardRepository repository.BoardRepository, validator *validator.Validate) TaskAndOwnerService {
-	return &taskAndOwnerService{taskAndOwnerRepository, boardRepository, validator}
+func NewTaskAndOwnerService(taskAndOwnerRepository repository.TaskAndOwnerRepository, boardRepository repository.BoardRepository, fileService FileService, notificationService NotificationService, webhookService WebhookService, chatService ChatService, calendarService CalendarService, validator *validator.Validate) TaskAndOwnerService {
+	return &taskAndOwnerService{taskAndOwnerRepository, boardRepository, fileService, notificationService, webhookService, chatService, calendarService, validator}
 }
 
 func (t *taskAndOwnerService) CreateTaskAndOwner(user *domain.User, task *domain.Task, board *domain.Board) (*domain.Task, *domain.Owner, error) {
@@ -32,6 +39,7 @@ func (t *taskAndOwnerService) CreateTaskAndOwner(user *domain.User, task *domain
 	if err != nil {
 		return nil, nil, err
 	}
+	t.webhookService.Dispatch(taskDB.BoardID, domain.WebhookEventTaskCreated, map[string]interface{}{"task": domain.NewWebhookTask(taskDB)})
 
 	return taskDB, ownerDB, nil
 }
@@ -64,7 +72,7 @@ func (t *taskAndOwnerService) FindAllProjectFiles() ([]*domain.Task, error) {
 	return t.taskAndOwnerRepository.FindAllProjectFiles()
 }
 
-func (t *taskAndOwnerService) UpdateTaskAndOwner(task *domain.Task, manager *domain.Manager, employee *domain.Employee, PlanningDescriptionFile *domain.PlanningDescriptionFile, planningFile *domain.PlanningFile, projectFile *domain.ProjectFile, taskID uint, boardID uint) (*web.UpdateResponse, error) {
+func (t *taskAndOwnerService) UpdateTaskAndOwner(task *domain.Task, manager *domain.Manager, employee *domain.Employee, planningDescriptionFiles []*domain.PlanningDescriptionFile, planningFiles []*domain.PlanningFile, projectFiles []*domain.ProjectFile, taskID uint, boardID uint, userID uint) (*web.UpdateResponse, error) {
 	boardDB, err := t.boardRepository.FindById(uint64(boardID))
 	if err != nil {
 		return nil, err
@@ -80,280 +88,158 @@ func (t *taskAndOwnerService) UpdateTaskAndOwner(task *domain.Task, manager *dom
 	task.ID = taskDB.ID
 	task.OwnerID = taskDB.OwnerID
 
-	updateTask, updateManager, updateEmployee, updatePlanningDescriptionFile, updatePlanningFile, updateProjectFile, managerInvitation, employeeInvitation, err := t.taskAndOwnerRepository.Update(task, manager, employee, PlanningDescriptionFile, planningFile, projectFile)
-	if err != nil {
-		return nil, err
-	}
-
-	// Persiapan respons
-	response := &web.UpdateResponse{}
-	var emailsSent []string
-
-	// Populate response dengan data dari updateTask
-	// notif email
-	if task.NameTask != "" {
-		response.NameTask = updateTask.NameTask
-		ownerEmail, managerEmails, employeeEmails, nametask, _, err := t.taskAndOwnerRepository.GetNameEmailsDescription(uint64(taskID))
+	// tanggal jatuh tempo diperiksa sebelum update agar request yang tidak valid tidak menyimpan perubahan apapun
+	var planningDueDate, projectDueDate time.Time
+	if task.PlanningDueDate != "" {
+		planningDueDate, err = time.Parse("02-01-2006", task.PlanningDueDate)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("Failed to parse planning due date: %v", err)
 		}
-
-		to := []string{ownerEmail}
-		to = append(to, managerEmails...)
-		to = append(to, employeeEmails...)
-
-		subject := "Task Name Updated"
-		body := helper.GetEmailTemplate("Name task Update", nametask, "Name Updated", fmt.Sprintf("The name of the task has been updated to '%s'.", updateTask.NameTask))
-
-		err = helper.SendEmail(to, subject, body)
+	}
+	if task.ProjectDueDate != "" {
+		projectDueDate, err = time.Parse("02-01-2006", task.ProjectDueDate)
 		if err != nil {
-			log.Printf("Failed to send email: %v", err)
-		} else {
-			emailsSent = append(emailsSent, "Name task Update, Email sent successfully")
+			return nil, fmt.Errorf("Failed to parse project due date: %v", err)
 		}
-
-		log.Println(ownerEmail, managerEmails, employeeEmails)
 	}
 
-	if task.PlanningDescriptionPersen != "" {
-		response.PlanningDescriptionPersen = updateTask.PlanningDescriptionPersen
-
-		// Send email notification for planning description Persen update
-		ownerEmail, managerEmails, employeeEmails, nametask, _, err := t.taskAndOwnerRepository.GetNameEmailsDescription(uint64(taskID))
-		if err != nil {
-			return nil, err
+	// notifikasi disusun setelah semua perubahan tersimpan dan dicatat pada outbox dalam transaksi yang sama,
+	// lalu dikirim oleh worker notifikasi sehingga kegagalan email tidak lagi memengaruhi update
+	var emailsSent []string
+	notify := func(recipients domain.TaskRecipients) ([]*domain.OutboxMessage, error) {
+		var messages []*domain.OutboxMessage
+		var renderErr error
+		queue := func(event string, to []string, template string, data domain.EmailData, queued string) {
+			if renderErr != nil {
+				return
+			}
+			message, err := taskNotification(event, task.ID, to, template, data)
+			if err != nil {
+				renderErr = err
+				return
+			}
+			messages = append(messages, message)
+			emailsSent = append(emailsSent, queued)
 		}
+		nametask := recipients.TaskName
 
-		to := []string{ownerEmail}
-		to = append(to, managerEmails...)
-		to = append(to, employeeEmails...)
-
-		subject := "Planning Description Persen Updated"
-		body := helper.GetEmailTemplate("Planning Description Persen Update", nametask, "Persen Updated", fmt.Sprintf("The planning description Persen has been updated to: '%s'", updateTask.PlanningDescriptionPersen))
-
-		err = helper.SendEmail(to, subject, body)
-		if err != nil {
-			log.Printf("Failed to send email: %v", err)
-		} else {
-			emailsSent = append(emailsSent, "Planning Description Persen Update Email sent successfully")
+		if task.NameTask != "" {
+			queue("task.name_updated", recipients.Members(), "task_renamed",
+				domain.EmailData{TaskName: nametask, Value: task.NameTask},
+				"Name task Update, Email queued")
 		}
-	}
-
-	if updatePlanningDescriptionFile.ID != 0 || updatePlanningDescriptionFile.FileUrl != "" || updatePlanningDescriptionFile.FileName != "" {
-		response.PlanningDescriptionFile.ID = updatePlanningDescriptionFile.ID
-		response.PlanningDescriptionFile.FileUrl = updatePlanningDescriptionFile.FileUrl
-		response.PlanningDescriptionFile.FileName = updatePlanningDescriptionFile.FileName
 
-		// Send email notification for planning description file update
-		ownerEmail, managerEmails, employeeEmails, nametask, _, err := t.taskAndOwnerRepository.GetNameEmailsDescription(uint64(taskID))
-		if err != nil {
-			return nil, err
+		if task.PlanningDescriptionPersen != "" {
+			queue("task.planning_description_persen_updated", recipients.Members(), "task_progress_updated",
+				domain.EmailData{TaskName: nametask, Value: task.PlanningDescriptionPersen},
+				"Planning Description Persen Update, Email queued")
 		}
 
-		to := []string{ownerEmail}
-		to = append(to, managerEmails...)
-		to = append(to, employeeEmails...)
-
-		subject := "Planning Description File Updated"
-		body := helper.GetEmailTemplate("Planning Description File Update", nametask, "File Updated", fmt.Sprintf("A planning description file has been updated:\nFile Name: %s\nFile URL: %s", updatePlanningDescriptionFile.FileName, updatePlanningDescriptionFile.FileUrl))
-
-		err = helper.SendEmail(to, subject, body)
-		if err != nil {
-			log.Printf("Failed to send email: %v", err)
-		} else {
-			emailsSent = append(emailsSent, "Planning Description File Update Email sent successfully")
+		switch task.PlanningStatus {
+		case "Approved", "Not Approved":
+			queue("task.planning_status_updated", recipients.Members(), "planning_status_updated",
+				domain.EmailData{TaskName: nametask, Status: task.PlanningStatus},
+				"Task Planning status Update, Email queued")
 		}
-	}
 
-	// notif email
-	if updateTask.PlanningStatus == "Approved" || updateTask.PlanningStatus == "Not Approved" {
-		response.PlanningStatus = updateTask.PlanningStatus
-		ownerEmail, managerEmails, employeeEmails, nametask, _, err := t.taskAndOwnerRepository.GetNameEmailsDescription(uint64(taskID))
-		if err != nil {
-			return nil, err
+		switch task.ProjectStatus {
+		case "Done", "Undone", "Working":
+			queue("task.project_status_updated", recipients.Members(), "project_status_updated",
+				domain.EmailData{TaskName: nametask, Status: task.ProjectStatus},
+				"Task Project status update, Email queued")
 		}
 
-		to := []string{ownerEmail}
-		to = append(to, managerEmails...)
-		to = append(to, employeeEmails...)
-
-		var subject, body string
-		if updateTask.PlanningStatus == "Approved" {
-			subject = "Task Planning Approved"
-			body = helper.GetEmailTemplate("Planning Status Update", nametask, "Approved", "The planning for this task has been approved.")
-		} else {
-			subject = "Task Planning Not Approved"
-			body = helper.GetEmailTemplate("Planning Status Update", nametask, "Not Approved", "The planning for this task has not been approved. Please review and make necessary adjustments.")
+		if task.PlanningDueDate != "" && len(recipients.ManagerEmails) > 0 {
+			queue("task.planning_due_date_updated", recipients.ManagerEmails, "calendar_invite",
+				domain.EmailData{TaskName: nametask, Value: task.PlanningDueDate},
+				"Task Planning due date Update, Email queued")
 		}
-
-		err = helper.SendEmail(to, subject, body)
-		if err != nil {
-			log.Printf("Failed to send email: %v", err)
-			return nil, fmt.Errorf("failed to send email: %v", err)
-		} else {
-			emailsSent = append(emailsSent, "Task Planning status Update Email sent successfully")
+		if task.ProjectDueDate != "" && len(recipients.EmployeeEmails) > 0 {
+			queue("task.project_due_date_updated", recipients.EmployeeEmails, "calendar_invite",
+				domain.EmailData{TaskName: nametask, Value: task.ProjectDueDate},
+				"Task Project due date Update, Email queued")
 		}
 
-		log.Println(ownerEmail, managerEmails, employeeEmails)
-	}
-
-	// notif email
-	if updateTask.ProjectStatus == "Done" || updateTask.ProjectStatus == "Undone" || updateTask.ProjectStatus == "Working" {
-		response.ProjectStatus = updateTask.ProjectStatus
-		ownerEmail, managerEmails, employeeEmails, nametask, _, err := t.taskAndOwnerRepository.GetNameEmailsDescription(uint64(taskID))
-		if err != nil {
-			return nil, err
+		if task.ProjectComment != "" {
+			queue("task.comment_added", []string{recipients.OwnerEmail}, "comment_added",
+				domain.EmailData{TaskName: nametask, Value: task.ProjectComment},
+				"Task Project comment Update, Email queued")
 		}
 
-		to := []string{ownerEmail}
-		to = append(to, managerEmails...)
-		to = append(to, employeeEmails...)
-
-		var subject, body string
-		switch updateTask.ProjectStatus {
-		case "Done":
-			subject = "Task Project Done"
-			body = helper.GetEmailTemplate("Project Status Update", nametask, "Done", "The project for this task has been completed.")
-		case "Undone":
-			subject = "Task Project Undone"
-			body = helper.GetEmailTemplate("Project Status Update", nametask, "Undone", "The project for this task has not been completed. Please review and make necessary adjustments.")
-		case "Working":
-			subject = "Task Project in Progress"
-			body = helper.GetEmailTemplate("Project Status Update", nametask, "Working", "Work has started on the project for this task.")
+		// semua file yang ditambahkan pada request ini dikirim dalam satu notifikasi
+		var addedFiles []domain.EmailFile
+		for _, file := range planningDescriptionFiles {
+			addedFiles = append(addedFiles, domain.EmailFile{Type: domain.FileTypePlanningDescription, Name: file.FileName, URL: helper.FileDownloadURL(task.ID, file.ID, domain.FileTypePlanningDescription)})
 		}
-
-		err = helper.SendEmail(to, subject, body)
-		if err != nil {
-			log.Printf("Failed to send email: %v", err)
-			return nil, fmt.Errorf("failed to send email: %v", err)
-		} else {
-			emailsSent = append(emailsSent, "Task Project status update, Email sent successfully")
+		for _, file := range planningFiles {
+			addedFiles = append(addedFiles, domain.EmailFile{Type: domain.FileTypePlanning, Name: file.FileName, Version: file.Version, URL: helper.FileDownloadURL(task.ID, file.ID, domain.FileTypePlanning)})
 		}
-
-		log.Println(ownerEmail, managerEmails, employeeEmails)
-	}
-
-	if updateTask.PlanningDueDate != "" {
-		response.PlanningDueDate = updateTask.PlanningDueDate
-
-		_, managerEmails, _, TaskDescription, nametask, err := t.taskAndOwnerRepository.GetNameEmailsDescription(uint64(taskID))
-		if err != nil {
-			return nil, err
+		for _, file := range projectFiles {
+			addedFiles = append(addedFiles, domain.EmailFile{Type: domain.FileTypeProject, Name: file.FileName, Version: file.Version, URL: helper.FileDownloadURL(task.ID, file.ID, domain.FileTypeProject)})
+		}
+		if len(addedFiles) > 0 {
+			queue("task.files_added", recipients.Members(), "files_added",
+				domain.EmailData{TaskName: nametask, Files: addedFiles},
+				"Task files Update, Email queued")
 		}
 
-		if len(managerEmails) > 0 {
-			//Parse date from string to time.Time
-			parsedDueDate, err := time.Parse("02-01-2006", updateTask.PlanningDueDate)
-			if err != nil {
-				return nil, fmt.Errorf("Failed to parse planning due date: %v", err)
-			}
-
-			// Create Google Calendar event
-			senderEmail := "m.andres.novrizal@gmail.com"
-			summary := fmt.Sprintf("Task: %s", nametask)
-			description := TaskDescription
-
-			// Use current time for startDateTime
-			startDateTime := time.Now().Format(time.RFC3339)
+		if manager != nil && manager.Email != "" {
+			queue("task.invitation_received", []string{manager.Email}, "invitation_received",
+				domain.EmailData{TaskName: nametask, Value: "manager", Detail: manager.Email},
+				"Manager invitation, Email queued")
+		}
+		if employee != nil && employee.Email != "" {
+			queue("task.invitation_received", []string{employee.Email}, "invitation_received",
+				domain.EmailData{TaskName: nametask, Value: "employee", Detail: employee.Email},
+				"Employee invitation, Email queued")
+		}
 
-			// Format endDateTime as expected by Google Calendar API
-			endDateTime := parsedDueDate.Format(time.RFC3339)
+		// notifikasi yang gagal dirender membatalkan update agar perubahan tidak tersimpan tanpa notifikasinya
+		if renderErr != nil {
+			return nil, renderErr
+		}
+		// pesan chat untuk integrasi board dicatat pada outbox yang sama dengan notifikasi email
+		return append(messages, t.chatService.Messages(uint64(boardID), uint64(userID), messages)...), nil
+	}
 
-			timeZone := "Asia/Jakarta" // Adjust to desired timezone
-			attendees := managerEmails
+	updateTask, updateManager, updateEmployee, updatePlanningDescriptionFiles, updatePlanningFiles, updateProjectFiles, managerInvitation, employeeInvitation, err := t.taskAndOwnerRepository.Update(task, manager, employee, planningDescriptionFiles, planningFiles, projectFiles, uint64(userID), notify)
+	if err != nil {
+		return nil, err
+	}
+	t.notificationService.Schedule()
+	t.dispatchTaskUpdated(uint64(taskID), updatedFields(task, manager, employee, planningDescriptionFiles, planningFiles, projectFiles))
 
-			event, err := helper.CreateGoogleCalendarEvent(senderEmail, summary, description, startDateTime, endDateTime, timeZone, attendees)
-			if err != nil {
-				log.Printf("Failed to create Google Calendar event: %v", err)
-			} else {
-				log.Printf("Google Calendar event created: %s", event.HtmlLink)
-			}
+	// Persiapan respons
+	response := &web.UpdateResponse{}
 
-			// Send email invitation
-			emailSubject := fmt.Sprintf("Calendar Invite: %s", summary)
-			emailBody := helper.GetCalendarInviteTemplate(summary, description)
-			err = helper.SendEmail(attendees, emailSubject, emailBody)
-			if err != nil {
-				log.Printf("Failed to send email: %v", err)
-			} else {
-				emailsSent = append(emailsSent, "Task Planning due date Update, Email sent successfully")
-			}
-		}
+	// Populate response dengan data dari updateTask
+	if task.NameTask != "" {
+		response.NameTask = updateTask.NameTask
+	}
+	if task.PlanningDescriptionPersen != "" {
+		response.PlanningDescriptionPersen = updateTask.PlanningDescriptionPersen
+	}
+	if updateTask.PlanningStatus == "Approved" || updateTask.PlanningStatus == "Not Approved" {
+		response.PlanningStatus = updateTask.PlanningStatus
+	}
+	if updateTask.ProjectStatus == "Done" || updateTask.ProjectStatus == "Undone" || updateTask.ProjectStatus == "Working" {
+		response.ProjectStatus = updateTask.ProjectStatus
 	}
 
 	// calendar schedule
+	if updateTask.PlanningDueDate != "" {
+		response.PlanningDueDate = updateTask.PlanningDueDate
+		t.syncDueDateEvent(uint64(taskID), taskDB.Owner.UserID, domain.CalendarDueTypePlanning, planningDueDate)
+	}
 	if updateTask.ProjectDueDate != "" {
 		response.ProjectDueDate = updateTask.ProjectDueDate
-
-		_, _, employeeEmails, TaskDescription, nametask, err := t.taskAndOwnerRepository.GetNameEmailsDescription(uint64(taskID))
-		if err != nil {
-			return nil, err
-		}
-
-		if len(employeeEmails) > 0 {
-			//Parse date from string to time.Time
-			parsedDueDate, err := time.Parse("02-01-2006", updateTask.ProjectDueDate)
-			if err != nil {
-				return nil, fmt.Errorf("Failed to parse project due date: %v", err)
-			}
-
-			// Create Google Calendar event
-			senderEmail := "m.andres.novrizal@gmail.com"
-			summary := fmt.Sprintf("Task: %s", nametask)
-			description := TaskDescription
-
-			// Use current time for startDateTime
-			startDateTime := time.Now().Format(time.RFC3339)
-
-			// Format endDateTime as expected by Google Calendar API
-			endDateTime := parsedDueDate.Format(time.RFC3339)
-
-			timeZone := "Asia/Jakarta" // Adjust to desired timezone
-			attendees := employeeEmails
-
-			event, err := helper.CreateGoogleCalendarEvent(senderEmail, summary, description, startDateTime, endDateTime, timeZone, attendees)
-			if err != nil {
-				log.Printf("Failed to create Google Calendar event: %v", err)
-			} else {
-				log.Printf("Google Calendar event created: %s", event.HtmlLink)
-			}
-
-			// Send email invitation
-			emailSubject := fmt.Sprintf("Calendar Invite: %s", summary)
-			emailBody := helper.GetCalendarInviteTemplate(summary, description)
-			err = helper.SendEmail(attendees, emailSubject, emailBody)
-			if err != nil {
-				log.Printf("Failed to send email: %v", err)
-			} else {
-				emailsSent = append(emailsSent, "Task Project due date Update, Email sent successfully")
-			}
-		}
+		t.syncDueDateEvent(uint64(taskID), taskDB.Owner.UserID, domain.CalendarDueTypeProject, projectDueDate)
 	}
 
 	response.Priority = updateTask.Priority
 
-	// notif email
 	if updateTask.ProjectComment != "" {
 		response.ProjectComment = updateTask.ProjectComment
-		ownerEmail, _, _, nametask, _, err := t.taskAndOwnerRepository.GetNameEmailsDescription(uint64(taskID))
-		if err != nil {
-			return nil, err
-		}
-
-		to := []string{ownerEmail}
-
-		subject := "New Project Comment Added"
-		body := helper.GetEmailTemplate("Project Comment Update", nametask, "New Comment", fmt.Sprintf("A new comment has been added to the project:\n\n'%s'", updateTask.ProjectComment))
-
-		err = helper.SendEmail(to, subject, body)
-		if err != nil {
-			log.Printf("Failed to send email: %v", err)
-			return nil, fmt.Errorf("failed to send email: %v", err)
-		} else {
-			emailsSent = append(emailsSent, "Task Project comment Update Email sent successfully")
-		}
-
-		log.Println(ownerEmail)
 	}
 
 	// Populate managerResponse dengan data dari updateManager jika tidak kosong
@@ -377,69 +263,130 @@ func (t *taskAndOwnerService) UpdateTaskAndOwner(task *domain.Task, manager *dom
 		}
 	}
 
-	// Populate planningFileResponse dengan data dari updatePlanningFile jika tidak kosong
-	if updatePlanningFile.ID != 0 || updatePlanningFile.FileUrl != "" || updatePlanningFile.FileName != "" {
-		response.PlanningFile.ID = updatePlanningFile.ID
-		response.PlanningFile.FileUrl = updatePlanningFile.FileUrl
-		response.PlanningFile.FileName = updatePlanningFile.FileName
-
-		// notif email
-		ownerEmail, managerEmails, employeeEmails, nametask, _, err := t.taskAndOwnerRepository.GetNameEmailsDescription(uint64(taskID))
-		if err != nil {
-			return nil, err
-		}
-
-		to := []string{ownerEmail}
-		to = append(to, managerEmails...)
-		to = append(to, employeeEmails...)
+	for _, file := range updatePlanningDescriptionFiles {
+		file.FileUrl = helper.FileDownloadURL(updateTask.ID, file.ID, domain.FileTypePlanningDescription)
+		response.PlanningDescriptionFiles = append(response.PlanningDescriptionFiles, web.UpdateResponseFile{ID: file.ID, FileUrl: file.FileUrl, FileName: file.FileName})
+	}
+	for _, file := range updatePlanningFiles {
+		file.FileUrl = helper.FileDownloadURL(updateTask.ID, file.ID, domain.FileTypePlanning)
+		response.PlanningFiles = append(response.PlanningFiles, web.UpdateResponseFile{ID: file.ID, FileUrl: file.FileUrl, FileName: file.FileName, Version: file.Version})
+	}
+	for _, file := range updateProjectFiles {
+		file.FileUrl = helper.FileDownloadURL(updateTask.ID, file.ID, domain.FileTypeProject)
+		response.ProjectFiles = append(response.ProjectFiles, web.UpdateResponseFile{ID: file.ID, FileUrl: file.FileUrl, FileName: file.FileName, Version: file.Version})
+	}
 
-		subject := "Planning File Updated"
-		body := helper.GetEmailTemplate("Planning File Update", nametask, "File Updated", fmt.Sprintf("A planning file has been updated:\nFile Name: %s\nFile URL: %s", updatePlanningFile.FileName, updatePlanningFile.FileUrl))
+	response.EmailsSent = emailsSent
 
-		err = helper.SendEmail(to, subject, body)
-		if err != nil {
-			log.Printf("Failed to send email: %v", err)
-			return nil, fmt.Errorf("failed to send email: %v", err)
-		} else {
-			emailsSent = append(emailsSent, "Task Planning file Update Email sent successfully")
-		}
+	return response, nil
+}
 
-		log.Println(ownerEmail, managerEmails, employeeEmails)
+// dispatchTaskUpdated mengirim event task.updated berisi task setelah perubahan dan nama field yang diubah
+func (t *taskAndOwnerService) dispatchTaskUpdated(taskID uint64, changes []string) {
+	task, err := t.taskAndOwnerRepository.FindById(uint(taskID))
+	if err != nil {
+		log.Printf("Failed to find task %d for webhook: %v", taskID, err)
+		return
 	}
+	t.webhookService.Dispatch(task.BoardID, domain.WebhookEventTaskUpdated, map[string]interface{}{"task": domain.NewWebhookTask(&task.Task), "changes": changes})
+}
 
-	// Populate projectFileResponse dengan data dari updateProjectFile jika tidak kosong
-	if updateProjectFile.ID != 0 || updateProjectFile.FileUrl != "" || updateProjectFile.FileName != "" {
-		response.ProjectFile.ID = updateProjectFile.ID
-		response.ProjectFile.FileUrl = updateProjectFile.FileUrl
-		response.ProjectFile.FileName = updateProjectFile.FileName
-
-		// notif email
-		ownerEmail, managerEmails, employeeEmails, nametask, _, err := t.taskAndOwnerRepository.GetNameEmailsDescription(uint64(taskID))
-		if err != nil {
-			return nil, err
-		}
+// updatedFields adalah nama field yang dikirim pada request update task
+func updatedFields(task *domain.Task, manager *domain.Manager, employee *domain.Employee, planningDescriptionFiles []*domain.PlanningDescriptionFile, planningFiles []*domain.PlanningFile, projectFiles []*domain.ProjectFile) []string {
+	changes := []string{}
+	fields := []struct {
+		name    string
+		changed bool
+	}{
+		{"name_task", task.NameTask != ""},
+		{"planning_description_persen", task.PlanningDescriptionPersen != ""},
+		{"planning_status", task.PlanningStatus != ""},
+		{"project_status", task.ProjectStatus != ""},
+		{"planning_due_date", task.PlanningDueDate != ""},
+		{"project_due_date", task.ProjectDueDate != ""},
+		{"priority", task.Priority != ""},
+		{"project_comment", task.ProjectComment != ""},
+		{"manager", manager != nil && manager.Email != ""},
+		{"employee", employee != nil && employee.Email != ""},
+		{"planning_description_files", len(planningDescriptionFiles) > 0},
+		{"planning_files", len(planningFiles) > 0},
+		{"project_files", len(projectFiles) > 0},
+	}
+	for _, field := range fields {
+		if field.changed {
+			changes = append(changes, field.name)
+		}
+	}
+	return changes
+}
 
-		to := []string{ownerEmail}
-		to = append(to, managerEmails...)
-		to = append(to, employeeEmails...)
+// syncDueDateEvent membuat atau memperbarui event pada Google Calendar owner task di tanggal jatuh tempo planning untuk
+// manager atau project untuk employee. dueDate kosong hanya menyamakan attendee event yang sudah ada dengan anggota task.
+// Owner yang belum menghubungkan calendar dilewati, kegagalan hanya dicatat karena perubahan task sudah tersimpan
+func (t *taskAndOwnerService) syncDueDateEvent(taskID uint64, ownerUserID uint64, dueType string, dueDate time.Time) {
+	_, managerEmails, employeeEmails, description, nametask, err := t.taskAndOwnerRepository.GetNameEmailsDescription(taskID)
+	if err != nil {
+		log.Printf("Failed to find task %d for calendar event: %v", taskID, err)
+		return
+	}
 
-		subject := "Project File Updated"
-		body := helper.GetEmailTemplate("Project File Update", nametask, "File Updated", fmt.Sprintf("A project file has been updated:\nFile Name: %s\nFile URL: %s", updateProjectFile.FileName, updateProjectFile.FileUrl))
+	attendees := employeeEmails
+	if dueType == domain.CalendarDueTypePlanning {
+		attendees = managerEmails
+	}
 
-		err = helper.SendEmail(to, subject, body)
-		if err != nil {
-			log.Printf("Failed to send email: %v", err)
-			return nil, fmt.Errorf("failed to send email: %v", err)
-		} else {
-			emailsSent = append(emailsSent, "Task Project file Update Email sent successfully")
-		}
+	event := &CalendarEvent{
+		Summary:     fmt.Sprintf("Task: %s", nametask),
+		Description: description,
+		End:         dueDate,
+		TimeZone:    "Asia/Jakarta", // Adjust to desired timezone
+		Attendees:   attendees,
+	}
+	// Use current time for start
+	if !dueDate.IsZero() {
+		event.Start = time.Now()
+	}
 
-		log.Println(ownerEmail, managerEmails, employeeEmails)
+	calendarEvent, err := t.calendarService.SyncTaskEvent(ownerUserID, taskID, dueType, event)
+	switch {
+	case errors.Is(err, ErrCalendarNotConnected), errors.Is(err, ErrCalendarNotConfigured):
+		log.Printf("Google Calendar event for task %d skipped: %v", taskID, err)
+	case err != nil:
+		log.Printf("Failed to sync Google Calendar event of task %d (%s): %v", taskID, dueType, err)
+	case calendarEvent != nil:
+		log.Printf("Google Calendar event synced: %s", calendarEvent.HtmlLink)
 	}
+}
 
-	response.EmailsSent = emailsSent
+// syncCalendarAttendees menyamakan attendee event tenggat task setelah manager atau employee bergabung atau dihapus
+func (t *taskAndOwnerService) syncCalendarAttendees(taskID uint64) {
+	task, err := t.taskAndOwnerRepository.FindById(uint(taskID))
+	if err != nil {
+		log.Printf("Failed to find task %d for calendar event: %v", taskID, err)
+		return
+	}
+	for _, dueType := range []string{domain.CalendarDueTypePlanning, domain.CalendarDueTypeProject} {
+		t.syncDueDateEvent(taskID, task.Owner.UserID, dueType, time.Time{})
+	}
+}
 
-	return response, nil
+// taskNotification menyusun pesan outbox dari template email. Subject dan body dirender dengan bahasa default
+// untuk ditampilkan di endpoint admin, saat dikirim template dirender ulang sesuai bahasa masing-masing penerima
+func taskNotification(event string, taskID uint64, to []string, template string, data domain.EmailData) (*domain.OutboxMessage, error) {
+	rendered, err := helper.RenderEmail(template, helper.DefaultLocale, &data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s email for task %d: %w", template, taskID, err)
+	}
+	return &domain.OutboxMessage{
+		Event:      event,
+		TaskID:     taskID,
+		Channel:    domain.OutboxChannelEmail,
+		Recipients: to,
+		Template:   template,
+		Data:       data,
+		Subject:    rendered.Subject,
+		Body:       rendered.HTMLBody,
+	}, nil
 }
 
 func (t *taskAndOwnerService) RespondToInvitation(invitationID uint64, response string) (*domain.Invitation, error) {
@@ -453,10 +400,10 @@ func (t *taskAndOwnerService) RespondToInvitation(invitationID uint64, response
 		// Tambahkan user ke task sesuai role
 		if invitation.Role == "manager" {
 			manager := &domain.Manager{UserID: invitation.UserID}
-			_, _, _, _, _, _, _, _, err = t.taskAndOwnerRepository.Update(&domain.Task{ID: invitation.TaskID}, manager, nil, nil, nil, nil)
+			_, _, _, _, _, _, _, _, err = t.taskAndOwnerRepository.Update(&domain.Task{ID: invitation.TaskID}, manager, nil, nil, nil, nil, 0, nil)
 		} else if invitation.Role == "employee" {
 			employee := &domain.Employee{UserID: invitation.UserID}
-			_, _, _, _, _, _, _, _, err = t.taskAndOwnerRepository.Update(&domain.Task{ID: invitation.TaskID}, nil, employee, nil, nil, nil)
+			_, _, _, _, _, _, _, _, err = t.taskAndOwnerRepository.Update(&domain.Task{ID: invitation.TaskID}, nil, employee, nil, nil, nil, 0, nil)
 		}
 	} else if response == "reject" {
 		invitation.Status = "rejected"
@@ -468,10 +415,86 @@ func (t *taskAndOwnerService) RespondToInvitation(invitationID uint64, response
 	if err != nil {
 		return nil, err
 	}
+	t.notifyInvitationResponse(invitation)
+	if invitation.Status == "accepted" {
+		t.dispatchInvitationAccepted(invitation)
+		t.syncCalendarAttendees(invitation.TaskID)
+	}
 
 	return invitation, nil
 }
 
+// notifyInvitationResponse memberi tahu owner task dan integrasi chat board bahwa undangan diterima atau ditolak
+func (t *taskAndOwnerService) notifyInvitationResponse(invitation *domain.Invitation) {
+	ownerEmail, _, _, _, nameTask, err := t.taskAndOwnerRepository.GetNameEmailsDescription(invitation.TaskID)
+	if err != nil {
+		log.Printf("Failed to find owner of task %d: %v", invitation.TaskID, err)
+		return
+	}
+
+	data := domain.EmailData{TaskName: nameTask, Value: invitation.Role, Status: invitation.Status, Detail: invitation.UserEmail}
+	message, err := taskNotification("task.invitation_responded", invitation.TaskID, []string{ownerEmail}, "invitation_responded", data)
+	if err != nil {
+		log.Printf("Failed to queue invitation response notification: %v", err)
+		return
+	}
+	messages := []*domain.OutboxMessage{message}
+	if task, err := t.taskAndOwnerRepository.FindById(uint(invitation.TaskID)); err == nil {
+		messages = append(messages, t.chatService.Messages(task.BoardID, invitation.UserID, messages)...)
+	} else {
+		log.Printf("Failed to find board of task %d for chat messages: %v", invitation.TaskID, err)
+	}
+	if err := t.notificationService.Enqueue(messages...); err != nil {
+		log.Printf("Failed to queue invitation response notification: %v", err)
+	}
+}
+
+// AddCommentReply menambahkan balasan email anggota task sebagai komentar project. Anggota task lainnya diberi tahu
+// seperti komentar dari endpoint update, pengirim balasan tidak dikirimi notifikasinya sendiri
+func (t *taskAndOwnerService) AddCommentReply(taskID uint64, user *domain.User, comment string) error {
+	task, err := t.taskAndOwnerRepository.FindById(uint(taskID))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrTaskNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err := t.taskAndOwnerRepository.ValidationTaskMember(uint(taskID), uint(user.ID)); err != nil {
+		return ErrNotTaskMember
+	}
+
+	notify := func(recipients domain.TaskRecipients) ([]*domain.OutboxMessage, error) {
+		var to []string
+		for _, email := range recipients.Members() {
+			if !strings.EqualFold(email, user.Email) {
+				to = append(to, email)
+			}
+		}
+		message, err := taskNotification("task.comment_added", taskID, to, "comment_added",
+			domain.EmailData{TaskName: recipients.TaskName, Value: comment})
+		if err != nil {
+			return nil, err
+		}
+		messages := []*domain.OutboxMessage{message}
+		return append(messages, t.chatService.Messages(task.BoardID, user.ID, messages)...), nil
+	}
+	if err := t.taskAndOwnerRepository.AppendComment(taskID, comment, notify); err != nil {
+		return err
+	}
+	t.notificationService.Schedule()
+	t.dispatchTaskUpdated(taskID, []string{"project_comment"})
+	return nil
+}
+
+func (t *taskAndOwnerService) dispatchInvitationAccepted(invitation *domain.Invitation) {
+	task, err := t.taskAndOwnerRepository.FindById(uint(invitation.TaskID))
+	if err != nil {
+		log.Printf("Failed to find task %d for webhook: %v", invitation.TaskID, err)
+		return
+	}
+	t.webhookService.Dispatch(task.BoardID, domain.WebhookEventInvitationAccepted, map[string]interface{}{"invitation": invitation, "task": domain.NewWebhookTask(&task.Task)})
+}
+
 func (t *taskAndOwnerService) GetAllInvitations() ([]domain.Invitation, error) {
 	return t.taskAndOwnerRepository.GetAllInvitations()
 }
@@ -508,6 +531,7 @@ func (t *taskAndOwnerService) DeleteManager(taskId uint, managerId uint) error {
 	if err != nil {
 		return err
 	}
+	t.syncCalendarAttendees(uint64(taskId))
 
 	var manager domain.Manager
 	err = helper.ResetAutoIncrement(db, &manager, "id", "managers")
@@ -547,6 +571,7 @@ func (t *taskAndOwnerService) DeleteEmployee(taskId uint, employeeId uint) error
 	if err != nil {
 		return err
 	}
+	t.syncCalendarAttendees(uint64(taskId))
 
 	var employee domain.Employee
 	err = helper.ResetAutoIncrement(db, &employee, "id", "employees")
@@ -565,62 +590,75 @@ func (t *taskAndOwnerService) DeleteEmployee(taskId uint, employeeId uint) error
 	return nil
 }
 
-func (t *taskAndOwnerService) DeletePlanningDescriptionFile(fileId uint) (string, error) {
-	db, fileName, err := t.taskAndOwnerRepository.DeletePlanningDescriptionFile(fileId)
+func (t *taskAndOwnerService) DeletePlanningDescriptionFile(fileId uint) ([]string, error) {
+	db, fileKeys, err := t.taskAndOwnerRepository.DeletePlanningDescriptionFile(fileId)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var planningDescriptionFile domain.PlanningDescriptionFile
 	err = helper.ResetAutoIncrement(db, &planningDescriptionFile, "id", "planning_description_files")
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fileName, nil
+	return fileKeys, nil
 }
 
-func (t *taskAndOwnerService) DeletePlanningFile(fileId uint) (string, error) {
-	db, fileName, err := t.taskAndOwnerRepository.DeletePlanningFile(fileId)
+func (t *taskAndOwnerService) DeletePlanningFile(fileId uint) ([]string, error) {
+	db, fileKeys, err := t.taskAndOwnerRepository.DeletePlanningFile(fileId)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var planningFile domain.PlanningFile
 	err = helper.ResetAutoIncrement(db, &planningFile, "id", "planning_files")
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fileName, nil
+	return fileKeys, nil
 }
 
-func (t *taskAndOwnerService) DeleteProjectFile(fileId uint) (string, error) {
-	db, fileName, err := t.taskAndOwnerRepository.DeleteProjectFile(fileId)
+func (t *taskAndOwnerService) DeleteProjectFile(fileId uint) ([]string, error) {
+	db, fileKeys, err := t.taskAndOwnerRepository.DeleteProjectFile(fileId)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var projectFile domain.ProjectFile
 	err = helper.ResetAutoIncrement(db, &projectFile, "id", "project_files")
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fileName, nil
+	return fileKeys, nil
 }
 
 func (t *taskAndOwnerService) DeleteTaskAndOwner(taskID uint) error {
-	db, countOwners, countManager, countEmployee, countPlanningFile, countProjectFile, countPlanningDescriptionFile, err := t.taskAndOwnerRepository.Delete(taskID)
+	// ambil key file milik task sebelum record-nya dihapus, hanya objek tersebut yang dihapus dari storage
+	fileKeys, err := t.fileService.TaskFileKeys(uint64(taskID))
 	if err != nil {
 		return err
 	}
-	if err == nil {
-		err = helper.SetupS3DeleteAll()
-		if err != nil {
-			return err
-		}
+	// data task untuk webhook task.deleted diambil sebelum task dihapus
+	deletedTask, err := t.taskAndOwnerRepository.FindById(taskID)
+	if err != nil {
+		return err
+	}
+	// event calendar task dibatalkan setelah task terhapus
+	calendarEvents, err := t.calendarService.TaskEvents(uint64(taskID))
+	if err != nil {
+		return err
+	}
+
+	db, countOwners, countManager, countEmployee, countPlanningFile, countProjectFile, countPlanningDescriptionFile, err := t.taskAndOwnerRepository.Delete(taskID)
+	if err != nil {
+		return err
 	}
+	t.fileService.ReleaseFiles(fileKeys)
+	t.calendarService.CancelEvents(calendarEvents)
+	t.webhookService.Dispatch(deletedTask.BoardID, domain.WebhookEventTaskDeleted, map[string]interface{}{"task": domain.NewWebhookTask(&deletedTask.Task)})
 
 	// reset auto increment
 	if countOwners > 0 {
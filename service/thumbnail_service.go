@@ -0,0 +1,7 @@
+package service
+
+type ThumbnailService interface {
+	Schedule()
+	Scheduled() <-chan struct{}
+	GeneratePending() (int, error)
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/repository"
+	"os"
+	"time"
+)
+
+const (
+	defaultThumbnailSize       = 256
+	defaultThumbnailPDFCommand = "pdftoppm"
+	thumbnailBatchSize         = 20
+	thumbnailTimeout           = time.Minute
+)
+
+type thumbnailService struct {
+	storage        helper.Storage
+	fileRepository repository.FileRepository
+	size           int
+	pdfCommand     string
+	scheduled      chan struct{}
+}
+
+func NewThumbnailService(storage helper.Storage, fileRepository repository.FileRepository) ThumbnailService {
+	size := helper.IntFromEnv("THUMBNAIL_SIZE", defaultThumbnailSize)
+	if size <= 0 {
+		size = defaultThumbnailSize
+	}
+
+	pdfCommand := os.Getenv("THUMBNAIL_PDF_COMMAND")
+	if pdfCommand == "" {
+		pdfCommand = defaultThumbnailPDFCommand
+	}
+
+	return &thumbnailService{
+		storage:        storage,
+		fileRepository: fileRepository,
+		size:           size,
+		pdfCommand:     pdfCommand,
+		scheduled:      make(chan struct{}, 1),
+	}
+}
+
+// thumbnailStatus adalah status awal thumbnail untuk stored object baru dengan content type tersebut
+func thumbnailStatus(contentType string) string {
+	if helper.CanGenerateThumbnail(contentType) {
+		return domain.ThumbnailStatusPending
+	}
+	return ""
+}
+
+// Schedule membangunkan worker thumbnail setelah ada objek baru, tidak menunggu jika worker sudah dijadwalkan
+func (t *thumbnailService) Schedule() {
+	select {
+	case t.scheduled <- struct{}{}:
+	default:
+	}
+}
+
+func (t *thumbnailService) Scheduled() <-chan struct{} {
+	return t.scheduled
+}
+
+// GeneratePending membuat thumbnail untuk semua objek yang masih pending dan mengembalikan jumlah thumbnail yang berhasil dibuat
+func (t *thumbnailService) GeneratePending() (int, error) {
+	generated := 0
+	for {
+		objects, err := t.fileRepository.FindPendingThumbnails(thumbnailBatchSize)
+		if err != nil {
+			return generated, err
+		}
+		if len(objects) == 0 {
+			return generated, nil
+		}
+
+		for _, object := range objects {
+			status := domain.ThumbnailStatusReady
+			if err := t.generate(object); err != nil {
+				status = domain.ThumbnailStatusFailed
+				if errors.Is(err, helper.ErrThumbnailNotSupported) {
+					status = domain.ThumbnailStatusUnsupported
+				}
+				log.Printf("Failed to generate thumbnail of %s: %v", object.ObjectKey, err)
+			} else {
+				generated++
+			}
+
+			if err := t.fileRepository.UpdateThumbnailStatus(object.ObjectKey, status); err != nil {
+				return generated, err
+			}
+		}
+	}
+}
+
+func (t *thumbnailService) generate(object domain.StoredObject) error {
+	ctx, cancel := context.WithTimeout(context.Background(), thumbnailTimeout)
+	defer cancel()
+
+	body, err := t.storage.Get(ctx, object.ObjectKey)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	thumbnail, err := helper.GenerateThumbnail(ctx, body, object.ContentType, t.size, t.pdfCommand)
+	if err != nil {
+		return err
+	}
+
+	return t.storage.Put(ctx, helper.ThumbnailKey(object.ObjectKey), bytes.NewReader(thumbnail), "image/png")
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/repository"
+)
+
+var (
+	ErrInvalidUpload        = errors.New("Invalid upload")
+	ErrUploadNotFound       = errors.New("Upload not found")
+	ErrUploadOffsetMismatch = repository.ErrUploadOffsetMismatch
+	ErrUploadChunkTooSmall  = errors.New("Upload chunk too small")
+	ErrUploadChunkTooLarge  = errors.New("Upload chunk exceeds the declared upload length")
+	ErrUploadIncomplete     = errors.New("Upload incomplete")
+	ErrUploadNotAttachable  = repository.ErrUploadNotAttachable
+)
+
+type UploadService interface {
+	CreateUpload(taskID uint64, userID uint64, fileType string, fileName string, size int64) (*domain.UploadSession, error)
+	GetUpload(id string, userID uint64) (*domain.UploadSession, error)
+	AppendChunk(id string, userID uint64, offset int64, chunk io.Reader, size int64) (*domain.UploadSession, error)
+	FinalizeUpload(id string, userID uint64) (*domain.UploadSession, error)
+	CancelUpload(id string, userID uint64) error
+	ClaimUploads(ids []string, userID uint64, taskID uint64, fileType string) ([]UploadedFile, error)
+	UnclaimUploads(ids []string)
+	CleanupExpiredUploads() (int, error)
+}
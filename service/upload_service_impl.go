@@ -0,0 +1,444 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/repository"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultUploadSessionTTL = 24 * time.Hour
+
+	// S3 mensyaratkan setiap part kecuali part terakhir minimal 5MB dan paling banyak 10000 part
+	minUploadChunkSize = 5 << 20
+	maxUploadParts     = 10000
+)
+
+var ErrUploadClosed = errors.New("Upload no longer accepts changes")
+
+type uploadService struct {
+	storage          helper.Storage
+	uploadRepository repository.UploadRepository
+	fileRepository   repository.FileRepository
+	fileService      FileService
+	thumbnailService ThumbnailService
+	sessionTTL       time.Duration
+	// chunk dan finalize pada upload yang sama diproses bergantian agar part pada storage tidak saling menimpa
+	locks [64]sync.Mutex
+}
+
+func NewUploadService(storage helper.Storage, uploadRepository repository.UploadRepository, fileRepository repository.FileRepository, fileService FileService, thumbnailService ThumbnailService) UploadService {
+	sessionTTL := helper.DurationFromEnv("UPLOAD_SESSION_TTL", defaultUploadSessionTTL)
+	if sessionTTL == 0 {
+		sessionTTL = defaultUploadSessionTTL
+	}
+
+	return &uploadService{
+		storage:          storage,
+		uploadRepository: uploadRepository,
+		fileRepository:   fileRepository,
+		fileService:      fileService,
+		thumbnailService: thumbnailService,
+		sessionTTL:       sessionTTL,
+	}
+}
+
+func (u *uploadService) lock(id string) func() {
+	hash := fnv.New32a()
+	hash.Write([]byte(id))
+	mutex := &u.locks[hash.Sum32()%uint32(len(u.locks))]
+	mutex.Lock()
+	return mutex.Unlock
+}
+
+// CreateUpload memulai resumable upload untuk planning file atau project file, hak user atas jenis file diperiksa oleh controller.
+// Ukuran dan jumlah file diperiksa di awal agar client tidak mengirim file yang pasti ditolak, jenis isi file baru bisa diperiksa saat finalize
+func (u *uploadService) CreateUpload(taskID uint64, userID uint64, fileType string, fileName string, size int64) (*domain.UploadSession, error) {
+	if fileType != domain.FileTypePlanning && fileType != domain.FileTypeProject {
+		return nil, fmt.Errorf("%w: invalid file type %q", ErrInvalidUpload, fileType)
+	}
+	fileName = filepath.Base(filepath.Clean("/" + fileName))
+	if fileName == "/" || fileName == "." {
+		return nil, fmt.Errorf("%w: file name is required", ErrInvalidUpload)
+	}
+	if size <= 0 {
+		return nil, fmt.Errorf("%w: size must be greater than 0", ErrInvalidUpload)
+	}
+
+	if err := u.fileService.CheckUploadSize(fileName, size, fileType); err != nil {
+		return nil, err
+	}
+	if err := u.fileService.CheckFileCount(taskID, fileType, []string{fileName}); err != nil {
+		return nil, err
+	}
+
+	boardID, err := u.uploadRepository.FindTaskBoardID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: task not found", ErrInvalidUpload)
+		}
+		return nil, err
+	}
+
+	id, err := newUploadID()
+	if err != nil {
+		return nil, err
+	}
+	objectKey := helper.UploadObjectKey(boardID, taskID, fileType, id, fileName)
+	storageUploadID, err := u.storage.CreateMultipartUpload(context.TODO(), objectKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	session := &domain.UploadSession{
+		ID:              id,
+		TaskID:          taskID,
+		BoardID:         boardID,
+		UserID:          userID,
+		FileType:        fileType,
+		FileName:        fileName,
+		Size:            size,
+		ObjectKey:       objectKey,
+		StorageUploadID: storageUploadID,
+		Status:          domain.UploadStatusUploading,
+		ExpiresAt:       time.Now().Add(u.sessionTTL),
+	}
+	if err := u.uploadRepository.Create(session); err != nil {
+		if abortErr := u.storage.AbortMultipartUpload(context.TODO(), objectKey, storageUploadID); abortErr != nil {
+			log.Printf("Failed to abort multipart upload %s: %v", objectKey, abortErr)
+		}
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func newUploadID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// GetUpload mengembalikan upload milik user, upload milik user lain atau yang sudah kedaluwarsa dianggap tidak ada
+func (u *uploadService) GetUpload(id string, userID uint64) (*domain.UploadSession, error) {
+	session, err := u.uploadRepository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUploadNotFound
+		}
+		return nil, err
+	}
+	if session.UserID != userID || session.ExpiresAt.Before(time.Now()) {
+		return nil, ErrUploadNotFound
+	}
+	return session, nil
+}
+
+// AppendChunk menyimpan potongan berikutnya sebagai satu part, offset harus sama dengan jumlah byte yang sudah diterima
+// sehingga client yang terputus cukup menanyakan offset terakhir lalu melanjutkan dari sana
+func (u *uploadService) AppendChunk(id string, userID uint64, offset int64, chunk io.Reader, size int64) (*domain.UploadSession, error) {
+	defer u.lock(id)()
+
+	session, err := u.GetUpload(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != domain.UploadStatusUploading {
+		return nil, fmt.Errorf("%w: upload is %s", ErrUploadClosed, session.Status)
+	}
+	if offset != session.Offset {
+		return nil, fmt.Errorf("%w: expected offset %d", ErrUploadOffsetMismatch, session.Offset)
+	}
+	if size == 0 {
+		return session, nil
+	}
+	if offset+size > session.Size {
+		return nil, fmt.Errorf("%w of %d bytes", ErrUploadChunkTooLarge, session.Size)
+	}
+	if offset+size < session.Size && size < minUploadChunkSize {
+		return nil, fmt.Errorf("%w: every chunk except the last must be at least %d bytes", ErrUploadChunkTooSmall, minUploadChunkSize)
+	}
+
+	parts, err := u.uploadRepository.FindParts(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	partNumber := len(parts) + 1
+	if partNumber > maxUploadParts {
+		return nil, fmt.Errorf("%w: an upload can have at most %d chunks", ErrUploadChunkTooSmall, maxUploadParts)
+	}
+
+	etag, err := u.storage.UploadPart(context.TODO(), session.ObjectKey, session.StorageUploadID, partNumber, chunk, size)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.uploadRepository.AppendPart(session, &domain.UploadSessionPart{
+		SessionID:  session.ID,
+		PartNumber: partNumber,
+		ETag:       etag,
+		Size:       size,
+	}); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// FinalizeUpload menggabungkan semua part menjadi satu objek lalu memeriksa isinya terhadap upload policy.
+// Objek dengan isi yang sudah pernah tersimpan dihapus dan upload memakai objek yang sudah ada. Upload yang sudah
+// digabung tetapi gagal diperiksa karena gangguan sementara tetap assembled sehingga finalize bisa diulang
+func (u *uploadService) FinalizeUpload(id string, userID uint64) (*domain.UploadSession, error) {
+	defer u.lock(id)()
+
+	session, err := u.GetUpload(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	switch session.Status {
+	case domain.UploadStatusUploading:
+		if err := u.assembleUpload(session); err != nil {
+			return nil, err
+		}
+	case domain.UploadStatusAssembled:
+	default:
+		return nil, fmt.Errorf("%w: upload is %s", ErrUploadClosed, session.Status)
+	}
+
+	contentType, checksum, scanStatus, err := u.inspectUpload(session)
+	if err != nil {
+		if uploadRejected(err) {
+			u.rejectUpload(session)
+		}
+		return nil, err
+	}
+
+	object, err := u.fileRepository.AcquireObject(&domain.StoredObject{
+		ObjectKey:       session.ObjectKey,
+		Checksum:        checksum,
+		Size:            session.Size,
+		ContentType:     contentType,
+		ThumbnailStatus: thumbnailStatus(contentType),
+		ScanStatus:      scanStatus,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if object.ScanStatus == domain.ScanStatusInfected {
+		u.fileService.ReleaseFiles([]string{object.ObjectKey})
+		u.rejectUpload(session)
+		return nil, fmt.Errorf("%w: %s was previously quarantined", ErrFileInfected, session.FileName)
+	}
+
+	// objek hasil penggabungan baru dihapus setelah upload tercatat completed agar finalize yang gagal bisa diulang
+	assembledKey := session.ObjectKey
+	session.ObjectKey = object.ObjectKey
+	session.Status = domain.UploadStatusCompleted
+	session.ContentType = contentType
+	session.Checksum = checksum
+	session.ScanStatus = object.ScanStatus
+	if err := u.uploadRepository.Update(session); err != nil {
+		// hanya referensinya yang dilepas, objek hasil penggabungan tetap dipakai saat finalize diulang
+		if _, releaseErr := u.fileRepository.ReleaseObject(object.ObjectKey); releaseErr != nil {
+			log.Printf("Failed to release object %s: %v", object.ObjectKey, releaseErr)
+		}
+		return nil, err
+	}
+	if object.ObjectKey != assembledKey {
+		if err := u.storage.Delete(context.TODO(), assembledKey); err != nil {
+			log.Printf("Failed to delete duplicate upload %s: %v", assembledKey, err)
+		}
+	} else if object.ThumbnailStatus == domain.ThumbnailStatusPending {
+		u.thumbnailService.Schedule()
+	}
+
+	return session, nil
+}
+
+// assembleUpload menggabungkan semua part menjadi satu objek dan langsung mencatat upload sebagai assembled, karena
+// multipart upload pada storage sudah tidak ada lagi setelah digabung
+func (u *uploadService) assembleUpload(session *domain.UploadSession) error {
+	if session.Offset != session.Size {
+		return fmt.Errorf("%w: received %d of %d bytes", ErrUploadIncomplete, session.Offset, session.Size)
+	}
+
+	parts, err := u.uploadRepository.FindParts(session.ID)
+	if err != nil {
+		return err
+	}
+	uploadedParts := make([]helper.UploadedPart, 0, len(parts))
+	for _, part := range parts {
+		uploadedParts = append(uploadedParts, helper.UploadedPart{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+	if err := u.storage.CompleteMultipartUpload(context.TODO(), session.ObjectKey, session.StorageUploadID, uploadedParts); err != nil {
+		return err
+	}
+
+	session.StorageUploadID = ""
+	session.Status = domain.UploadStatusAssembled
+	return u.uploadRepository.Update(session)
+}
+
+// uploadRejected mengembalikan true jika isi upload ditolak sehingga finalize tidak ada gunanya diulang
+func uploadRejected(err error) bool {
+	return errors.Is(err, ErrFileTooLarge) || errors.Is(err, ErrFileTypeNotAllowed) || errors.Is(err, ErrFileInfected)
+}
+
+// rejectUpload menghapus objek dan catatan upload yang isinya ditolak, karena upload tidak bisa dilanjutkan
+func (u *uploadService) rejectUpload(session *domain.UploadSession) {
+	if err := u.storage.Delete(context.TODO(), session.ObjectKey); err != nil {
+		log.Printf("Failed to delete rejected upload %s: %v", session.ObjectKey, err)
+	}
+	if _, err := u.uploadRepository.Delete(session.ID, session.Status); err != nil {
+		log.Printf("Failed to delete rejected upload %s: %v", session.ID, err)
+	}
+}
+
+// inspectUpload membaca ulang objek yang sudah digabung ke file sementara untuk menghitung checksum, mendeteksi content type
+// dan memindai virus, sama dengan pemeriksaan pada upload biasa
+func (u *uploadService) inspectUpload(session *domain.UploadSession) (string, string, string, error) {
+	body, err := u.storage.Get(context.TODO(), session.ObjectKey)
+	if err != nil {
+		return "", "", "", err
+	}
+	defer body.Close()
+
+	tmp, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return "", "", "", err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), body)
+	if err != nil {
+		return "", "", "", err
+	}
+	if size != session.Size {
+		return "", "", "", fmt.Errorf("%w: stored %d of %d bytes", ErrUploadIncomplete, size, session.Size)
+	}
+
+	contentType, err := u.fileService.CheckUploadPolicy(session.FileName, size, tmp, session.FileType)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	scanStatus, err := u.fileService.ScanFile(session.TaskID, session.FileType, session.FileName, checksum, tmp)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return contentType, checksum, scanStatus, nil
+}
+
+// CancelUpload membatalkan upload yang belum dilampirkan beserta part atau objek yang sudah tersimpan
+func (u *uploadService) CancelUpload(id string, userID uint64) error {
+	defer u.lock(id)()
+
+	session, err := u.GetUpload(id, userID)
+	if err != nil {
+		return err
+	}
+	if session.Status == domain.UploadStatusAttached {
+		return fmt.Errorf("%w: upload is %s", ErrUploadClosed, session.Status)
+	}
+
+	_, err = u.removeUpload(*session)
+	return err
+}
+
+// removeUpload menghapus catatan upload lalu membersihkan storage sesuai statusnya, false jika upload sudah berubah lebih dulu
+func (u *uploadService) removeUpload(session domain.UploadSession) (bool, error) {
+	deleted, err := u.uploadRepository.Delete(session.ID, session.Status)
+	if err != nil || !deleted {
+		return false, err
+	}
+
+	switch session.Status {
+	case domain.UploadStatusUploading:
+		if err := u.storage.AbortMultipartUpload(context.TODO(), session.ObjectKey, session.StorageUploadID); err != nil {
+			log.Printf("Failed to abort multipart upload %s: %v", session.ObjectKey, err)
+		}
+	case domain.UploadStatusAssembled:
+		if err := u.storage.Delete(context.TODO(), session.ObjectKey); err != nil {
+			log.Printf("Failed to delete assembled upload %s: %v", session.ObjectKey, err)
+		}
+	case domain.UploadStatusCompleted:
+		u.fileService.ReleaseFiles([]string{session.ObjectKey})
+	}
+	return true, nil
+}
+
+// ClaimUploads mengambil upload yang sudah selesai untuk dilampirkan pada task, referensi objeknya berpindah ke file yang dibuat.
+// Jika update task gagal, upload dikembalikan dengan UnclaimUploads dan bukan dilepas
+func (u *uploadService) ClaimUploads(ids []string, userID uint64, taskID uint64, fileType string) ([]UploadedFile, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	sessions, err := u.uploadRepository.Claim(ids, userID, taskID, fileType)
+	if err != nil {
+		return nil, err
+	}
+
+	uploaded := make([]UploadedFile, 0, len(sessions))
+	for _, session := range sessions {
+		uploaded = append(uploaded, UploadedFile{
+			Key:         session.ObjectKey,
+			FileName:    session.FileName,
+			Size:        session.Size,
+			ContentType: session.ContentType,
+			Checksum:    session.Checksum,
+			ScanStatus:  session.ScanStatus,
+		})
+	}
+	return uploaded, nil
+}
+
+func (u *uploadService) UnclaimUploads(ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+	if err := u.uploadRepository.Unclaim(ids); err != nil {
+		log.Printf("Failed to restore uploads %v: %v", ids, err)
+	}
+}
+
+// CleanupExpiredUploads membatalkan upload yang melewati UPLOAD_SESSION_TTL dan menghapus catatan upload yang sudah dilampirkan
+func (u *uploadService) CleanupExpiredUploads() (int, error) {
+	sessions, err := u.uploadRepository.FindExpired(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, session := range sessions {
+		deleted, err := u.removeUpload(session)
+		if err != nil {
+			log.Printf("Failed to remove expired upload %s: %v", session.ID, err)
+			continue
+		}
+		if deleted {
+			removed++
+		}
+	}
+	return removed, nil
+}
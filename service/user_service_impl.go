@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"manajemen_tugas_master/helper"
@@ -17,6 +18,7 @@ import (
 
 type userService struct {
 	userRepository repository.UserRepository
+	mailer         helper.Mailer
 	validator      *validator.Validate
 	resetCodes     map[string]resetCodeInfo
 	resetMutex     sync.Mutex
@@ -27,9 +29,10 @@ type resetCodeInfo struct {
 	expiresAt time.Time
 }
 
-func NewUserService(userRepository repository.UserRepository, validator *validator.Validate) UserService {
+func NewUserService(userRepository repository.UserRepository, mailer helper.Mailer, validator *validator.Validate) UserService {
 	return &userService{
 		userRepository: userRepository,
+		mailer:         mailer,
 		validator:      validator,
 		resetCodes:     make(map[string]resetCodeInfo),
 	}
@@ -170,8 +173,8 @@ func (s *userService) InitiateForgotPassword(email string) error {
 		return errors.New("Invalid email format")
 	}
 
-	// Periksa apakah user ada, tanpa menyimpan hasilnya ke variabel
-	_, err := s.userRepository.GetUserByEmail(email)
+	// Periksa apakah user ada, bahasanya dipakai untuk email kode reset
+	user, err := s.userRepository.GetUserByEmail(email)
 	if err != nil {
 		return errors.New("User not found")
 	}
@@ -185,10 +188,13 @@ func (s *userService) InitiateForgotPassword(email string) error {
 	}
 	s.resetMutex.Unlock()
 
-	subject := "Password Reset Code"
-	body := helper.ForgotPasswordTemplate(resetCode)
+	rendered, err := helper.RenderEmail("password_reset", user.Locale, &domain.EmailData{Code: resetCode})
+	if err != nil {
+		return errors.New("Failed to send reset email")
+	}
 
-	err = helper.SendEmail([]string{email}, subject, body)
+	// kode reset dikirim langsung, bukan lewat outbox, agar user tahu saat itu juga jika email gagal dikirim
+	err = helper.SendToEach(context.TODO(), s.mailer, []string{email}, rendered.Subject, rendered.HTMLBody, rendered.TextBody, nil)
 	if err != nil {
 		return errors.New("Failed to send reset email")
 	}
@@ -259,6 +265,15 @@ func (s *userService) UpdateUser(user *domain.User) (*domain.User, error) {
 		return nil, errors.New(errMsg)
 	}
 
+	// bahasa email tidak berubah jika tidak dikirim pada request
+	if user.Locale == "" {
+		existingUser, err := s.userRepository.FindById(user.ID)
+		if err != nil {
+			return nil, errors.New("User not found")
+		}
+		user.Locale = existingUser.Locale
+	}
+
 	updateUser, err := s.userRepository.Update(user)
 	if err != nil {
 		return nil, errors.New("User not found")
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"errors"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/model/web"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("Webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("Webhook delivery not found")
+	ErrNotBoardOwner           = errors.New("Only the board owner can manage webhooks")
+	ErrInvalidWebhookURL       = errors.New("Webhook url must be an absolute http or https url of a public host")
+	ErrInvalidWebhookEvent     = errors.New("Invalid webhook event")
+)
+
+type WebhookService interface {
+	CreateWebhook(userID uint64, boardID uint64, request *web.CreateWebhookRequest) (*web.CreatedWebhookResponse, error)
+	GetWebhooks(userID uint64, boardID uint64) ([]domain.Webhook, error)
+	DeleteWebhook(userID uint64, boardID uint64, webhookID uint64) error
+	GetDeliveries(userID uint64, boardID uint64, webhookID uint64, limit int, offset int) ([]domain.WebhookDelivery, int64, error)
+	GetDelivery(userID uint64, boardID uint64, webhookID uint64, deliveryID uint64) (*domain.WebhookDelivery, error)
+	Redeliver(userID uint64, boardID uint64, webhookID uint64, deliveryID uint64) (*domain.WebhookDelivery, error)
+	Dispatch(boardID uint64, event string, data interface{})
+	DeleteBoardWebhooks(boardID uint64)
+	Schedule()
+	Scheduled() <-chan struct{}
+	DeliverPending() (int, error)
+}
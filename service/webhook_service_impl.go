@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"manajemen_tugas_master/helper"
+	"manajemen_tugas_master/model/domain"
+	"manajemen_tugas_master/model/web"
+	"manajemen_tugas_master/repository"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultWebhookWorkers     = 4
+	defaultWebhookMaxAttempts = 6
+	defaultWebhookRetryBase   = 30 * time.Second
+	defaultWebhookRetryMax    = time.Hour
+	defaultWebhookTimeout     = 10 * time.Second
+	webhookBatchSize          = 50
+	// pengiriman yang sedang berjalan diambil lagi setelah lease habis jika worker berhenti sebelum mencatat hasilnya
+	webhookLease = 5 * time.Minute
+)
+
+// webhookPayload adalah body json yang dikirim ke setiap webhook
+type webhookPayload struct {
+	Event     string      `json:"event"`
+	BoardID   uint64      `json:"board_id"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+type webhookService struct {
+	webhookRepository repository.WebhookRepository
+	client            *http.Client
+	workers           int
+	maxAttempts       int
+	retryBase         time.Duration
+	retryMax          time.Duration
+	scheduled         chan struct{}
+}
+
+func NewWebhookService(webhookRepository repository.WebhookRepository) WebhookService {
+	workers := helper.IntFromEnv("WEBHOOK_WORKERS", defaultWebhookWorkers)
+	if workers <= 0 {
+		workers = defaultWebhookWorkers
+	}
+	maxAttempts := helper.IntFromEnv("WEBHOOK_MAX_ATTEMPTS", defaultWebhookMaxAttempts)
+	if maxAttempts <= 0 {
+		maxAttempts = defaultWebhookMaxAttempts
+	}
+	retryBase := helper.DurationFromEnv("WEBHOOK_RETRY_BASE", defaultWebhookRetryBase)
+	if retryBase == 0 {
+		retryBase = defaultWebhookRetryBase
+	}
+	timeout := helper.DurationFromEnv("WEBHOOK_TIMEOUT", defaultWebhookTimeout)
+	if timeout == 0 {
+		timeout = defaultWebhookTimeout
+	}
+
+	return &webhookService{
+		webhookRepository: webhookRepository,
+		// penerima hanya boleh di alamat publik, respons yang dicatat tidak pernah berasal dari layanan internal
+		client:      helper.NewPublicHTTPClient(timeout),
+		workers:     workers,
+		maxAttempts: maxAttempts,
+		retryBase:   retryBase,
+		retryMax:    max(helper.DurationFromEnv("WEBHOOK_RETRY_MAX", defaultWebhookRetryMax), retryBase),
+		scheduled:   make(chan struct{}, 1),
+	}
+}
+
+// authorizeBoard memastikan board ada dan user adalah owner-nya
+func (w *webhookService) authorizeBoard(userID uint64, boardID uint64) error {
+	ownerID, err := w.webhookRepository.FindBoardOwner(boardID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrBoardNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if ownerID != userID {
+		return ErrNotBoardOwner
+	}
+	return nil
+}
+
+func (w *webhookService) findWebhook(userID uint64, boardID uint64, webhookID uint64) (*domain.Webhook, error) {
+	if err := w.authorizeBoard(userID, boardID); err != nil {
+		return nil, err
+	}
+	webhook, err := w.webhookRepository.FindByID(boardID, webhookID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrWebhookNotFound
+	}
+	return webhook, err
+}
+
+func (w *webhookService) CreateWebhook(userID uint64, boardID uint64, request *web.CreateWebhookRequest) (*web.CreatedWebhookResponse, error) {
+	if err := w.authorizeBoard(userID, boardID); err != nil {
+		return nil, err
+	}
+	if !helper.PublicWebhookURL(request.URL) {
+		return nil, ErrInvalidWebhookURL
+	}
+
+	events := slices.Clone(request.Events)
+	if len(events) == 0 {
+		events = slices.Clone(domain.WebhookEvents)
+	}
+	for _, event := range events {
+		if !slices.Contains(domain.WebhookEvents, event) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidWebhookEvent, event)
+		}
+	}
+	slices.Sort(events)
+
+	secret := request.Secret
+	if secret == "" {
+		generated, err := helper.GenerateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	webhook := &domain.Webhook{
+		BoardID: boardID,
+		UserID:  userID,
+		URL:     request.URL,
+		Secret:  secret,
+		Events:  slices.Compact(events),
+		Active:  true,
+	}
+	if err := w.webhookRepository.Create(webhook); err != nil {
+		return nil, err
+	}
+	return &web.CreatedWebhookResponse{Webhook: *webhook, Secret: secret}, nil
+}
+
+func (w *webhookService) GetWebhooks(userID uint64, boardID uint64) ([]domain.Webhook, error) {
+	if err := w.authorizeBoard(userID, boardID); err != nil {
+		return nil, err
+	}
+	return w.webhookRepository.FindByBoard(boardID)
+}
+
+func (w *webhookService) DeleteWebhook(userID uint64, boardID uint64, webhookID uint64) error {
+	if _, err := w.findWebhook(userID, boardID, webhookID); err != nil {
+		return err
+	}
+	return w.webhookRepository.Delete(webhookID)
+}
+
+func (w *webhookService) GetDeliveries(userID uint64, boardID uint64, webhookID uint64, limit int, offset int) ([]domain.WebhookDelivery, int64, error) {
+	if _, err := w.findWebhook(userID, boardID, webhookID); err != nil {
+		return nil, 0, err
+	}
+	return w.webhookRepository.FindDeliveries(webhookID, limit, offset)
+}
+
+func (w *webhookService) GetDelivery(userID uint64, boardID uint64, webhookID uint64, deliveryID uint64) (*domain.WebhookDelivery, error) {
+	if _, err := w.findWebhook(userID, boardID, webhookID); err != nil {
+		return nil, err
+	}
+	delivery, err := w.webhookRepository.FindDelivery(webhookID, deliveryID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+	return delivery, err
+}
+
+// Redeliver mengirim ulang payload sebuah pengiriman sebagai pengiriman baru ke url webhook saat ini,
+// signature dihitung ulang dengan secret webhook saat ini
+func (w *webhookService) Redeliver(userID uint64, boardID uint64, webhookID uint64, deliveryID uint64) (*domain.WebhookDelivery, error) {
+	webhook, err := w.findWebhook(userID, boardID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	original, err := w.webhookRepository.FindDelivery(webhookID, deliveryID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &domain.WebhookDelivery{
+		WebhookID:    webhook.ID,
+		Event:        original.Event,
+		URL:          webhook.URL,
+		Payload:      original.Payload,
+		Signature:    helper.SignWebhookPayload(webhook.Secret, []byte(original.Payload)),
+		RedeliveryOf: &original.ID,
+	}
+	if err := w.webhookRepository.CreateDeliveries([]*domain.WebhookDelivery{delivery}); err != nil {
+		return nil, err
+	}
+	w.Schedule()
+	return delivery, nil
+}
+
+// Dispatch mencatat pengiriman event ke semua webhook aktif pada board yang melanggan event tersebut. Perubahan yang
+// memicu event sudah tersimpan, sehingga kegagalan di sini hanya dicatat ke log
+func (w *webhookService) Dispatch(boardID uint64, event string, data interface{}) {
+	webhooks, err := w.webhookRepository.FindSubscribed(boardID, event)
+	if err != nil {
+		log.Printf("Failed to find webhooks for %s on board %d: %v", event, boardID, err)
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(webhookPayload{Event: event, BoardID: boardID, CreatedAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		log.Printf("Failed to encode %s webhook payload: %v", event, err)
+		return
+	}
+
+	deliveries := make([]*domain.WebhookDelivery, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		deliveries = append(deliveries, &domain.WebhookDelivery{
+			WebhookID: webhook.ID,
+			Event:     event,
+			URL:       webhook.URL,
+			Payload:   string(payload),
+			Signature: helper.SignWebhookPayload(webhook.Secret, payload),
+		})
+	}
+	if err := w.webhookRepository.CreateDeliveries(deliveries); err != nil {
+		log.Printf("Failed to record %s webhook deliveries for board %d: %v", event, boardID, err)
+		return
+	}
+	w.Schedule()
+}
+
+// DeleteBoardWebhooks menghapus webhook milik board yang sudah dihapus, dipanggil setelah event board.deleted dicatat
+func (w *webhookService) DeleteBoardWebhooks(boardID uint64) {
+	if err := w.webhookRepository.DeleteByBoard(boardID); err != nil {
+		log.Printf("Failed to delete webhooks of board %d: %v", boardID, err)
+	}
+}
+
+// Schedule membangunkan worker webhook setelah ada pengiriman baru, tidak menunggu jika worker sudah dijadwalkan
+func (w *webhookService) Schedule() {
+	select {
+	case w.scheduled <- struct{}{}:
+	default:
+	}
+}
+
+func (w *webhookService) Scheduled() <-chan struct{} {
+	return w.scheduled
+}
+
+// DeliverPending mengirim semua pengiriman yang sudah waktunya dengan WEBHOOK_WORKERS pengiriman bersamaan
+// dan mengembalikan jumlah pengiriman yang berhasil
+func (w *webhookService) DeliverPending() (int, error) {
+	delivered := 0
+	for {
+		deliveries, err := w.webhookRepository.ClaimDue(webhookBatchSize, webhookLease)
+		if err != nil {
+			return delivered, err
+		}
+		if len(deliveries) == 0 {
+			return delivered, nil
+		}
+
+		var (
+			wg   sync.WaitGroup
+			mu   sync.Mutex
+			jobs = make(chan domain.WebhookDelivery)
+		)
+		for i := 0; i < min(w.workers, len(deliveries)); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for delivery := range jobs {
+					if w.deliver(delivery) {
+						mu.Lock()
+						delivered++
+						mu.Unlock()
+					}
+				}
+			}()
+		}
+		for _, delivery := range deliveries {
+			jobs <- delivery
+		}
+		close(jobs)
+		wg.Wait()
+	}
+}
+
+// deliver mengirim satu pengiriman dan mencatat percobaannya. Kegagalan dicoba lagi dengan jeda yang berlipat dua
+// sampai WEBHOOK_MAX_ATTEMPTS, setelah itu pengiriman menjadi failed dan hanya bisa dikirim ulang lewat redeliver
+func (w *webhookService) deliver(delivery domain.WebhookDelivery) bool {
+	response, sendErr := helper.SendWebhook(context.Background(), w.client, delivery.URL, delivery.Event, delivery.ID, []byte(delivery.Payload), delivery.Signature)
+
+	attempt := &domain.WebhookDeliveryAttempt{DeliveryID: delivery.ID, Attempt: delivery.Attempts}
+	delivery.ResponseStatus = 0
+	if response != nil {
+		attempt.ResponseStatus = response.Status
+		attempt.ResponseBody = response.Body
+		attempt.DurationMs = response.Duration.Milliseconds()
+		delivery.ResponseStatus = response.Status
+	}
+
+	if sendErr == nil {
+		now := time.Now()
+		delivery.Status = domain.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+	} else {
+		attempt.Error = sendErr.Error()
+		delivery.LastError = sendErr.Error()
+		delivery.Status = domain.WebhookDeliveryPending
+		delivery.NextAttemptAt = time.Now().Add(helper.RetryBackoff(w.retryBase, w.retryMax, delivery.Attempts))
+		if delivery.Attempts >= w.maxAttempts {
+			delivery.Status = domain.WebhookDeliveryFailed
+			log.Printf("Webhook delivery %d (%s) failed %d times: %v", delivery.ID, delivery.Event, delivery.Attempts, sendErr)
+		}
+	}
+
+	if err := w.webhookRepository.RecordAttempt(&delivery, attempt); err != nil {
+		log.Printf("Failed to record webhook delivery %d: %v", delivery.ID, err)
+	}
+	return sendErr == nil
+}